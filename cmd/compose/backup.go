@@ -0,0 +1,203 @@
+package compose
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/containers"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/ui"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+var (
+	backupDirFlag   string
+	backupKeepFlag  int
+	backupPauseFlag bool
+	backupLiveFlag  bool
+	restoreYesFlag  bool
+	restoreListFlag bool
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup <stack>",
+	Short: "Archive the volumes and bind mounts of a Compose stack",
+	Long: `Archive the named volumes and bind mounts of a Compose stack, as declared in its compose file.
+
+The stack is stopped while its data is archived, or paused with --pause, and started again afterwards
+if it was running. Each volume is archived by a short-lived helper container to a tar.gz file in a
+timestamped directory under <containers.backup_path>/<stack>, next to a manifest.json and SHA256SUMS
+of the archives. Afterwards, only the newest --keep backups of the stack are kept.
+
+Bind mounts whose paths use variables, and sockets or devices, are skipped and reported.`,
+	Example: `  eng compose backup web
+  eng compose backup web --pause --keep 14
+  eng compose backup web --dir /mnt/backups/containers`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.Primary).
+			MarginBottom(1)
+		if !ui.DisableProgress {
+			fmt.Fprintln(log.Out, headerStyle.Render("📦 Backing Up Docker Compose Stack"))
+		}
+
+		cfg := config.GetContainersConfig()
+		mgr := containers.NewManager(cfg.Path)
+
+		keep := cfg.BackupRetention
+		if cmd.Flags().Changed("keep") {
+			keep = backupKeepFlag
+		}
+
+		theme.InfoMessage(fmt.Sprintf("Backing up stack %s...", args[0]))
+		res, err := mgr.Backup(args[0], containers.BackupOptions{
+			Dir:   backupDir(cfg),
+			Keep:  keep,
+			Pause: backupPauseFlag,
+			Live:  backupLiveFlag,
+		})
+		if res != nil {
+			for _, skipped := range res.Skipped {
+				log.Warn("Skipped %s", skipped)
+			}
+		}
+		if res == nil {
+			return fmt.Errorf("backup failed: %w", err)
+		}
+
+		for _, v := range res.Manifest.Volumes {
+			log.Info("Archived %s %s → %s (%s)", v.Kind, v.Name, v.Archive, humanize.Bytes(uint64(v.Size)))
+		}
+		for _, path := range res.Removed {
+			log.Info("Removed old backup %s", filepath.Base(path))
+		}
+		if err != nil {
+			return fmt.Errorf("backed up stack %s to %s, but: %w", res.Manifest.Stack, res.Path, err)
+		}
+
+		theme.SuccessMessage(fmt.Sprintf("Backed up %d volume(s) of stack %s to %s.",
+			len(res.Manifest.Volumes), res.Manifest.Stack, res.Path))
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <stack> [backup]",
+	Short: "Restore the volumes and bind mounts of a Compose stack from a backup",
+	Long: `Restore the volumes and bind mounts of a Compose stack from a backup made by 'eng compose backup':
+the latest, or the one named by its directory name or path. Use --list to show the backups.
+
+The checksums of every archive are verified first. The stack is then stopped, the current data of
+each volume is replaced with the archived data, and the stack is started again if it was running.
+
+Only the volumes and bind mounts the stack's current compose file declares are restored, to the paths
+it declares; a backup of a volume the compose file no longer declares is refused.`,
+	Example: `  eng compose restore web --list
+  eng compose restore web
+  eng compose restore web 20261017-031500 --yes`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.Primary).
+			MarginBottom(1)
+		if !ui.DisableProgress {
+			fmt.Fprintln(log.Out, headerStyle.Render("♻️ Restoring Docker Compose Stack"))
+		}
+
+		cfg := config.GetContainersConfig()
+		mgr := containers.NewManager(cfg.Path)
+		stack := args[0]
+
+		if restoreListFlag {
+			return listBackups(mgr, stack, backupDir(cfg))
+		}
+
+		var name string
+		if len(args) > 1 {
+			name = args[1]
+		}
+		b, err := mgr.FindBackup(stack, name, backupDir(cfg))
+		if err != nil {
+			return err
+		}
+
+		if !restoreYesFlag {
+			msg := fmt.Sprintf("Replace the data of %d volume(s) of stack %s with backup %s?",
+				len(b.Manifest.Volumes), stack, filepath.Base(b.Path))
+			confirmed, err := ui.Confirm(msg, false)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				theme.WarningMessage("Restore cancelled.")
+				return nil
+			}
+		}
+
+		theme.InfoMessage(fmt.Sprintf("Restoring stack %s from %s...", stack, filepath.Base(b.Path)))
+		manifest, err := mgr.Restore(stack, b.Path, backupDir(cfg))
+		if err != nil {
+			return fmt.Errorf("restore failed: %w", err)
+		}
+
+		for _, v := range manifest.Volumes {
+			log.Info("Restored %s %s", v.Kind, v.Name)
+		}
+		theme.SuccessMessage(fmt.Sprintf("Restored %d volume(s) of stack %s.", len(manifest.Volumes), stack))
+		return nil
+	},
+}
+
+// listBackups logs the backups of a stack, newest first.
+func listBackups(mgr *containers.Manager, stack, dir string) error {
+	backups, err := mgr.ListBackups(stack, dir)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		theme.WarningMessage(fmt.Sprintf("No backups of stack %s found.", stack))
+		return nil
+	}
+
+	for _, b := range backups {
+		var size int64
+		names := make([]string, 0, len(b.Manifest.Volumes))
+		for _, v := range b.Manifest.Volumes {
+			size += v.Size
+			names = append(names, v.Name)
+		}
+		log.Info("%s  %s  %s", filepath.Base(b.Path), humanize.Bytes(uint64(size)), strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// backupDir returns the --dir flag, or the configured backup path.
+func backupDir(cfg config.ContainersConfig) string {
+	if backupDirFlag != "" {
+		return backupDirFlag
+	}
+	return cfg.BackupPath
+}
+
+func init() {
+	backupCmd.Flags().StringVar(&backupDirFlag, "dir", "", "Backup root directory (default <containers.path>/backups)")
+	backupCmd.Flags().IntVarP(&backupKeepFlag, "keep", "k", 0,
+		fmt.Sprintf("Backups of the stack to keep, 0 for all (default containers.backup_retention or %d)",
+			config.DefaultBackupRetention))
+	backupCmd.Flags().BoolVar(&backupPauseFlag, "pause", false, "Pause the stack instead of stopping it")
+	backupCmd.Flags().BoolVar(&backupLiveFlag, "live", false, "Back up without stopping or pausing the stack")
+	backupCmd.MarkFlagsMutuallyExclusive("pause", "live")
+
+	restoreCmd.Flags().StringVar(&backupDirFlag, "dir", "", "Backup root directory (default <containers.path>/backups)")
+	restoreCmd.Flags().BoolVarP(&restoreYesFlag, "yes", "y", false, "Restore without asking for confirmation")
+	restoreCmd.Flags().BoolVarP(&restoreListFlag, "list", "l", false, "List the backups of the stack")
+}
@@ -21,4 +21,8 @@ func init() {
 	ComposeCmd.AddCommand(pullCmd)
 	ComposeCmd.AddCommand(statusCmd)
 	ComposeCmd.AddCommand(logsCmd)
+	ComposeCmd.AddCommand(newCmd)
+	ComposeCmd.AddCommand(envCmd)
+	ComposeCmd.AddCommand(backupCmd)
+	ComposeCmd.AddCommand(restoreCmd)
 }
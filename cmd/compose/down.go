@@ -21,6 +21,10 @@ var (
 var downCmd = &cobra.Command{
 	Use:   "down [stack...]",
 	Short: "Spin down one or more Compose stacks",
+	Long: `Spin down one or more Compose stacks.
+
+Stacks are stopped in reverse dependency order, as declared by depends_on in their stack.yaml
+files, so stacks that depend on others stop first.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		headerStyle := lipgloss.NewStyle().
 			Bold(true).
@@ -0,0 +1,177 @@
+package compose
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/containers"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/ui"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+var (
+	envCheckEnvFlag    string
+	envCheckStrictFlag bool
+	envDiffShowValues  bool
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Validate and compare the env files of Compose stacks",
+	Long: `Validate and compare the .env.<env> files that 'eng compose up -e <env>' passes to
+docker compose.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var envCheckCmd = &cobra.Command{
+	Use:   "check [stack...]",
+	Short: "Report variables a compose file uses that its env file does not set",
+	Long: `Check the variables referenced by the compose files of the given stacks, or of all stacks,
+against the env file 'eng compose up' would use: .env.<env>, or .env when it does not exist.
+
+References are ${VAR}, $VAR, and the default (${VAR:-default}, ${VAR-default}), required
+(${VAR:?message}, ${VAR?message}), and alternate (${VAR:+value}, ${VAR+value}) forms.
+
+  missing   a reference without a default whose variable is not set, or is empty for ${VAR:?}
+  default   a variable that is not set, so the reference's default applies
+  unused    a key of the env file that the compose file never references
+
+The command fails when a variable is missing, or with --strict when a key is unused.`,
+	Example: `  eng compose env check --env prod
+  eng compose env check web -e dev --strict`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.Primary).
+			MarginBottom(1)
+		if !ui.DisableProgress {
+			fmt.Fprintln(log.Out, headerStyle.Render("🔎 Checking Compose Env Files"))
+		}
+
+		cfg := config.GetContainersConfig()
+		mgr := containers.NewManager(cfg.Path)
+
+		checks, err := mgr.CheckEnv(args, envCheckEnvFlag)
+		if err != nil {
+			return err
+		}
+
+		missing, unused := 0, 0
+		for _, c := range checks {
+			missing += len(c.Missing)
+			unused += len(c.Unused)
+			printEnvCheck(c, envCheckEnvFlag)
+		}
+
+		if missing > 0 {
+			return theme.NewActionableError(
+				fmt.Errorf("%d variable(s) missing for env %s", missing, envCheckEnvFlag),
+				fmt.Sprintf("Set them in the .env.%s file of each stack, or give them a default.", envCheckEnvFlag),
+			)
+		}
+		if envCheckStrictFlag && unused > 0 {
+			return fmt.Errorf("%d unused key(s) in env files for env %s", unused, envCheckEnvFlag)
+		}
+
+		theme.SuccessMessage(fmt.Sprintf("Checked %d stack(s) for env %s.", len(checks), envCheckEnvFlag))
+		return nil
+	},
+}
+
+// printEnvCheck logs the result of checking the env file of a stack.
+func printEnvCheck(c containers.EnvCheck, envName string) {
+	composeFile := filepath.Base(c.File)
+	envFile := filepath.Base(c.EnvFile)
+	switch {
+	case c.EnvFile == "":
+		envFile = "env file"
+		log.Warn("%s: no .env.%s or .env file, only defaults apply", c.Stack, envName)
+	case c.Fallback && envName != "":
+		log.Warn("%s: no .env.%s, falling back to .env", c.Stack, envName)
+	}
+
+	for _, ref := range c.Missing {
+		msg := fmt.Sprintf("%s: %s is not set in %s (%s:%d)", c.Stack, ref.Name, envFile, composeFile, ref.Line)
+		if strings.Contains(ref.Operator, "?") && ref.Value != "" {
+			msg += ": " + ref.Value
+		}
+		log.Error("%s", msg)
+	}
+	for _, ref := range c.Defaulted {
+		log.Info("%s: %s is not set, its default applies (%s:%d)", c.Stack, ref.Name, composeFile, ref.Line)
+	}
+	for _, key := range c.Unused {
+		log.Warn("%s: %s in %s is not used by %s", c.Stack, key, envFile, composeFile)
+	}
+
+	if len(c.Missing) == 0 {
+		log.Success("%s: all referenced variables are set or have defaults", c.Stack)
+	}
+}
+
+var envDiffCmd = &cobra.Command{
+	Use:   "diff <env> <env> [stack...]",
+	Short: "Compare the env files of two environments key by key",
+	Long: `Compare, key by key, the env files 'eng compose up' would use for two environments in the
+given stacks, or in all stacks. Keys that differ, or are set in only one environment, are listed with
+their values masked; use --show-values to reveal them.`,
+	Example: `  eng compose env diff dev prod
+  eng compose env diff staging prod web --show-values`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.Primary).
+			MarginBottom(1)
+		if !ui.DisableProgress {
+			fmt.Fprintln(log.Out, headerStyle.Render("🔀 Comparing Compose Env Files"))
+		}
+
+		cfg := config.GetContainersConfig()
+		mgr := containers.NewManager(cfg.Path)
+
+		envA, envB := args[0], args[1]
+		diffs, err := mgr.DiffEnv(args[2:], envA, envB)
+		if err != nil {
+			return err
+		}
+
+		differ := 0
+		for _, d := range diffs {
+			for _, e := range d.Entries {
+				if e.Differ {
+					differ++
+				}
+			}
+			for _, env := range [][2]string{{envA, d.FileA}, {envB, d.FileB}} {
+				if env[1] == "" {
+					log.Warn("%s: no .env.%s or .env file", d.Stack, env[0])
+				} else if filepath.Base(env[1]) == ".env" {
+					log.Warn("%s: no .env.%s, comparing .env", d.Stack, env[0])
+				}
+			}
+			fmt.Fprintln(log.Out, ui.RenderEnvDiffTable(d, envA, envB, envDiffShowValues, ui.GetTerminalWidth()))
+		}
+
+		theme.InfoMessage(fmt.Sprintf("%d key(s) differ between %s and %s across %d stack(s).",
+			differ, envA, envB, len(diffs)))
+		return nil
+	},
+}
+
+func init() {
+	envCheckCmd.Flags().StringVarP(&envCheckEnvFlag, "env", "e", "prod", "Target environment (e.g. dev, staging, prod)")
+	envCheckCmd.Flags().BoolVar(&envCheckStrictFlag, "strict", false, "Also fail when an env file has unused keys")
+	envDiffCmd.Flags().BoolVar(&envDiffShowValues, "show-values", false, "Show values instead of masking them")
+
+	envCmd.AddCommand(envCheckCmd)
+	envCmd.AddCommand(envDiffCmd)
+}
@@ -0,0 +1,90 @@
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/containers"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/ui"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+var (
+	servicesFlag  []string
+	dependsOnFlag []string
+	forceFlagNew  bool
+)
+
+var newCmd = &cobra.Command{
+	Use:   "new <stack>",
+	Short: "Scaffold a new Compose stack from templates",
+	Long: `Scaffold a new Compose stack at <containers.path>/stacks/<stack>.
+
+The generated docker-compose.yml joins every service to the shared eng-shared-net network under a
+<stack>-<service> alias. Alongside it, a .env.example documents
+every variable, and .env.dev and .env.prod skeletons are created for 'eng compose up -e dev|prod'.
+Existing .env.dev and .env.prod files are never overwritten.
+
+Service templates:
+` + serviceTemplateList(),
+	Example: `  eng compose new web
+  eng compose new web --service app,postgres,redis --depends-on proxy
+  eng compose new proxy --service traefik`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.Primary).
+			MarginBottom(1)
+		if !ui.DisableProgress {
+			fmt.Fprintln(log.Out, headerStyle.Render("🧱 Scaffolding Docker Compose Stack"))
+		}
+
+		cfg := config.GetContainersConfig()
+		mgr := containers.NewManager(cfg.Path)
+
+		name := args[0]
+		written, err := mgr.NewStack(name, containers.NewStackOptions{
+			Services:  servicesFlag,
+			DependsOn: dependsOnFlag,
+			Force:     forceFlagNew,
+		})
+		if err != nil {
+			if errors.Is(err, containers.ErrStackExists) {
+				return theme.NewActionableError(err, "Use --force to regenerate its compose file and .env.example.")
+			}
+			return err
+		}
+
+		for _, file := range written {
+			log.Info("Wrote %s", file)
+		}
+		theme.SuccessMessage(fmt.Sprintf("Stack %s created in %s.", name, filepath.Dir(written[0])))
+		theme.InfoMessage(fmt.Sprintf("Fill in .env.dev, then run 'eng compose up %s -e dev'.", name))
+		return nil
+	},
+}
+
+// serviceTemplateList lists the service templates for the command's help.
+func serviceTemplateList() string {
+	var b strings.Builder
+	for _, t := range containers.ServiceTemplates() {
+		fmt.Fprintf(&b, "  %-9s %s\n", t.Name, t.Description)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func init() {
+	newCmd.Flags().StringSliceVarP(&servicesFlag, "service", "s", nil,
+		"Service templates to include (default "+containers.DefaultServiceTemplate+")")
+	newCmd.Flags().
+		StringSliceVar(&dependsOnFlag, "depends-on", nil, "Stacks this stack depends on, written to stack.yaml")
+	newCmd.Flags().BoolVarP(&forceFlagNew, "force", "f", false, "Overwrite the compose file of an existing stack")
+}
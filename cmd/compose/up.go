@@ -1,7 +1,11 @@
 package compose
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -14,15 +18,36 @@ import (
 )
 
 var (
-	envFlag    string
-	allFlagUp  bool
-	detachFlag bool
-	buildFlag  bool
+	envFlag      string
+	allFlagUp    bool
+	detachFlag   bool
+	buildFlag    bool
+	waitFlag     bool
+	timeoutFlag  time.Duration
+	logLinesFlag int
 )
 
 var upCmd = &cobra.Command{
 	Use:   "up [stack...]",
 	Short: "Spin up one or more Compose stacks",
+	Long: `Spin up one or more Compose stacks.
+
+Stacks may declare the stacks they need in a stack.yaml file next to their compose file:
+
+  depends_on:
+    - proxy
+    - db
+
+The stacks a target depends on are started first, and each must become healthy before the stacks
+that depend on it start. Dependency cycles are reported as errors.
+
+With --wait, the command also waits until every container is running and, if it has a health check,
+healthy, showing a live table of the services. One-shot containers that exited with code 0, such as
+init or migration services, count as ready. If --timeout passes first, the last log lines of the
+services that are not ready are printed and the command exits with an error.`,
+	Example: `  eng compose up proxy db app
+  eng compose up app --wait --timeout 5m
+  eng compose up -a --wait --log-lines 100`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		headerStyle := lipgloss.NewStyle().
 			Bold(true).
@@ -36,27 +61,91 @@ var upCmd = &cobra.Command{
 			return fmt.Errorf("specify at least one stack name or use --all (-a)")
 		}
 
+		if waitFlag && !detachFlag {
+			return fmt.Errorf("--wait requires running detached (--detach)")
+		}
+
 		cfg := config.GetContainersConfig()
 		mgr := containers.NewManager(cfg.Path)
+		mgr.HealthTimeout = timeoutFlag
 
 		targets := args
 		if allFlagUp {
 			targets = []string{"all"}
 		}
 
+		order, err := mgr.OrderStacks(targets, true)
+		if err != nil {
+			return err
+		}
+		names := make([]string, len(order))
+		for i, s := range order {
+			names[i] = s.Name
+		}
+		if len(order) > 1 {
+			theme.InfoMessage("Start order: " + strings.Join(names, " → "))
+		}
+
 		theme.InfoMessage(fmt.Sprintf("Spinning up stack(s) (env: %s)...", envFlag))
 		if err := mgr.Up(targets, envFlag, detachFlag, buildFlag); err != nil {
 			return err
 		}
 
 		theme.SuccessMessage("Compose stack(s) started successfully.")
+		if !waitFlag {
+			return nil
+		}
+
+		block := ui.NewLiveBlock()
+		err = mgr.WaitHealthy(names, timeoutFlag, func(details map[string][]containers.ContainerDetail) {
+			block.Update(ui.RenderHealthTable(names, details, ui.GetTerminalWidth()))
+		})
+		var unhealthy *containers.UnhealthyError
+		if errors.As(err, &unhealthy) {
+			printUnhealthyLogs(mgr, unhealthy, logLinesFlag)
+		}
+		if err != nil {
+			return err
+		}
+
+		theme.SuccessMessage("All services are healthy.")
 		return nil
 	},
 }
 
+// printUnhealthyLogs prints the last log lines of the services that did not become healthy.
+func printUnhealthyLogs(mgr *containers.Manager, unhealthy *containers.UnhealthyError, lines int) {
+	stackNames := make([]string, 0, len(unhealthy.Pending))
+	for name := range unhealthy.Pending {
+		stackNames = append(stackNames, name)
+	}
+	sort.Strings(stackNames)
+
+	for _, name := range stackNames {
+		services := unhealthy.Services(name)
+		target := name
+		if len(services) > 0 {
+			target = fmt.Sprintf("%s (%s)", name, strings.Join(services, ", "))
+		}
+		log.Warn("Last %d log lines of %s:", lines, target)
+
+		logs, err := mgr.ServiceLogs(name, services, lines)
+		if err != nil {
+			log.Error("%v", err)
+		}
+		if logs = strings.TrimRight(logs, "\n"); logs != "" {
+			fmt.Fprintln(log.Out, logs)
+		}
+	}
+}
+
 func init() {
 	upCmd.Flags().StringVarP(&envFlag, "env", "e", "prod", "Target environment (e.g. dev, staging, prod)")
 	upCmd.Flags().BoolVarP(&allFlagUp, "all", "a", false, "Spin up all discovered stacks")
 	upCmd.Flags().BoolVarP(&detachFlag, "detach", "d", true, "Run containers in background")
 	upCmd.Flags().BoolVar(&buildFlag, "build", false, "Build images before starting containers")
+	upCmd.Flags().BoolVar(&waitFlag, "wait", false, "Wait for all services to be running and healthy")
+	upCmd.Flags().DurationVar(&timeoutFlag, "timeout", containers.DefaultHealthTimeout,
+		"How long to wait for services, and for dependencies to become healthy")
+	upCmd.Flags().IntVar(&logLinesFlag, "log-lines", 50, "Log lines to print of unhealthy services on timeout")
 }
@@ -0,0 +1,73 @@
+package compose
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/ui"
+)
+
+func TestUpWaitTimeoutPrintsLogs(t *testing.T) {
+	tempDir := t.TempDir()
+	stackDir := filepath.Join(tempDir, "stacks", "web")
+	if err := os.MkdirAll(stackDir, 0o755); err != nil {
+		t.Fatalf("failed to create stack dir: %v", err)
+	}
+	compose := []byte("services:\n  app:\n    image: nginx\n  db:\n    image: postgres\n")
+	if err := os.WriteFile(filepath.Join(stackDir, "docker-compose.yml"), compose, 0o644); err != nil {
+		t.Fatalf("failed to write compose file: %v", err)
+	}
+
+	// A fake docker records its arguments, reports db as unhealthy, and prints its logs.
+	binDir := t.TempDir()
+	dockerLog := filepath.Join(tempDir, "docker.log")
+	script := `#!/bin/sh
+echo "$@" >> "` + dockerLog + `"
+case "$*" in
+  *"ps --format json"*)
+    echo '{"Name":"web-app-1","Service":"app","State":"running"}'
+    echo '{"Name":"web-db-1","Service":"db","State":"running","Health":"unhealthy"}';;
+  *" logs "*) echo "db-1  | FATAL: password authentication failed";;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "docker"), []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to write fake docker: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	viper.Set("containers.path", tempDir)
+	defer viper.Set("containers.path", "")
+
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+	oldOut, oldDisable := ui.Out, ui.DisableProgress
+	ui.Out, ui.DisableProgress = &buf, true
+	defer func() { ui.Out, ui.DisableProgress = oldOut, oldDisable }()
+
+	ComposeCmd.SetOut(&buf)
+	ComposeCmd.SetErr(&buf)
+	ComposeCmd.SetArgs([]string{"up", "web", "--wait", "--timeout", "1ms", "--log-lines", "5"})
+	err := ComposeCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "web-db-1 (running, unhealthy)") {
+		t.Fatalf("expected a health timeout naming web-db-1, got %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"1/2 ready", "Last 5 log lines of web (db)", "password authentication failed"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	calls, _ := os.ReadFile(dockerLog)
+	if !strings.Contains(string(calls), "logs --no-color --tail 5 db") {
+		t.Errorf("expected the logs of db to be read, got:\n%s", calls)
+	}
+}
@@ -9,19 +9,44 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/eng618/eng/internal/cmdutil"
 	"github.com/eng618/eng/internal/config"
 	"github.com/eng618/eng/internal/ui"
 	"github.com/eng618/eng/internal/ui/dashboard"
 )
 
+var (
+	snapshotFlag      bool
+	snapshotRefresh   bool
+	snapshotWidthFlag int
+)
+
 // dashboardCmd represents the dashboard command.
 var dashboardCmd = &cobra.Command{
 	Use:   "dashboard",
 	Short: "Launch the interactive Project & Git Dashboard",
 	Long: `Opens a full-screen "mission control" interface to view all configured projects.
 It displays your projects in a list, and shows the live status of their repositories
-(cloned state, current branch, and uncommitted changes) in real-time.`,
+(cloned state, current branch, and uncommitted changes) in real-time.
+
+With --snapshot, the same statuses are collected once, printed as static tables, and the command
+exits; with --output json or yaml, they are printed as structured data instead. No terminal is
+needed, so snapshots suit tmux status lines, MOTD scripts, and cron emails.`,
+	Example: `  eng dashboard --snapshot
+  eng dashboard --snapshot --refresh --width 120
+  eng dashboard -o json | jq '.needs_attention'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmdutil.GetOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		if snapshotFlag || format.IsStructured() {
+			return dashboard.Snapshot(cmd.OutOrStdout(), dashboard.SnapshotOptions{
+				Format:  format,
+				Refresh: snapshotRefresh,
+				Width:   snapshotWidthFlag,
+			})
+		}
 		return dashboard.Run()
 	},
 }
@@ -144,5 +169,10 @@ var selectEditorCmd = &cobra.Command{
 }
 
 func init() {
+	dashboardCmd.Flags().BoolVar(&snapshotFlag, "snapshot", false, "Print a static snapshot of all projects and exit")
+	cmdutil.AddOutputFlag(dashboardCmd)
+	dashboardCmd.Flags().
+		BoolVar(&snapshotRefresh, "refresh", false, "Query git directly instead of using cached statuses")
+	dashboardCmd.Flags().IntVar(&snapshotWidthFlag, "width", 100, "Width of the --snapshot tables")
 	dashboardCmd.AddCommand(selectEditorCmd)
 }
@@ -0,0 +1,338 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+// ExecAllCmd defines the cobra command for running an arbitrary command in every git repository.
+var ExecAllCmd = &cobra.Command{
+	Use:   "exec-all [flags] -- <command> [args...]",
+	Short: "Run a command in every git repository in development folder",
+	Long: `This command runs the given command inside each git repository found in your development folder.
+
+Repositories are processed concurrently. Each repository's output is buffered and printed
+as a single block once its command finishes, so lines from different repositories never
+interleave. A summary of exit codes is printed at the end and the command exits non-zero
+if any repository failed.
+
+Use --filter to limit which repositories run the command. Filters can be repeated and
+all of them must match:
+  path=<glob>     Match the repository name or full path (e.g. path=api-*)
+  branch=<glob>   Match the current branch (e.g. branch=feature/*)
+  dirty=<bool>    Match repositories with (true) or without (false) uncommitted changes
+
+Example:
+  eng git exec-all -- git log -1 --oneline
+  eng git exec-all --filter dirty=true -- git status --short
+  eng git exec-all --filter path=*-service --shell -- "go mod tidy && go test ./..."`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		printHeader("⚡ Running Command Across Repositories")
+
+		setup, err := setupGitCommand(cmd)
+		if err != nil {
+			return err
+		}
+
+		rawFilters, _ := cmd.Flags().GetStringArray("filter")
+		filters, err := parseExecFilters(rawFilters)
+		if err != nil {
+			return err
+		}
+
+		useShell, _ := cmd.Flags().GetBool("shell")
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		if jobs < 1 {
+			jobs = 1
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = cmdutil.FallbackContext()
+		}
+
+		repos, err := findGitRepositories(setup.DevPath)
+		if err != nil {
+			return fmt.Errorf("failed to find git repositories: %w", err)
+		}
+
+		repos = filterExecRepos(ctx, repos, filters)
+		if len(repos) == 0 {
+			log.Warn("No git repositories in %s match the given filters", setup.DevPath)
+			return nil
+		}
+
+		commandLine := strings.Join(args, " ")
+		log.Info("Running `%s` in %d repositories", commandLine, len(repos))
+
+		if setup.DryRun {
+			for _, repoPath := range repos {
+				log.Info("  [DRY RUN] Would run in: %s", repoPath)
+			}
+			return nil
+		}
+
+		results := runExecAll(ctx, repos, args, useShell, jobs)
+		return summarizeExecResults(results)
+	},
+}
+
+func init() {
+	ExecAllCmd.Flags().Bool("dry-run", false, "List matching repositories without running the command")
+	ExecAllCmd.Flags().
+		StringArray("filter", nil, "Filter repositories by path=<glob>, branch=<glob>, or dirty=<bool> (repeatable)")
+	ExecAllCmd.Flags().BoolP("shell", "s", false, "Run the command through 'sh -c' to allow pipes and &&")
+	ExecAllCmd.Flags().IntP("jobs", "j", 10, "Maximum number of repositories to run concurrently")
+}
+
+// execFilter narrows the set of repositories exec-all runs in.
+type execFilter struct {
+	Key   string
+	Value string
+}
+
+// execResult captures the outcome of running the command in a single repository.
+type execResult struct {
+	Name     string
+	Path     string
+	ExitCode int
+	Output   []byte
+	Err      error
+}
+
+// parseExecFilters converts key=value filter flags into execFilters.
+func parseExecFilters(raw []string) ([]execFilter, error) {
+	filters := make([]execFilter, 0, len(raw))
+	for _, r := range raw {
+		key, value, ok := strings.Cut(r, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid filter %q (expected key=value)", r)
+		}
+
+		switch key {
+		case "path", "branch":
+			if _, err := filepath.Match(value, ""); err != nil {
+				return nil, fmt.Errorf("invalid %s pattern %q: %w", key, value, err)
+			}
+		case "dirty":
+			if _, err := strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("invalid dirty value %q (expected true or false)", value)
+			}
+		default:
+			return nil, fmt.Errorf("unknown filter %q (expected path, branch, or dirty)", key)
+		}
+
+		filters = append(filters, execFilter{Key: key, Value: value})
+	}
+	return filters, nil
+}
+
+// filterExecRepos returns the repositories matching every filter.
+// Repository status is only read when a branch or dirty filter is present, and then always fresh,
+// since a cached status can miss edits that do not touch .git.
+func filterExecRepos(ctx context.Context, repos []string, filters []execFilter) []string {
+	if len(filters) == 0 {
+		return repos
+	}
+
+	needsStatus := false
+	for _, f := range filters {
+		if f.Key != "path" {
+			needsStatus = true
+			break
+		}
+	}
+
+	var matched []string
+	for _, repoPath := range repos {
+		var status repo.StatusInfo
+		if needsStatus {
+			var err error
+			status, err = repo.RefreshDetailedStatus(ctx, repoPath)
+			if err != nil {
+				log.Warn("Skipping %s: failed to read status - %s", filepath.Base(repoPath), err)
+				continue
+			}
+		}
+
+		if matchesExecFilters(repoPath, status, filters) {
+			matched = append(matched, repoPath)
+		}
+	}
+	return matched
+}
+
+// matchesExecFilters reports whether a repository satisfies every filter.
+func matchesExecFilters(repoPath string, status repo.StatusInfo, filters []execFilter) bool {
+	for _, f := range filters {
+		switch f.Key {
+		case "path":
+			nameMatch, _ := filepath.Match(f.Value, filepath.Base(repoPath))
+			pathMatch, _ := filepath.Match(f.Value, repoPath)
+			if !nameMatch && !pathMatch {
+				return false
+			}
+		case "branch":
+			if ok, _ := filepath.Match(f.Value, status.Branch); !ok {
+				return false
+			}
+		case "dirty":
+			want, _ := strconv.ParseBool(f.Value)
+			isDirty := status.StagedCount+status.UnstagedCount+status.UntrackedCount+status.ConflictCount > 0
+			if isDirty != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// runExecAll runs the command in each repository concurrently and prints each
+// repository's buffered output as one block as soon as it finishes.
+func runExecAll(ctx context.Context, repos, args []string, useShell bool, jobs int) []execResult {
+	results := make([]execResult, len(repos))
+
+	multi, err := ui.NewMultiSpinner()
+	if err != nil {
+		log.Error("Failed to initialize UI: %s", err)
+		return results
+	}
+	defer multi.Stop()
+
+	var outputMu sync.Mutex
+
+	var eg errgroup.Group
+	eg.SetLimit(jobs)
+
+	for i, repoPath := range repos {
+		eg.Go(func() error {
+			repoName := filepath.Base(repoPath)
+			spinner := multi.AddSpinner(fmt.Sprintf("Running in %s...", repoName))
+
+			result := runInRepo(ctx, repoPath, args, useShell)
+			results[i] = result
+
+			// Print the output block and its status line together so repositories never interleave.
+			outputMu.Lock()
+			defer outputMu.Unlock()
+
+			printExecOutput(result)
+			switch {
+			case result.Err != nil:
+				spinner.Fail(fmt.Sprintf("%s: %s", repoName, result.Err))
+			case result.ExitCode != 0:
+				spinner.Fail(fmt.Sprintf("%s exited with code %d", repoName, result.ExitCode))
+			default:
+				spinner.Success(fmt.Sprintf("%s exited with code 0", repoName))
+			}
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+	return results
+}
+
+// runInRepo executes the command inside repoPath, capturing combined output and the exit code.
+func runInRepo(ctx context.Context, repoPath string, args []string, useShell bool) execResult {
+	result := execResult{Name: filepath.Base(repoPath), Path: repoPath}
+
+	var c *exec.Cmd
+	if useShell {
+		c = exec.CommandContext(ctx, "sh", "-c", strings.Join(args, " "))
+	} else {
+		c = exec.CommandContext(ctx, args[0], args[1:]...)
+	}
+	c.Dir = repoPath
+
+	var buf bytes.Buffer
+	c.Stdout = &buf
+	c.Stderr = &buf
+
+	err := c.Run()
+	result.Output = buf.Bytes()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		result.ExitCode = 0
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.ExitCode = -1
+		result.Err = err
+	}
+	return result
+}
+
+// printExecOutput writes one repository's captured output as a labeled block.
+func printExecOutput(result execResult) {
+	output := strings.TrimRight(string(result.Output), "\n")
+	if output == "" {
+		return
+	}
+
+	fmt.Fprintln(log.Out, theme.BoldText.Render(fmt.Sprintf("── %s ──", result.Name)))
+	fmt.Fprintln(log.Out, output)
+}
+
+// summarizeExecResults prints exit codes for failing repositories and the overall totals.
+// It returns an error when any repository failed so the process exits non-zero.
+func summarizeExecResults(results []execResult) error {
+	var failed []execResult
+	for _, r := range results {
+		if r.Err != nil || r.ExitCode != 0 {
+			failed = append(failed, r)
+		}
+	}
+
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].Name < failed[j].Name
+	})
+
+	if len(failed) > 0 {
+		log.Info("")
+		log.Info("Failed repositories:")
+		for _, r := range failed {
+			if r.Err != nil {
+				log.Error("  %-30s %s", r.Name, r.Err)
+			} else {
+				log.Error("  %-30s exit code %d", r.Name, r.ExitCode)
+			}
+		}
+	}
+
+	summaryMsg := fmt.Sprintf(
+		"Exec completed: %d succeeded, %d failed across %d repositories.",
+		len(results)-len(failed),
+		len(failed),
+		len(results),
+	)
+	if len(failed) > 0 {
+		theme.WarningMessage(summaryMsg)
+		return fmt.Errorf("command failed in %d of %d repositories", len(failed), len(results))
+	}
+
+	theme.SuccessMessage(summaryMsg)
+	return nil
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui"
+)
+
+func TestParseExecFilters(t *testing.T) {
+	filters, err := parseExecFilters([]string{"path=api-*", "Branch=feature/*", "dirty=true"})
+	if err != nil {
+		t.Fatalf("parseExecFilters failed: %v", err)
+	}
+	if len(filters) != 3 || filters[1].Key != "branch" {
+		t.Errorf("Unexpected filters: %+v", filters)
+	}
+
+	for _, invalid := range []string{"path", "dirty=maybe", "owner=me", "path=[", "branch="} {
+		if _, err := parseExecFilters([]string{invalid}); err == nil {
+			t.Errorf("Expected error for filter %q", invalid)
+		}
+	}
+}
+
+func TestMatchesExecFilters(t *testing.T) {
+	clean := repo.StatusInfo{Branch: "main"}
+	dirty := repo.StatusInfo{Branch: "feature/login", UnstagedCount: 2}
+
+	tests := []struct {
+		name    string
+		path    string
+		status  repo.StatusInfo
+		filters []string
+		want    bool
+	}{
+		{"no filters", "/dev/api", clean, nil, true},
+		{"name glob", "/dev/api-gateway", clean, []string{"path=api-*"}, true},
+		{"full path glob", "/dev/web", clean, []string{"path=/dev/*"}, true},
+		{"name mismatch", "/dev/web", clean, []string{"path=api-*"}, false},
+		{"branch glob", "/dev/web", dirty, []string{"branch=feature/*"}, true},
+		{"branch mismatch", "/dev/web", clean, []string{"branch=feature/*"}, false},
+		{"dirty true", "/dev/web", dirty, []string{"dirty=true"}, true},
+		{"dirty false", "/dev/web", dirty, []string{"dirty=false"}, false},
+		{"all must match", "/dev/api", dirty, []string{"path=api", "dirty=false"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := parseExecFilters(tt.filters)
+			if err != nil {
+				t.Fatalf("parseExecFilters failed: %v", err)
+			}
+			if got := matchesExecFilters(tt.path, tt.status, filters); got != tt.want {
+				t.Errorf("matchesExecFilters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunExecAll(t *testing.T) {
+	ui.DisableProgress = true
+	defer func() { ui.DisableProgress = false }()
+	workspace, cleanup := setupTestCommandEnvironment(t, []string{"ok-repo", "fail-repo"})
+	defer cleanup()
+
+	if err := os.WriteFile(filepath.Join(workspace, "fail-repo", "FAIL"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("Failed to create marker file: %v", err)
+	}
+
+	repos, err := findGitRepositories(workspace)
+	if err != nil {
+		t.Fatalf("findGitRepositories failed: %v", err)
+	}
+
+	results := runExecAll(context.Background(), repos, []string{"test ! -e FAIL && echo done"}, true, 2)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	byName := make(map[string]execResult)
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+
+	if r := byName["ok-repo"]; r.ExitCode != 0 || string(r.Output) != "done\n" {
+		t.Errorf("Unexpected ok-repo result: exit=%d output=%q", r.ExitCode, r.Output)
+	}
+	if r := byName["fail-repo"]; r.ExitCode != 1 {
+		t.Errorf("Expected fail-repo to exit 1, got %d", r.ExitCode)
+	}
+
+	if err := summarizeExecResults(results); err == nil {
+		t.Error("Expected summary error when a repository fails")
+	}
+
+	missing := runInRepo(context.Background(), workspace, []string{"definitely-not-a-real-command"}, false)
+	if missing.Err == nil || missing.ExitCode != -1 {
+		t.Errorf("Expected start failure for missing command, got %+v", missing)
+	}
+}
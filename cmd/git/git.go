@@ -107,6 +107,9 @@ func init() {
 	GitCmd.AddCommand(BranchAllCmd)
 	GitCmd.AddCommand(StashAllCmd)
 	GitCmd.AddCommand(CleanAllCmd)
+	GitCmd.AddCommand(ExecAllCmd)
+	GitCmd.AddCommand(PruneBranchesCmd)
+	GitCmd.AddCommand(ReportCmd)
 }
 
 // getBoolFlag safely checks if a flag exists anywhere in the command's local,
@@ -146,3 +149,9 @@ func getWorkingPath(cmd *cobra.Command) (string, error) {
 	devPath = os.ExpandEnv(devPath)
 	return devPath, nil
 }
+
+// projectsDevPath returns the expanded configured development path that projects are cloned under,
+// as <dev_path>/<project>/<repo>. It differs from the scanned folder when --current is used.
+func projectsDevPath() string {
+	return os.ExpandEnv(config.GetGitConfig().DevPath)
+}
@@ -0,0 +1,343 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+// PruneBranchesCmd defines the cobra command for deleting stale local branches across repositories.
+var PruneBranchesCmd = &cobra.Command{
+	Use:   "prune-branches",
+	Short: "Delete merged and gone branches in all git repositories",
+	Long: `This command finds local branches that are safe to clean up in every git repository in
+your development folder and deletes the ones you select.
+
+A branch is a candidate when:
+  - its upstream branch was deleted on the remote ("gone"), detected after 'git fetch --all --prune'
+  - it is fully merged into the default branch (main/master) or the develop branch
+
+The current branch, the default and develop branches, branches checked out in a worktree,
+and branches matching git.protected_branches in the config file (default: main, master,
+develop; glob patterns such as release/* are allowed) are never proposed.
+
+Candidates are listed in a table and selected from a checklist before anything is deleted.
+Merged branches are deleted with 'git branch -d'. Gone branches whose commits are still on a
+base branch or a remote branch are deleted with 'git branch -D'. Gone branches with commits
+found nowhere else, such as work committed after a review, are listed as unmerged and kept:
+with --force they are offered unselected in the checklist, and deleted by --yes.
+
+Example:
+  eng git prune-branches --dry-run      # Only list the candidates
+  eng git prune-branches                # Pick branches to delete
+  eng git prune-branches --yes          # Delete every merged candidate without prompting
+  eng git prune-branches --yes --force  # Also delete unmerged gone branches`,
+	Run: func(cmd *cobra.Command, _args []string) {
+		printHeader("🌿 Pruning Stale Branches")
+
+		setup, err := setupGitCommand(cmd)
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		skipFetch, _ := cmd.Flags().GetBool("skip-fetch")
+		yes, _ := cmd.Flags().GetBool("yes")
+		force, _ := cmd.Flags().GetBool("force")
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = cmdutil.FallbackContext()
+		}
+
+		repos, err := findGitRepositories(setup.DevPath)
+		if err != nil {
+			log.Error("Failed to find git repositories: %s", err)
+			return
+		}
+		if len(repos) == 0 {
+			log.Warn("No git repositories found in %s", setup.DevPath)
+			return
+		}
+
+		gitCfg := config.GetGitConfig()
+		candidates, scanFailed := collectPruneCandidates(ctx, repos, gitCfg, !skipFetch)
+		if scanFailed > 0 {
+			log.Warn("%d repository(ies) could not be scanned", scanFailed)
+		}
+		if len(candidates) == 0 {
+			theme.SuccessMessage(fmt.Sprintf("No stale branches found across %d repositories.", len(repos)))
+			return
+		}
+
+		printPruneTable(candidates)
+
+		if setup.DryRun {
+			theme.InfoMessage(
+				fmt.Sprintf("Dry run complete. %d branch(es) would be proposed for deletion.", len(candidates)),
+			)
+			return
+		}
+
+		offered := candidates
+		if !force {
+			offered = mergedPruneCandidates(candidates)
+			if kept := len(candidates) - len(offered); kept > 0 {
+				log.Warn("Keeping %d unmerged branch(es) with commits on no other branch; use --force to delete them.",
+					kept)
+			}
+		}
+
+		if len(offered) == 0 {
+			log.Message("No merged branches to delete. Nothing deleted.")
+			return
+		}
+
+		selected := offered
+		if !yes {
+			selected, err = selectPruneCandidates(offered)
+			if err != nil {
+				log.Error("Selection cancelled: %s", err)
+				return
+			}
+		}
+		if len(selected) == 0 {
+			log.Message("No branches selected. Nothing deleted.")
+			return
+		}
+
+		deleted, failed := deletePruneCandidates(ctx, selected, force)
+		summaryMsg := fmt.Sprintf("Deleted %d branch(es), %d failed.", deleted, failed)
+		if failed > 0 {
+			theme.WarningMessage(summaryMsg)
+		} else {
+			theme.SuccessMessage(summaryMsg)
+		}
+	},
+}
+
+func init() {
+	PruneBranchesCmd.Flags().Bool("dry-run", false, "List candidate branches without deleting anything")
+	PruneBranchesCmd.Flags().Bool("skip-fetch", false, "Do not run 'git fetch --all --prune' before scanning")
+	PruneBranchesCmd.Flags().BoolP("yes", "y", false, "Delete every candidate without prompting")
+	PruneBranchesCmd.Flags().Bool("force", false, "Also offer, or with --yes delete, unmerged gone branches")
+}
+
+// pruneCandidate is a stale branch in a specific repository.
+type pruneCandidate struct {
+	RepoPath string
+	RepoName string
+	Branch   repo.StaleBranch
+}
+
+// description explains why the branch is proposed.
+func (c pruneCandidate) description() string {
+	if c.Branch.Reason == repo.StaleReasonGone && c.Branch.Unmerged {
+		return fmt.Sprintf("upstream %s gone, unmerged", c.Branch.Upstream)
+	}
+	if c.Branch.Reason == repo.StaleReasonGone {
+		return fmt.Sprintf("upstream %s gone", c.Branch.Upstream)
+	}
+	return "merged into " + c.Branch.MergedTo
+}
+
+// label identifies the candidate in the selection list.
+func (c pruneCandidate) label() string {
+	return fmt.Sprintf("%s: %s (%s)", c.RepoName, c.Branch.Name, c.description())
+}
+
+// collectPruneCandidates fetches (optionally) and scans each repository concurrently for
+// stale branches, dropping protected ones. It returns the candidates sorted by repository
+// and branch, and the number of repositories that could not be scanned.
+func collectPruneCandidates(
+	ctx context.Context,
+	repos []string,
+	gitCfg config.GitConfig,
+	fetch bool,
+) ([]pruneCandidate, int) {
+	var mu sync.Mutex
+	var candidates []pruneCandidate
+	failed := 0
+
+	multi, err := ui.NewMultiSpinner()
+	if err != nil {
+		log.Error("Failed to initialize UI: %s", err)
+		return nil, len(repos)
+	}
+	defer multi.Stop()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(10)
+
+	for _, repoPath := range repos {
+		eg.Go(func() error {
+			repoName := filepath.Base(repoPath)
+			spinner := multi.AddSpinner(fmt.Sprintf("Scanning %s...", repoName))
+
+			if fetch {
+				spinner.UpdateText(fmt.Sprintf("Fetching %s...", repoName))
+				if err := repo.FetchAllPrune(egCtx, repoPath); err != nil {
+					spinner.Fail(fmt.Sprintf("Fetch failed for %s: %s", repoName, err))
+					mu.Lock()
+					failed++
+					mu.Unlock()
+					return nil
+				}
+			}
+
+			bases := []string{}
+			if mainBranch, err := repo.GetMainBranch(egCtx, repoPath); err == nil {
+				bases = append(bases, mainBranch)
+			}
+			if developBranch, err := repo.GetDevelopBranch(egCtx, repoPath); err == nil && developBranch != "" {
+				bases = append(bases, developBranch)
+			}
+
+			stale, err := repo.FindStaleBranches(egCtx, repoPath, bases)
+			if err != nil {
+				spinner.Fail(fmt.Sprintf("Failed to scan %s: %s", repoName, err))
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				return nil
+			}
+
+			var found []pruneCandidate
+			for _, b := range stale {
+				if gitCfg.IsProtectedBranch(b.Name) {
+					continue
+				}
+				found = append(found, pruneCandidate{RepoPath: repoPath, RepoName: repoName, Branch: b})
+			}
+
+			if len(found) == 0 {
+				spinner.Success(fmt.Sprintf("%s: no stale branches", repoName))
+			} else {
+				spinner.Warning(fmt.Sprintf("%s: %d stale branch(es)", repoName, len(found)))
+			}
+			mu.Lock()
+			candidates = append(candidates, found...)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+	multi.Stop()
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].RepoName != candidates[j].RepoName {
+			return candidates[i].RepoName < candidates[j].RepoName
+		}
+		return candidates[i].Branch.Name < candidates[j].Branch.Name
+	})
+	return candidates, failed
+}
+
+// printPruneTable renders the candidates as a table, or as log lines when progress output is disabled.
+func printPruneTable(candidates []pruneCandidate) {
+	if ui.DisableProgress {
+		log.Info("Stale branches:")
+		for _, c := range candidates {
+			log.Info("  %s", c.label())
+		}
+		return
+	}
+
+	var boxLines []string
+	boxLines = append(boxLines, fmt.Sprintf("Found %s stale branch(es):",
+		theme.PrimaryText.Bold(true).Render(fmt.Sprintf("%d", len(candidates))),
+	))
+	boxLines = append(boxLines, "")
+	boxLines = append(boxLines, fmt.Sprintf("  %-25s %-30s %s",
+		theme.BoldText.Render("Repository"),
+		theme.BoldText.Render("Branch"),
+		theme.BoldText.Render("Reason"),
+	))
+	boxLines = append(boxLines, "  "+strings.Repeat("─", 75))
+
+	for _, c := range candidates {
+		boxLines = append(boxLines, fmt.Sprintf("  %-25s %-30s %s",
+			theme.PrimaryText.Render(c.RepoName),
+			c.Branch.Name,
+			theme.MutedText.Render(c.description()),
+		))
+	}
+
+	fmt.Fprintln(log.Out, theme.InfoBox.Render(strings.Join(boxLines, "\n")))
+}
+
+// mergedPruneCandidates returns the candidates that are not unmerged gone branches.
+func mergedPruneCandidates(candidates []pruneCandidate) []pruneCandidate {
+	var merged []pruneCandidate
+	for _, c := range candidates {
+		if !c.Branch.Unmerged {
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
+
+// selectPruneCandidates lets the user pick which candidates to delete; all but unmerged gone
+// branches are preselected.
+func selectPruneCandidates(candidates []pruneCandidate) ([]pruneCandidate, error) {
+	labels := make([]string, len(candidates))
+	var preselected []string
+	byLabel := make(map[string]pruneCandidate, len(candidates))
+	for i, c := range candidates {
+		labels[i] = c.label()
+		byLabel[labels[i]] = c
+		if !c.Branch.Unmerged {
+			preselected = append(preselected, labels[i])
+		}
+	}
+
+	chosen, err := ui.MultiSelect("Select branches to delete:", labels, preselected)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]pruneCandidate, 0, len(chosen))
+	for _, l := range chosen {
+		if c, ok := byLabel[l]; ok {
+			selected = append(selected, c)
+		}
+	}
+	return selected, nil
+}
+
+// deletePruneCandidates deletes the selected branches and returns the success and failure counts.
+// Gone branches are force-deleted, which loses the commits of unmerged ones, so those are only
+// deleted with forceUnmerged.
+func deletePruneCandidates(ctx context.Context, selected []pruneCandidate, forceUnmerged bool) (int, int) {
+	deleted, failed := 0, 0
+	for _, c := range selected {
+		if c.Branch.Unmerged && !forceUnmerged {
+			log.Error("  %s: %s has commits on no other branch; use --force to delete it", c.RepoName, c.Branch.Name)
+			failed++
+			continue
+		}
+		force := c.Branch.Reason == repo.StaleReasonGone
+		if err := repo.DeleteBranch(ctx, c.RepoPath, c.Branch.Name, force); err != nil {
+			log.Error("  %s: %s", c.RepoName, err)
+			failed++
+			continue
+		}
+		log.Success("  %s: deleted %s", c.RepoName, c.Branch.Name)
+		deleted++
+	}
+	return deleted, failed
+}
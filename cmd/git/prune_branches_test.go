@@ -0,0 +1,103 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui"
+)
+
+func TestPruneBranches_CollectSelectDelete(t *testing.T) {
+	ui.DisableProgress = true
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	workspace := setupTestWorkspace(t, []string{"alpha", "beta"})
+	defer os.RemoveAll(workspace)
+
+	alpha := filepath.Join(workspace, "alpha")
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = alpha
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	// These branches point at main's commit, so they are fully merged.
+	run("branch", "done-feature")
+	run("branch", "keep-experiment")
+	run("branch", "release-1")
+
+	gitCfg := config.GitConfig{ProtectedBranches: []string{"main", "keep-*", "release-*"}}
+	repos := []string{alpha, filepath.Join(workspace, "beta")}
+
+	candidates, failed := collectPruneCandidates(context.Background(), repos, gitCfg, false)
+	if failed != 0 {
+		t.Fatalf("Expected no scan failures, got %d", failed)
+	}
+	if len(candidates) != 1 || candidates[0].Branch.Name != "done-feature" || candidates[0].RepoName != "alpha" {
+		t.Fatalf("Expected only done-feature as a candidate, got %+v", candidates)
+	}
+	if got := candidates[0].label(); got != "alpha: done-feature (merged into main)" {
+		t.Errorf("Unexpected label %q", got)
+	}
+
+	oldMultiSelect := ui.MultiSelect
+	defer func() { ui.MultiSelect = oldMultiSelect }()
+	var offered []string
+	ui.MultiSelect = func(_ string, options, defaultSelected []string) ([]string, error) {
+		offered = defaultSelected
+		return options, nil
+	}
+
+	selected, err := selectPruneCandidates(candidates)
+	if err != nil {
+		t.Fatalf("selectPruneCandidates failed: %v", err)
+	}
+	if len(offered) != 1 || len(selected) != 1 {
+		t.Fatalf("Expected the candidate to be preselected and chosen, got %v / %+v", offered, selected)
+	}
+
+	deleted, deleteFailed := deletePruneCandidates(context.Background(), selected, false)
+	if deleted != 1 || deleteFailed != 0 {
+		t.Fatalf("Expected 1 deletion, got %d deleted, %d failed\n%s", deleted, deleteFailed, buf.String())
+	}
+
+	cmd := exec.Command("git", "branch", "--list", "done-feature")
+	cmd.Dir = alpha
+	out, _ := cmd.Output()
+	if len(bytes.TrimSpace(out)) != 0 {
+		t.Errorf("Expected done-feature to be deleted, still listed: %s", out)
+	}
+
+	// A gone branch with commits nowhere else is offered unselected, and only deleted with force.
+	run("checkout", "-b", "reviewed")
+	run("commit", "--allow-empty", "-m", "Follow-up after review")
+	run("checkout", "main")
+	unmerged := pruneCandidate{RepoPath: alpha, RepoName: "alpha", Branch: repo.StaleBranch{
+		Name: "reviewed", Reason: repo.StaleReasonGone, Upstream: "origin/reviewed", Unmerged: true,
+	}}
+	if got := unmerged.label(); got != "alpha: reviewed (upstream origin/reviewed gone, unmerged)" {
+		t.Errorf("Unexpected label %q", got)
+	}
+	if merged := mergedPruneCandidates([]pruneCandidate{unmerged}); len(merged) != 0 {
+		t.Errorf("Expected unmerged branches to be left out without --force, got %+v", merged)
+	}
+	if _, err := selectPruneCandidates([]pruneCandidate{unmerged}); err != nil || len(offered) != 0 {
+		t.Errorf("Expected the unmerged branch not to be preselected, got %v (%v)", offered, err)
+	}
+	if deleted, _ := deletePruneCandidates(context.Background(), []pruneCandidate{unmerged}, false); deleted != 0 {
+		t.Error("Expected the unmerged branch to be kept without force")
+	}
+	if deleted, _ := deletePruneCandidates(context.Background(), []pruneCandidate{unmerged}, true); deleted != 1 {
+		t.Errorf("Expected the unmerged branch to be deleted with force\n%s", buf.String())
+	}
+}
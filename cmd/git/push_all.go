@@ -1,15 +1,21 @@
 package git
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
 	"github.com/eng618/eng/internal/log"
 	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui"
 	"github.com/eng618/eng/internal/ui/theme"
 )
 
@@ -18,7 +24,24 @@ import (
 var PushAllCmd = &cobra.Command{
 	Use:   "push-all",
 	Short: "Push all git repositories in development folder",
-	Long:  `This command pushes commits for all git repositories found in your development folder that have unpushed commits.`,
+	Long: `This command pushes commits for all git repositories found in your development folder that have
+unpushed commits. Repositories are pushed concurrently.
+
+Safety checks run before each push:
+  - Branches matching git.protected_branches in the config file (default: main, master,
+    develop; glob patterns such as release/* are allowed) are refused unless
+    --allow-protected is given.
+  - Branches without an upstream are skipped unless --set-upstream is given, which pushes
+    them to origin under the same name and records the upstream.
+  - Detached HEADs are skipped.
+
+--force-with-lease overwrites the remote branch only when it still matches your last fetch,
+so commits pushed by someone else are never discarded.
+
+Example:
+  eng git push-all --dry-run          # Preview what would be pushed
+  eng git push-all --set-upstream     # Also publish new local branches
+  eng git push-all --force-with-lease # Push rewritten history safely`,
 	Run: func(cmd *cobra.Command, args []string) {
 		printHeader("📤 Pushing Git Repositories")
 
@@ -28,10 +51,20 @@ var PushAllCmd = &cobra.Command{
 			return
 		}
 
-		force, _ := cmd.Flags().GetBool("force")
+		forceWithLease, _ := cmd.Flags().GetBool("force-with-lease")
+		if force, _ := cmd.Flags().GetBool("force"); force {
+			forceWithLease = true
+		}
+		setUpstream, _ := cmd.Flags().GetBool("set-upstream")
+		allowProtected, _ := cmd.Flags().GetBool("allow-protected")
+
+		if forceWithLease {
+			log.Warn("Force push mode enabled - remote branches will be overwritten with --force-with-lease")
+		}
 
-		if force {
-			log.Warn("Force push mode enabled - this will force push to remote")
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = cmdutil.FallbackContext()
 		}
 
 		repos, err := findGitRepositories(setup.DevPath)
@@ -47,115 +80,186 @@ var PushAllCmd = &cobra.Command{
 
 		log.Info("Found %d git repositories", len(repos))
 
-		successCount := 0
-		failureCount := 0
-		skippedCount := 0
+		summary := pushAll(ctx, repos, pushAllOptions{
+			DryRun:         setup.DryRun,
+			IsVerbose:      setup.IsVerbose,
+			ForceWithLease: forceWithLease,
+			SetUpstream:    setUpstream,
+			AllowProtected: allowProtected,
+			GitConfig:      config.GetGitConfig(),
+		})
+		summary.print(len(repos))
+	},
+}
+
+func init() {
+	PushAllCmd.Flags().Bool("dry-run", false, "Perform a dry run without making actual changes")
+	PushAllCmd.Flags().Bool("force-with-lease", false, "Overwrite remote branches only if they match the last fetch")
+	PushAllCmd.Flags().Bool("force", false, "Force push to remote (alias for --force-with-lease)")
+	_ = PushAllCmd.Flags().MarkDeprecated("force", "use --force-with-lease instead")
+	PushAllCmd.Flags().BoolP("set-upstream", "u", false, "Push branches without an upstream to origin and track them")
+	PushAllCmd.Flags().Bool("allow-protected", false, "Allow pushing branches matching git.protected_branches")
+}
 
-		for _, repoPath := range repos {
+// pushAllOptions holds the settings for pushAll.
+type pushAllOptions struct {
+	DryRun         bool
+	IsVerbose      bool
+	ForceWithLease bool
+	SetUpstream    bool
+	AllowProtected bool
+	GitConfig      config.GitConfig
+}
+
+// pushAllSummary collects the outcome of pushAll.
+type pushAllSummary struct {
+	Pushed     int
+	Failed     []string
+	Skipped    int
+	Refused    []string // Protected branches that were not pushed
+	NoUpstream []string // Branches without an upstream that were not pushed
+}
+
+// pushAll checks and pushes every repository concurrently.
+func pushAll(ctx context.Context, repos []string, opts pushAllOptions) pushAllSummary {
+	var mu sync.Mutex
+	var summary pushAllSummary
+
+	multi, err := ui.NewMultiSpinner()
+	if err != nil {
+		log.Error("Failed to initialize UI: %s", err)
+		return summary
+	}
+	defer multi.Stop()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(10) // Concurrent push limit
+
+	for _, repoPath := range repos {
+		eg.Go(func() error {
 			repoName := filepath.Base(repoPath)
-			log.Verbose(setup.IsVerbose, "Checking repository: %s", repoName)
-
-			if setup.DryRun {
-				hasUnpushed, err := hasUnpushedCommits(repoPath)
-				if err != nil {
-					log.Error("  [DRY RUN] Failed to check for unpushed commits: %s", err)
-					failureCount++
-					continue
-				}
-				if hasUnpushed {
-					log.Info("  [DRY RUN] Would push repository at: %s", repoPath)
-					successCount++
-				} else {
-					log.Verbose(setup.IsVerbose, "  [DRY RUN] No unpushed commits, skipping: %s", repoPath)
-					skippedCount++
-				}
-				continue
-			}
+			spinner := multi.AddSpinner(fmt.Sprintf("Checking %s...", repoName))
 
-			// Check if repository has unpushed commits
-			hasUnpushed, err := hasUnpushedCommits(repoPath)
+			status, err := repo.RefreshDetailedStatus(egCtx, repoPath)
 			if err != nil {
-				log.Error("  Failed to check for unpushed commits: %s", err)
-				failureCount++
-				continue
+				spinner.Fail(fmt.Sprintf("Failed to check status for %s: %s", repoName, err))
+				mu.Lock()
+				summary.Failed = append(summary.Failed, repoName)
+				mu.Unlock()
+				return nil
 			}
 
-			if !hasUnpushed {
-				log.Verbose(setup.IsVerbose, "  No unpushed commits, skipping: %s", repoName)
-				skippedCount++
-				continue
+			switch {
+			case status.IsDetached:
+				// Skipped repositories are only reported in verbose mode; an unfinished spinner prints nothing.
+				if opts.IsVerbose {
+					spinner.Info(fmt.Sprintf("Skipped %s (detached HEAD)", repoName))
+				}
+				mu.Lock()
+				summary.Skipped++
+				mu.Unlock()
+				return nil
+			case status.HasUpstream && status.AheadCount == 0:
+				if opts.IsVerbose {
+					spinner.Info(fmt.Sprintf("Skipped %s (nothing to push)", repoName))
+				}
+				mu.Lock()
+				summary.Skipped++
+				mu.Unlock()
+				return nil
 			}
 
-			// Check if repository is dirty (only warn, don't skip)
-			isDirty, err := repo.IsDirty(cmd.Context(), repoPath)
-			if err != nil {
-				log.Error("  Failed to check repository status: %s", err)
-				failureCount++
-				continue
+			label := fmt.Sprintf("%s (%s)", repoName, status.Branch)
+			if !opts.AllowProtected && opts.GitConfig.IsProtectedBranch(status.Branch) {
+				spinner.Warning(fmt.Sprintf("Refused to push %s: protected branch", label))
+				mu.Lock()
+				summary.Refused = append(summary.Refused, label)
+				mu.Unlock()
+				return nil
+			}
+			if !status.HasUpstream && !opts.SetUpstream {
+				spinner.Warning(fmt.Sprintf("Skipped %s: no upstream branch", label))
+				mu.Lock()
+				summary.NoUpstream = append(summary.NoUpstream, label)
+				mu.Unlock()
+				return nil
 			}
 
-			if isDirty {
-				log.Warn("  Repository %s has uncommitted changes, but proceeding with push...", repoName)
+			action := fmt.Sprintf("%d commit(s)", status.AheadCount)
+			if !status.HasUpstream {
+				action = "new branch to origin"
+			}
+			if opts.DryRun {
+				spinner.Success(fmt.Sprintf("[DRY RUN] Would push %s to %s", action, label))
+				mu.Lock()
+				summary.Pushed++
+				mu.Unlock()
+				return nil
 			}
 
-			// Push commits
-			if err := pushRepository(repoPath, force); err != nil {
-				log.Error("  Failed to push %s: %s", repoName, err)
-				failureCount++
-				continue
+			spinner.UpdateText(fmt.Sprintf("Pushing %s...", label))
+			pushOpts := repo.PushOptions{
+				ForceWithLease: opts.ForceWithLease,
+				SetUpstream:    !status.HasUpstream,
+			}
+			if err := repo.Push(egCtx, repoPath, pushOpts); err != nil {
+				spinner.Fail(fmt.Sprintf("Failed to push %s: %s", label, err))
+				mu.Lock()
+				summary.Failed = append(summary.Failed, repoName)
+				mu.Unlock()
+				return nil
 			}
 
-			log.Success("  Successfully pushed %s", repoName)
-			successCount++
-		}
+			message := fmt.Sprintf("Pushed %s to %s", action, label)
+			if status.UnstagedCount+status.StagedCount > 0 {
+				message += " (uncommitted changes were not pushed)"
+			}
+			spinner.Success(message)
+			mu.Lock()
+			summary.Pushed++
+			mu.Unlock()
+			return nil
+		})
+	}
 
-		summaryMsg := fmt.Sprintf(
-			"Push completed: %d successful, %d failed, %d skipped across %d repositories.",
-			successCount,
-			failureCount,
-			skippedCount,
-			len(repos),
-		)
-		if failureCount > 0 {
-			theme.WarningMessage(summaryMsg)
-		} else {
-			theme.SuccessMessage(summaryMsg)
-		}
-	},
-}
+	_ = eg.Wait()
+	multi.Stop()
 
-func init() {
-	PushAllCmd.Flags().Bool("dry-run", false, "Perform a dry run without making actual changes")
-	PushAllCmd.Flags().Bool("force", false, "Force push to remote (use with caution)")
+	sort.Strings(summary.Failed)
+	sort.Strings(summary.Refused)
+	sort.Strings(summary.NoUpstream)
+	return summary
 }
 
-// pushRepository performs a git push operation on the given repository path.
-func pushRepository(repoPath string, force bool) error {
-	args := []string{"-C", repoPath, "push"}
-	if force {
-		args = append(args, "--force-with-lease")
+// print reports the summary and the repositories that need attention.
+func (s pushAllSummary) print(total int) {
+	if len(s.Refused) > 0 {
+		log.Warn("Protected branches not pushed (use --allow-protected to override):")
+		for _, r := range s.Refused {
+			log.Warn("  - %s", r)
+		}
 	}
-
-	cmd := exec.Command("git", args...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		log.Error("Git push output: %s", string(output))
-		return err
+	if len(s.NoUpstream) > 0 {
+		log.Warn("Branches without an upstream (use --set-upstream to publish them):")
+		for _, r := range s.NoUpstream {
+			log.Warn("  - %s", r)
+		}
 	}
-	log.Info("Git push output: %s", string(output))
-	return nil
-}
-
-// hasUnpushedCommits checks if the repository has commits that haven't been pushed to remote.
-func hasUnpushedCommits(repoPath string) (bool, error) {
-	// Check if there are commits ahead of origin
-	cmd := exec.Command("git", "-C", repoPath, "rev-list", "--count", "@{upstream}..HEAD")
-	output, err := cmd.Output()
-	if err != nil {
-		// If there's no upstream, consider it as having unpushed commits
-		return true, nil
+	if len(s.Failed) > 0 {
+		log.Error("Failed repositories: %s", strings.Join(s.Failed, ", "))
 	}
 
-	count := strings.TrimSpace(string(output))
-	return count != "0", nil
+	summaryMsg := fmt.Sprintf(
+		"Push completed: %d successful, %d failed, %d refused, %d skipped across %d repositories.",
+		s.Pushed,
+		len(s.Failed),
+		len(s.Refused)+len(s.NoUpstream),
+		s.Skipped,
+		total,
+	)
+	if len(s.Failed)+len(s.Refused)+len(s.NoUpstream) > 0 {
+		theme.WarningMessage(summaryMsg)
+	} else {
+		theme.SuccessMessage(summaryMsg)
+	}
 }
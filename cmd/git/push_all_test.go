@@ -0,0 +1,88 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/ui"
+)
+
+func TestPushAll_ProtectedAndUpstream(t *testing.T) {
+	ui.DisableProgress = true
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	workspace := setupTestWorkspace(t, []string{"alpha", "beta", "gamma"})
+	defer os.RemoveAll(workspace)
+
+	run := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(dir, name string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		run(dir, "add", name)
+		run(dir, "commit", "-m", "Add "+name)
+	}
+
+	var repos []string
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		dir := filepath.Join(workspace, name)
+		remote := filepath.Join(workspace, name+".git")
+		run(workspace, "init", "--bare", remote)
+		run(dir, "remote", "add", "origin", remote)
+		run(dir, "push", "-u", "origin", "main")
+		repos = append(repos, dir)
+	}
+
+	// alpha: unpushed commit on a protected branch.
+	commit(repos[0], "alpha.txt")
+	// beta: unpushed commit on a tracked feature branch.
+	run(repos[1], "checkout", "-b", "feature")
+	run(repos[1], "push", "-u", "origin", "feature")
+	commit(repos[1], "beta.txt")
+	// gamma: new branch that was never pushed.
+	run(repos[2], "checkout", "-b", "topic")
+	commit(repos[2], "gamma.txt")
+
+	opts := pushAllOptions{GitConfig: config.GitConfig{ProtectedBranches: config.DefaultProtectedBranches}}
+	summary := pushAll(context.Background(), repos, opts)
+	if summary.Pushed != 1 || len(summary.Failed) != 0 {
+		t.Fatalf("Expected only beta to be pushed, got %+v\n%s", summary, buf.String())
+	}
+	if len(summary.Refused) != 1 || summary.Refused[0] != "alpha (main)" {
+		t.Errorf("Expected alpha (main) to be refused, got %v", summary.Refused)
+	}
+	if len(summary.NoUpstream) != 1 || summary.NoUpstream[0] != "gamma (topic)" {
+		t.Errorf("Expected gamma (topic) to lack an upstream, got %v", summary.NoUpstream)
+	}
+
+	opts.SetUpstream = true
+	summary = pushAll(context.Background(), repos, opts)
+	if summary.Pushed != 1 || summary.Skipped != 1 || len(summary.Refused) != 1 || len(summary.NoUpstream) != 0 {
+		t.Fatalf("Expected gamma to be published, got %+v\n%s", summary, buf.String())
+	}
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "topic@{upstream}")
+	cmd.Dir = repos[2]
+	if out, err := cmd.Output(); err != nil || string(bytes.TrimSpace(out)) != "origin/topic" {
+		t.Errorf("Expected topic to track origin/topic, got %q (%v)", out, err)
+	}
+
+	opts.AllowProtected = true
+	summary = pushAll(context.Background(), repos, opts)
+	if summary.Pushed != 1 || len(summary.Refused) != 0 {
+		t.Errorf("Expected alpha to be pushed with AllowProtected, got %+v", summary)
+	}
+}
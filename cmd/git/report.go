@@ -0,0 +1,414 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+// reportFormatMarkdown renders the activity report as Markdown, next to the standard output formats.
+const reportFormatMarkdown = "markdown"
+
+// ReportCmd defines the cobra command for summarizing recent activity across repositories.
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize recent commit activity across all git repositories",
+	Long: `This command reads the local branches of every git repository in your development folder
+and summarizes the commits made in a time window: per repository, per author, and per branch.
+It also lists branches whose last commit is older than --stale-after and repositories with no
+commits in the window.
+
+History is read directly with go-git, so no git processes are started. Only local branches are
+read; run 'eng git fetch-all' first to include work that exists only on the remotes.
+
+--since and --stale-after accept days (7d), weeks (2w), Go durations (36h), or a date
+(2006-01-02).
+
+Example:
+  eng git report                       # Activity in the last 7 days as tables
+  eng git report --since 14d -o markdown > standup.md
+  eng git report --since 2026-01-01 -o json | jq '.authors'`,
+	Run: func(cmd *cobra.Command, _args []string) {
+		formatFlag, _ := cmd.Flags().GetString("output")
+		format, err := parseReportFormat(formatFlag)
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		out := log.Out
+		if format != string(cmdutil.OutputTable) {
+			var restore func()
+			out, restore = cmdutil.RedirectLogsForStructuredOutput()
+			defer restore()
+		} else {
+			printHeader("📈 Repository Activity Report")
+		}
+
+		setup, err := setupGitCommand(cmd)
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		now := time.Now()
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		since, err := parseSince(sinceFlag, now)
+		if err != nil {
+			log.Error("Invalid --since: %s", err)
+			return
+		}
+		staleFlag, _ := cmd.Flags().GetString("stale-after")
+		staleBefore, err := parseSince(staleFlag, now)
+		if err != nil {
+			log.Error("Invalid --stale-after: %s", err)
+			return
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = cmdutil.FallbackContext()
+		}
+
+		repos, err := findGitRepositories(setup.DevPath)
+		if err != nil {
+			log.Error("Failed to find git repositories: %s", err)
+			return
+		}
+		if len(repos) == 0 {
+			log.Warn("No git repositories found in %s", setup.DevPath)
+			return
+		}
+
+		var spinner *ui.Spinner
+		if format == string(cmdutil.OutputTable) && !ui.DisableProgress {
+			spinner = ui.NewSpinner(fmt.Sprintf("Reading history of %d repositories...", len(repos)))
+			spinner.Start()
+		}
+		report := buildActivityReport(ctx, repos, projectsDevPath(), config.GetProjects(), since, staleBefore)
+		report.Until = now
+		if spinner != nil {
+			spinner.Stop()
+		}
+
+		switch format {
+		case reportFormatMarkdown:
+			renderActivityMarkdown(out, report)
+		case string(cmdutil.OutputTable):
+			renderActivityTable(out, report)
+		default:
+			if err := cmdutil.WriteStructured(out, cmdutil.OutputFormat(format), report); err != nil {
+				log.Error("Failed to write %s output: %s", format, err)
+			}
+		}
+	},
+}
+
+func init() {
+	ReportCmd.Flags().String("since", "7d", "Start of the report window (e.g. 7d, 2w, 36h, 2006-01-02)")
+	ReportCmd.Flags().String("stale-after", "30d", "Report branches with no commits within this period as stale")
+	ReportCmd.Flags().
+		StringP("output", "o", string(cmdutil.OutputTable), "Output format: table, markdown, json, or yaml")
+}
+
+// parseReportFormat accepts the standard output formats plus markdown.
+func parseReportFormat(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case reportFormatMarkdown, "md":
+		return reportFormatMarkdown, nil
+	}
+	format, err := cmdutil.ParseOutputFormat(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid output format %q (expected table, markdown, json, or yaml)", value)
+	}
+	return string(format), nil
+}
+
+// parseSince resolves a relative period (7d, 2w, 36h) or a date (2006-01-02) to a point in time.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty period")
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, now.Location()); err == nil {
+		return t, nil
+	}
+
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("invalid period %q", value)
+		}
+		if unit == 'w' {
+			n *= 7
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid period %q (expected e.g. 7d, 2w, 36h, or 2006-01-02)", value)
+	}
+	return now.Add(-d), nil
+}
+
+// activityReport is the machine-readable activity summary across repositories.
+type activityReport struct {
+	Since        time.Time             `json:"since" yaml:"since"`
+	Until        time.Time             `json:"until" yaml:"until"`
+	StaleBefore  time.Time             `json:"stale_before" yaml:"stale_before"`
+	TotalCommits int                   `json:"total_commits" yaml:"total_commits"`
+	Authors      []repo.AuthorActivity `json:"authors" yaml:"authors"`
+	Repos        []repoActivityReport  `json:"repos" yaml:"repos"`
+	Inactive     []string              `json:"inactive" yaml:"inactive"`
+}
+
+// repoActivityReport is the activity of a single repository.
+type repoActivityReport struct {
+	Name              string `json:"name" yaml:"name"`
+	Path              string `json:"path" yaml:"path"`
+	Project           string `json:"project,omitempty" yaml:"project,omitempty"`
+	repo.RepoActivity `yaml:",inline"`
+	Error             string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// buildActivityReport reads the activity of every repository concurrently and aggregates it.
+// Repositories are ordered by commit count, then name.
+func buildActivityReport(
+	ctx context.Context,
+	repos []string,
+	projectsPath string,
+	projects []config.Project,
+	since, staleBefore time.Time,
+) activityReport {
+	report := activityReport{Since: since, StaleBefore: staleBefore}
+	report.Repos = make([]repoActivityReport, len(repos))
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(10)
+	for i, repoPath := range repos {
+		eg.Go(func() error {
+			r := repoActivityReport{
+				Name:    filepath.Base(repoPath),
+				Path:    repoPath,
+				Project: config.FindProjectForPath(projects, projectsPath, repoPath),
+			}
+			activity, err := repo.CollectActivity(egCtx, repoPath, since, staleBefore)
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.RepoActivity = activity
+			}
+			report.Repos[i] = r
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	sort.SliceStable(report.Repos, func(i, j int) bool {
+		if report.Repos[i].Commits != report.Repos[j].Commits {
+			return report.Repos[i].Commits > report.Repos[j].Commits
+		}
+		return report.Repos[i].Name < report.Repos[j].Name
+	})
+
+	authors := make(map[string]*repo.AuthorActivity)
+	var order []string
+	for _, r := range report.Repos {
+		if r.Error != "" {
+			continue
+		}
+		if r.Commits == 0 {
+			report.Inactive = append(report.Inactive, r.Name)
+			continue
+		}
+		report.TotalCommits += r.Commits
+		for _, a := range r.Authors {
+			key := strings.ToLower(a.Email)
+			if existing, ok := authors[key]; ok {
+				existing.Commits += a.Commits
+				continue
+			}
+			copied := a
+			authors[key] = &copied
+			order = append(order, key)
+		}
+	}
+	for _, key := range order {
+		report.Authors = append(report.Authors, *authors[key])
+	}
+	sort.SliceStable(report.Authors, func(i, j int) bool {
+		return report.Authors[i].Commits > report.Authors[j].Commits
+	})
+	return report
+}
+
+// staleBranchLines returns "repo: branch (last commit date)" for every stale branch.
+func (r activityReport) staleBranchLines() []string {
+	var lines []string
+	for _, rep := range r.Repos {
+		for _, b := range rep.StaleBranches {
+			lines = append(lines, fmt.Sprintf("%s: %s (last commit %s)",
+				rep.Name, b.Name, b.LastCommit.Format(time.DateOnly)))
+		}
+	}
+	return lines
+}
+
+// inactiveLines returns "repo (last commit date)" for every repository without commits in the window.
+func (r activityReport) inactiveLines() []string {
+	var lines []string
+	for _, rep := range r.Repos {
+		if rep.Error != "" || rep.Commits > 0 {
+			continue
+		}
+		last := "never"
+		if !rep.LastCommit.IsZero() {
+			last = rep.LastCommit.Format(time.DateOnly)
+		}
+		lines = append(lines, fmt.Sprintf("%s (last commit %s)", rep.Name, last))
+	}
+	return lines
+}
+
+// topBranches lists the most active branches of a repository as "name (n)".
+func topBranches(branches []repo.BranchActivity, limit int) string {
+	var parts []string
+	for i, b := range branches {
+		if i == limit {
+			parts = append(parts, fmt.Sprintf("+%d more", len(branches)-limit))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", b.Name, b.Commits))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// newReportTable returns a Lip Gloss table styled like the other eng tables.
+func newReportTable(headers ...string) *table.Table {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Background).
+		Background(theme.Primary).
+		Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+
+	return table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(theme.Primary)).
+		Headers(headers...).
+		StyleFunc(func(row, _ int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return cellStyle
+		})
+}
+
+// renderActivityTable writes the report as Lip Gloss tables.
+func renderActivityTable(w io.Writer, r activityReport) {
+	fmt.Fprintf(w, "%s %s → %s: %s commit(s) across %d repositories\n\n",
+		theme.BoldText.Render("Activity"),
+		r.Since.Format(time.DateOnly),
+		r.Until.Format(time.DateOnly),
+		theme.PrimaryText.Bold(true).Render(strconv.Itoa(r.TotalCommits)),
+		len(r.Repos),
+	)
+
+	if r.TotalCommits > 0 {
+		repos := newReportTable("REPOSITORY", "COMMITS", "AUTHORS", "BRANCHES")
+		for _, rep := range r.Repos {
+			if rep.Error != "" || rep.Commits == 0 {
+				continue
+			}
+			repos.Row(rep.Name, strconv.Itoa(rep.Commits), strconv.Itoa(len(rep.Authors)), topBranches(rep.Branches, 3))
+		}
+		fmt.Fprintln(w, repos.Render())
+
+		authors := newReportTable("AUTHOR", "EMAIL", "COMMITS")
+		for _, a := range r.Authors {
+			authors.Row(a.Name, a.Email, strconv.Itoa(a.Commits))
+		}
+		fmt.Fprintln(w, authors.Render())
+	}
+
+	printList := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintln(w, theme.BoldText.Render(title))
+		for _, l := range lines {
+			fmt.Fprintln(w, "  "+theme.MutedText.Render(l))
+		}
+	}
+	printList(fmt.Sprintf("Stale branches (no commits since %s):", r.StaleBefore.Format(time.DateOnly)),
+		r.staleBranchLines())
+	printList("Inactive repositories:", r.inactiveLines())
+
+	for _, rep := range r.Repos {
+		if rep.Error != "" {
+			log.Error("  %s: %s", rep.Name, rep.Error)
+		}
+	}
+}
+
+// renderActivityMarkdown writes the report as Markdown suitable for pasting into standup notes.
+func renderActivityMarkdown(w io.Writer, r activityReport) {
+	fmt.Fprintf(w, "## Activity %s to %s\n\n", r.Since.Format(time.DateOnly), r.Until.Format(time.DateOnly))
+	fmt.Fprintf(w, "%d commit(s) across %d repositories.\n", r.TotalCommits, len(r.Repos))
+
+	if r.TotalCommits > 0 {
+		fmt.Fprint(w, "\n### Repositories\n\n")
+		fmt.Fprint(w, "| Repository | Commits | Authors | Branches |\n| --- | ---: | ---: | --- |\n")
+		for _, rep := range r.Repos {
+			if rep.Error != "" || rep.Commits == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "| %s | %d | %d | %s |\n",
+				rep.Name, rep.Commits, len(rep.Authors), topBranches(rep.Branches, 3))
+		}
+
+		fmt.Fprint(w, "\n### Authors\n\n| Author | Commits |\n| --- | ---: |\n")
+		for _, a := range r.Authors {
+			fmt.Fprintf(w, "| %s | %d |\n", a.Name, a.Commits)
+		}
+	}
+
+	printList := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n### %s\n\n", title)
+		for _, l := range lines {
+			fmt.Fprintf(w, "- %s\n", l)
+		}
+	}
+	printList("Stale branches", r.staleBranchLines())
+	printList("Inactive repositories", r.inactiveLines())
+
+	var failed []string
+	for _, rep := range r.Repos {
+		if rep.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s: %s", rep.Name, rep.Error))
+		}
+	}
+	printList("Unreadable repositories", failed)
+}
@@ -0,0 +1,85 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eng618/eng/internal/cmdutil"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "7d", want: now.AddDate(0, 0, -7)},
+		{input: "2w", want: now.AddDate(0, 0, -14)},
+		{input: "36h", want: now.Add(-36 * time.Hour)},
+		{input: "2026-10-01", want: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "", wantErr: true},
+		{input: "xd", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseSince(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSince(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildActivityReport(t *testing.T) {
+	workspace := setupTestWorkspace(t, []string{"alpha", "beta"})
+	defer os.RemoveAll(workspace)
+
+	repos := []string{filepath.Join(workspace, "alpha"), filepath.Join(workspace, "beta")}
+	now := time.Now()
+
+	report := buildActivityReport(context.Background(), repos, workspace, nil, now.AddDate(0, 0, -7), time.Time{})
+	report.Until = now
+	if report.TotalCommits != 2 || len(report.Inactive) != 0 {
+		t.Fatalf("Expected one recent commit per repository, got %+v", report)
+	}
+	if len(report.Authors) != 1 || report.Authors[0].Commits != 2 {
+		t.Errorf("Expected the test author to be aggregated across repositories, got %+v", report.Authors)
+	}
+
+	var md bytes.Buffer
+	renderActivityMarkdown(&md, report)
+	for _, want := range []string{"### Repositories", "| alpha | 1 | 1 | main (1) |", "### Authors"} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Expected Markdown to contain %q, got:\n%s", want, md.String())
+		}
+	}
+
+	// A window that starts after every commit leaves both repositories inactive.
+	report = buildActivityReport(context.Background(), repos, workspace, nil, now.Add(time.Hour), time.Time{})
+	if report.TotalCommits != 0 || len(report.Inactive) != 2 {
+		t.Fatalf("Expected both repositories to be inactive, got %+v", report)
+	}
+
+	var out bytes.Buffer
+	if err := cmdutil.WriteStructured(&out, cmdutil.OutputJSON, report); err != nil {
+		t.Fatalf("WriteStructured failed: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if decoded["inactive"] == nil {
+		t.Errorf("Expected inactive repositories in JSON, got %s", out.String())
+	}
+}
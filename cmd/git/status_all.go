@@ -1,13 +1,17 @@
 package git
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
 	"github.com/eng618/eng/internal/log"
 	"github.com/eng618/eng/internal/repo"
 	"github.com/eng618/eng/internal/ui"
@@ -18,8 +22,29 @@ import (
 var StatusAllCmd = &cobra.Command{
 	Use:   "status-all",
 	Short: "Check status of all git repositories in development folder",
-	Long:  `This command checks the status of all git repositories found in your development folder.`,
+	Long: `This command checks the status of all git repositories found in your development folder.
+
+Use --output json or --output yaml to emit machine-readable status for each repository,
+including branch, ahead/behind counts, file change counts, any in-progress operation,
+and the configured project the repository belongs to.
+
+Example:
+  eng git status-all                 # Styled summary table
+  eng git status-all -o json | jq .  # JSON for scripts and CI dashboards`,
 	Run: func(cmd *cobra.Command, _args []string) {
+		format, err := cmdutil.GetOutputFormat(cmd)
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		if format.IsStructured() {
+			out, restore := cmdutil.RedirectLogsForStructuredOutput()
+			defer restore()
+			runStatusAllStructured(cmd, out, format)
+			return
+		}
+
 		printHeader("📊 Development Repositories Status")
 
 		setup, err := setupGitCommand(cmd)
@@ -51,37 +76,21 @@ var StatusAllCmd = &cobra.Command{
 			return
 		}
 
-		type RepoStatusSummary struct {
-			Name    string
-			Branch  string
-			IsDirty bool
-		}
+		refresh, _ := cmd.Flags().GetBool("refresh")
+		reports := collectRepoStatusReports(cmd.Context(), repos, "", nil, refresh)
 
-		var summaries []RepoStatusSummary
 		cleanCount := 0
 		dirtyCount := 0
-
-		for _, repoPath := range repos {
-			repoName := filepath.Base(repoPath)
-			branch := getRepoBranch(repoPath)
-
-			isDirty, err := repo.IsDirty(cmd.Context(), repoPath)
-			if err != nil {
-				log.Error("  %s: Failed to check status - %s", repoName, err)
+		for _, r := range reports {
+			if r.Error != "" {
+				log.Error("  %s: Failed to check status - %s", r.Name, r.Error)
 				continue
 			}
-
-			if isDirty {
+			if r.Dirty {
 				dirtyCount++
 			} else {
 				cleanCount++
 			}
-
-			summaries = append(summaries, RepoStatusSummary{
-				Name:    repoName,
-				Branch:  branch,
-				IsDirty: isDirty,
-			})
 		}
 
 		// Render Lipgloss Table Box
@@ -98,15 +107,19 @@ var StatusAllCmd = &cobra.Command{
 		))
 		boxLines = append(boxLines, "  "+strings.Repeat("─", 65))
 
-		for _, s := range summaries {
+		for _, r := range reports {
+			if r.Error != "" {
+				continue
+			}
+
 			statusTag := theme.SuccessText.Render("✓ Clean")
-			if s.IsDirty {
+			if r.Dirty {
 				statusTag = theme.ErrorText.Render("⚠️ Uncommitted Changes")
 			}
 
 			boxLines = append(boxLines, fmt.Sprintf("  %-30s %-20s %s",
-				theme.PrimaryText.Render(s.Name),
-				theme.MutedText.Render(s.Branch),
+				theme.PrimaryText.Render(r.Name),
+				theme.MutedText.Render(r.Status.Branch),
 				statusTag,
 			))
 		}
@@ -129,6 +142,82 @@ var StatusAllCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	cmdutil.AddOutputFlag(StatusAllCmd)
+	StatusAllCmd.Flags().Bool("refresh", false, "Bypass the status cache and re-read every repository")
+}
+
+// repoStatusReport is the machine-readable status of a single repository.
+type repoStatusReport struct {
+	Name    string          `json:"name" yaml:"name"`
+	Path    string          `json:"path" yaml:"path"`
+	Project string          `json:"project,omitempty" yaml:"project,omitempty"`
+	Dirty   bool            `json:"dirty" yaml:"dirty"`
+	Status  repo.StatusInfo `json:"status" yaml:"status"`
+	Error   string          `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// runStatusAllStructured collects detailed status for every repository and writes it to out.
+func runStatusAllStructured(cmd *cobra.Command, out io.Writer, format cmdutil.OutputFormat) {
+	setup, err := setupGitCommand(cmd)
+	if err != nil {
+		log.Error("%s", err)
+		return
+	}
+
+	repos, err := findGitRepositories(setup.DevPath)
+	if err != nil {
+		log.Error("Failed to find git repositories: %s", err)
+		return
+	}
+
+	refresh, _ := cmd.Flags().GetBool("refresh")
+	reports := collectRepoStatusReports(cmd.Context(), repos, projectsDevPath(), config.GetProjects(), refresh)
+	if err := cmdutil.WriteStructured(out, format, reports); err != nil {
+		log.Error("Failed to write %s output: %s", format, err)
+	}
+}
+
+// collectRepoStatusReports builds a status report for each repository path,
+// resolving project membership from the projects cloned under projectsPath. Statuses come from
+// the status cache unless refresh is set.
+func collectRepoStatusReports(
+	ctx context.Context,
+	repos []string,
+	projectsPath string,
+	projects []config.Project,
+	refresh bool,
+) []repoStatusReport {
+	if ctx == nil {
+		ctx = cmdutil.FallbackContext()
+	}
+
+	reports := make([]repoStatusReport, 0, len(repos))
+	for _, repoPath := range repos {
+		report := repoStatusReport{
+			Name:    filepath.Base(repoPath),
+			Path:    repoPath,
+			Project: config.FindProjectForPath(projects, projectsPath, repoPath),
+		}
+
+		getStatus := repo.GetDetailedStatus
+		if refresh {
+			getStatus = repo.RefreshDetailedStatus
+		}
+
+		status, err := getStatus(ctx, repoPath)
+		if err != nil {
+			report.Error = err.Error()
+		} else {
+			report.Status = status
+			report.Dirty = status.StagedCount+status.UnstagedCount+status.UntrackedCount+status.ConflictCount > 0
+		}
+
+		reports = append(reports, report)
+	}
+	return reports
+}
+
 func getRepoBranch(repoPath string) string {
 	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
 	out, err := cmd.Output()
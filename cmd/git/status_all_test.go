@@ -0,0 +1,97 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
+)
+
+func TestCollectRepoStatusReports(t *testing.T) {
+	workspace, cleanup := setupTestCommandEnvironment(t, []string{"clean-repo", "dirty-repo"})
+	defer cleanup()
+
+	// Lay the repositories out as <dev_path>/<project>/<repo> under the configured dev path, and scan
+	// the project folder as --current does from inside it.
+	devRoot := t.TempDir()
+	projectDir := filepath.Join(devRoot, "Platform")
+	if err := os.Rename(workspace, projectDir); err != nil {
+		t.Fatalf("Failed to move workspace into dev path: %v", err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("git.dev_path", devRoot)
+
+	if err := os.WriteFile(filepath.Join(projectDir, "dirty-repo", "new-file.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("Failed to create untracked file: %v", err)
+	}
+
+	repos, err := findGitRepositories(projectDir)
+	if err != nil {
+		t.Fatalf("findGitRepositories failed: %v", err)
+	}
+
+	projects := []config.Project{
+		{
+			Name:  "Platform",
+			Repos: []config.ProjectRepo{{URL: "git@github.com:org/dirty-repo.git"}},
+		},
+	}
+
+	reports := collectRepoStatusReports(context.Background(), repos, projectsDevPath(), projects, false)
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 reports, got %d", len(reports))
+	}
+
+	byName := make(map[string]repoStatusReport)
+	for _, r := range reports {
+		byName[r.Name] = r
+	}
+
+	clean := byName["clean-repo"]
+	if clean.Dirty || clean.Status.Branch != "main" || clean.Project != "" {
+		t.Errorf("Unexpected clean-repo report: %+v", clean)
+	}
+
+	dirty := byName["dirty-repo"]
+	if !dirty.Dirty || dirty.Status.UntrackedCount != 1 {
+		t.Errorf("Expected dirty-repo to report 1 untracked file, got %+v", dirty)
+	}
+	if dirty.Project != "Platform" {
+		t.Errorf("Expected dirty-repo to belong to project Platform, got %q", dirty.Project)
+	}
+
+	// Resolving against the scanned project folder instead of the dev path finds no project.
+	for _, r := range collectRepoStatusReports(context.Background(), repos, projectDir, projects, false) {
+		if r.Project != "" {
+			t.Errorf("Expected no project for %s when resolving against the scanned folder, got %q", r.Name, r.Project)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := cmdutil.WriteStructured(&buf, cmdutil.OutputJSON, reports); err != nil {
+		t.Fatalf("WriteStructured failed: %v", err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	status, ok := decoded[0]["status"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected nested status object, got %v", decoded[0])
+	}
+	keys := []string{"branch", "ahead", "behind", "staged", "unstaged", "untracked", "conflicts", "ongoing_op"}
+	for _, key := range keys {
+		if _, found := status[key]; !found {
+			t.Errorf("Expected status key %q in JSON output", key)
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
 
+	"github.com/go-git/go-git/v5"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 
@@ -20,7 +23,12 @@ import (
 var SyncAllCmd = &cobra.Command{
 	Use:   "sync-all",
 	Short: "Sync all git repositories in development folder",
-	Long:  `This command fetches and pulls with rebase for all git repositories found in your development folder.`,
+	Long: `This command fetches and pulls with rebase for all git repositories found in your development folder.
+
+Repositories with uncommitted changes are skipped unless --autostash-dirty is set, in which
+case the changes (including untracked files) are stashed, the pull runs, and the changes are
+re-applied. If re-applying conflicts, the changes are kept in a named stash and the conflicted
+files are listed per repository.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		printHeader("🔄 Syncing Git Repositories")
 
@@ -43,8 +51,12 @@ var SyncAllCmd = &cobra.Command{
 
 		log.Info("Found %d git repositories", len(repos))
 
+		autostash, _ := cmd.Flags().GetBool("autostash-dirty")
+
 		var successCount atomic.Int32
 		var failureCount atomic.Int32
+		var conflictsMu sync.Mutex
+		stashConflicts := make(map[string]*repo.StashConflictError)
 
 		multi, err := ui.NewMultiSpinner()
 		if err != nil {
@@ -78,15 +90,30 @@ var SyncAllCmd = &cobra.Command{
 					return nil
 				}
 
-				if isDirty {
+				if isDirty && !autostash {
 					spinner.Warning(fmt.Sprintf("Repository %s has uncommitted changes, skipping...", repoName))
 					failureCount.Add(1)
 					return nil
 				}
 
 				// Pull latest code
-				spinner.UpdateText(fmt.Sprintf("Pulling %s...", repoName))
-				if err := repo.PullLatestCode(cmd.Context(), rPath); err != nil {
+				pull := repo.PullLatestCode
+				if isDirty {
+					spinner.UpdateText(fmt.Sprintf("Stashing and pulling %s...", repoName))
+					pull = repo.PullWithAutostash
+				} else {
+					spinner.UpdateText(fmt.Sprintf("Pulling %s...", repoName))
+				}
+				if err := pull(cmd.Context(), rPath); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+					var conflictErr *repo.StashConflictError
+					if errors.As(err, &conflictErr) {
+						spinner.Fail(fmt.Sprintf("Pulled %s but stashed changes conflict", repoName))
+						conflictsMu.Lock()
+						stashConflicts[repoName] = conflictErr
+						conflictsMu.Unlock()
+						failureCount.Add(1)
+						return nil
+					}
 					spinner.Fail(fmt.Sprintf("Failed to pull latest code for %s: %s", repoName, err))
 					failureCount.Add(1)
 					return nil
@@ -112,15 +139,20 @@ var SyncAllCmd = &cobra.Command{
 		} else {
 			theme.SuccessMessage(summaryMsg)
 		}
+
+		repo.PrintStashConflicts(stashConflicts)
 	},
 }
 
 func init() {
 	SyncAllCmd.Flags().Bool("dry-run", false, "Perform a dry run without making actual changes")
+	SyncAllCmd.Flags().
+		Bool("autostash-dirty", false, "Stash uncommitted changes, pull, and re-apply them instead of skipping")
 }
 
 // findGitRepositories scans the given directory for git repositories.
 // It returns a slice of absolute paths to directories containing .git folders.
+// Results are served from the status cache while the directory is unchanged.
 func findGitRepositories(devPath string) ([]string, error) {
 	var repos []string
 
@@ -129,6 +161,13 @@ func findGitRepositories(devPath string) ([]string, error) {
 		return nil, fmt.Errorf("development path does not exist: %s", devPath)
 	}
 
+	cache := repo.ActiveStatusCache()
+	if cache != nil {
+		if cached, ok := cache.GetRepoScan(devPath); ok {
+			return cached, nil
+		}
+	}
+
 	// Read the development directory
 	entries, err := os.ReadDir(devPath)
 	if err != nil {
@@ -149,5 +188,9 @@ func findGitRepositories(devPath string) ([]string, error) {
 		}
 	}
 
+	if cache != nil {
+		_ = cache.PutRepoScan(devPath, repos)
+	}
+
 	return repos, nil
 }
@@ -0,0 +1,140 @@
+package project
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	internalProject "github.com/eng618/eng/internal/project"
+	"github.com/eng618/eng/internal/ui"
+)
+
+// DiscoverCmd defines the cobra command for building project definitions from existing clones.
+var DiscoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Discover projects from repositories already cloned in the development folder",
+	Long: `This command scans your development folder for existing clones laid out as
+<dev-path>/<group>/<repo>, reads each clone's origin remote, and proposes one project per
+group directory. Repositories that are already configured are left out, so the command
+can be re-run safely after cloning more repositories by hand.
+
+You choose which projects to save from a list; all proposals are selected by default.
+Groups matching an existing project add their new repositories to that project.
+
+Clones directly in the development folder (no group directory) and clones without an
+origin remote are listed but not added.
+
+Example:
+  eng project discover            # Review and select discovered projects
+  eng project discover --dry-run  # Only show what would be added
+  eng project discover --yes      # Save every discovered project without prompting`,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Start("Discovering projects")
+
+		devPath := config.GetGitConfig().DevPath
+		if devPath == "" {
+			log.Error("Development folder path is not set. Use 'eng config git-dev-path' to set it.")
+			return
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = cmdutil.FallbackContext()
+		}
+
+		result, err := internalProject.Discover(ctx, internalProject.DiscoverOptions{
+			IsVerbose: cmdutil.IsVerbose(cmd),
+			DevPath:   os.ExpandEnv(devPath),
+			Projects:  config.GetProjects(),
+		})
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		printDiscoveryNotes(result)
+
+		labels := make([]string, 0, len(result.Candidates))
+		byLabel := make(map[string]internalProject.DiscoveryCandidate)
+		for _, c := range result.Candidates {
+			if len(c.NewRepos) == 0 {
+				continue
+			}
+			label := c.Label()
+			labels = append(labels, label)
+			byLabel[label] = c
+		}
+
+		if len(labels) == 0 {
+			log.Info("No new repositories found.")
+			return
+		}
+
+		if dryRun {
+			log.Info("[DRY RUN] Would offer the following projects:")
+			for _, label := range labels {
+				log.Info("  - %s", label)
+			}
+			return
+		}
+
+		selected := labels
+		if !assumeYes {
+			selected, err = ui.MultiSelect("Select projects to save:", labels, labels)
+			if err != nil {
+				log.Error("Prompt failed: %s", err)
+				return
+			}
+		}
+		if len(selected) == 0 {
+			log.Info("Canceled.")
+			return
+		}
+
+		saved := 0
+		for _, label := range selected {
+			candidate := byLabel[label]
+			if err := config.AddProject(candidate.Project); err != nil {
+				log.Error("Failed to save project %s: %s", candidate.Project.Name, err)
+				continue
+			}
+			log.Success("Saved %s with %d new repositories", candidate.Project.Name, len(candidate.NewRepos))
+			saved++
+		}
+
+		if saved > 0 {
+			log.Info("Run 'eng project list' to review the configured projects.")
+		}
+	},
+}
+
+func init() {
+	DiscoverCmd.Flags().BoolP("yes", "y", false, "Save every discovered project without prompting")
+}
+
+// printDiscoveryNotes reports clones and repositories that discovery could not turn into projects.
+func printDiscoveryNotes(result internalProject.DiscoveryResult) {
+	for _, c := range result.Candidates {
+		for _, conflict := range c.Conflicts {
+			log.Warn("Skipping %s/%s (%s): %s", conflict.Project, conflict.Path, conflict.URL, conflict.Reason)
+		}
+	}
+	if len(result.Ungrouped) > 0 {
+		log.Warn("Clones directly in the development folder (move them into a group directory to include them):")
+		for _, name := range result.Ungrouped {
+			log.Warn("  - %s", name)
+		}
+	}
+	if len(result.NoOrigin) > 0 {
+		log.Warn("Clones without an origin remote (skipped):")
+		for _, name := range result.NoOrigin {
+			log.Warn("  - %s", name)
+		}
+	}
+}
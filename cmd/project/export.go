@@ -0,0 +1,89 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+)
+
+// ExportCmd defines the cobra command for exporting project definitions to a manifest.
+var ExportCmd = &cobra.Command{
+	Use:   "export [project...]",
+	Short: "Export project definitions to a shareable YAML or JSON manifest",
+	Long: `This command writes the selected projects (or all projects) from your configuration
+to a standalone manifest. The manifest includes repository URLs, custom paths, and sync
+policies, and can be checked into a team repository and loaded with 'eng project import'.
+
+The manifest is written to stdout unless --file is given.
+
+Example:
+  eng project export                          # Export every project as YAML
+  eng project export MyProject -f team.yaml   # Export one project to a file
+  eng project export --format json            # Export as JSON`,
+	Run: func(cmd *cobra.Command, args []string) {
+		formatValue, _ := cmd.Flags().GetString("format")
+		format, err := cmdutil.ParseOutputFormat(formatValue)
+		if err != nil || !format.IsStructured() {
+			log.Error("Invalid manifest format %q (expected yaml or json)", formatValue)
+			return
+		}
+
+		names := args
+		if projectFilter, _ := cmd.Flags().GetString("project"); len(names) == 0 && projectFilter != "" {
+			names = []string{projectFilter}
+		}
+
+		projects := config.GetProjects()
+		if len(projects) == 0 {
+			log.Warn("No projects configured. Use 'eng project add' to add a project.")
+			return
+		}
+
+		manifest, err := config.ExportProjects(projects, names)
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			out, restore := cmdutil.RedirectLogsForStructuredOutput()
+			defer restore()
+
+			if err := cmdutil.WriteStructured(out, format, manifest); err != nil {
+				log.Error("Failed to write manifest: %s", err)
+			}
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := cmdutil.WriteStructured(&buf, format, manifest); err != nil {
+			log.Error("Failed to encode manifest: %s", err)
+			return
+		}
+		if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil {
+			log.Error("Failed to write manifest: %s", err)
+			return
+		}
+		log.Success("Exported %s to %s", pluralizeProjects(len(manifest.Projects)), file)
+	},
+}
+
+func init() {
+	ExportCmd.Flags().String("format", string(cmdutil.OutputYAML), "Manifest format: yaml or json")
+	ExportCmd.Flags().StringP("file", "f", "", "Write the manifest to this file instead of stdout")
+}
+
+// pluralizeProjects formats a project count for summary messages.
+func pluralizeProjects(n int) string {
+	if n == 1 {
+		return "1 project"
+	}
+	return fmt.Sprintf("%d projects", n)
+}
@@ -46,12 +46,14 @@ Example:
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		force, _ := cmd.Flags().GetBool("force")
 		projectFilter, _ := cmd.Flags().GetString("project")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
 
 		opts := internalProject.FetchOptions{
 			DryRun:        dryRun,
 			Force:         force,
 			IsVerbose:     cmdutil.IsVerbose(cmd),
 			ProjectFilter: projectFilter,
+			Tags:          tags,
 			DevPath:       os.ExpandEnv(devPath),
 			Projects:      config.GetProjects(),
 		}
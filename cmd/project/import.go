@@ -0,0 +1,101 @@
+package project
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+)
+
+// ImportCmd defines the cobra command for merging a project manifest into the configuration.
+var ImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import project definitions from a YAML or JSON manifest",
+	Long: `This command merges the projects in a manifest (see 'eng project export') into your
+configuration. The manifest can be a local path or a file:// URL.
+
+New projects are added as-is. Repositories are added to existing projects unless they
+conflict with your configuration:
+  - the repository URL is already configured in another project
+  - the repository URL is already configured under a different path
+  - another repository already uses the same path in the project
+
+The branch, auto_switch, pull_strategy, skip_sync, tags, and source of an existing project
+are never changed by an import; values in the manifest that differ from them are reported
+as conflicts.
+
+By default any conflict aborts the import and nothing is written. Use --skip-conflicts
+to import everything else and leave the conflicting repositories out. Repositories that
+are already configured identically are left untouched.
+
+Example:
+  eng project import team-projects.yaml
+  eng project import file:///srv/onboarding/projects.json --dry-run
+  eng project import team-projects.yaml --skip-conflicts && eng project setup`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Start("Importing project definitions")
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		skipConflicts, _ := cmd.Flags().GetBool("skip-conflicts")
+
+		manifest, err := config.ReadManifest(args[0])
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		merged, result := config.MergeProjects(config.GetProjects(), manifest.Projects)
+
+		if len(result.Conflicts) > 0 {
+			log.Warn("Conflicts:")
+			for _, c := range result.Conflicts {
+				if c.URL == "" {
+					log.Warn("  - %s: %s", c.Project, c.Reason)
+					continue
+				}
+				log.Warn("  - %s/%s (%s): %s", c.Project, c.Path, c.URL, c.Reason)
+			}
+			if !skipConflicts {
+				log.Error(
+					"Import aborted: %d conflict(s). Resolve them or re-run with --skip-conflicts.",
+					len(result.Conflicts),
+				)
+				return
+			}
+		}
+
+		log.Info(
+			"%d new project(s), %d repository(ies) to add, %d already configured, %d conflict(s) skipped",
+			len(result.AddedProjects),
+			result.AddedRepos,
+			result.Unchanged,
+			len(result.Conflicts),
+		)
+		if len(result.AddedProjects) > 0 {
+			log.Info("New projects: %s", strings.Join(result.AddedProjects, ", "))
+		}
+
+		if result.AddedRepos == 0 && len(result.AddedProjects) == 0 {
+			log.Info("Nothing to import.")
+			return
+		}
+		if dryRun {
+			log.Info("[DRY RUN] Configuration not modified")
+			return
+		}
+
+		if err := config.SaveProjects(merged); err != nil {
+			log.Error("%s", err)
+			return
+		}
+		log.Success("Imported %s from %s", pluralizeProjects(len(manifest.Projects)), args[0])
+		log.Info("Run 'eng project setup' to clone the new repositories.")
+	},
+}
+
+func init() {
+	ImportCmd.Flags().Bool("skip-conflicts", false, "Import non-conflicting repositories and skip the rest")
+}
@@ -26,17 +26,20 @@ Use the --verbose flag to see detailed information including:
   - Clone status (✓ cloned / ✗ missing)
   - Local paths
 
+Use --output json or --output yaml to emit each project with its repositories,
+clone status, and detailed git status (branch, ahead/behind, change counts,
+and any in-progress operation) for scripts and CI dashboards.
+
 Example:
   eng project list               # Show projects summary
   eng project list -v            # Show detailed repository information
-  eng project list -p MyProject  # Show only the specified project`,
+  eng project list -p MyProject  # Show only the specified project
+  eng project list -o json       # Emit machine-readable JSON`,
 	Run: func(cmd *cobra.Command, args []string) {
-		headerStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(theme.Primary).
-			MarginBottom(1)
-		if !ui.DisableProgress {
-			fmt.Fprintln(log.Out, headerStyle.Render("📌 Project Repository Collections"))
+		format, err := cmdutil.GetOutputFormat(cmd)
+		if err != nil {
+			log.Error("%s", err)
+			return
 		}
 
 		gitCfg := config.GetGitConfig()
@@ -49,6 +52,29 @@ Example:
 			Projects:      config.GetProjects(),
 		}
 
+		if format.IsStructured() {
+			out, restore := cmdutil.RedirectLogsForStructuredOutput()
+			defer restore()
+
+			report := internalProject.BuildListReport(cmd.Context(), opts)
+			if err := cmdutil.WriteStructured(out, format, report); err != nil {
+				log.Error("Failed to write %s output: %s", format, err)
+			}
+			return
+		}
+
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.Primary).
+			MarginBottom(1)
+		if !ui.DisableProgress {
+			fmt.Fprintln(log.Out, headerStyle.Render("📌 Project Repository Collections"))
+		}
+
 		internalProject.List(opts)
 	},
 }
+
+func init() {
+	cmdutil.AddOutputFlag(ListCmd)
+}
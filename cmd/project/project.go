@@ -11,7 +11,8 @@ import (
 )
 
 // ProjectCmd serves as the base command for all project management operations.
-// It groups subcommands like setup, list, add, remove, fetch, pull, and sync.
+// It groups subcommands like setup, list, add, remove, fetch, pull, sync, export, import, discover,
+// and worktree.
 var ProjectCmd = &cobra.Command{
 	Use:   "project",
 	Short: "Manage project-based repository collections",
@@ -73,6 +74,8 @@ Example structure:
 func init() {
 	ProjectCmd.Flags().BoolP("info", "i", false, "Show current project management configuration")
 	ProjectCmd.PersistentFlags().StringP("project", "p", "", "Filter operations to a specific project")
+	ProjectCmd.PersistentFlags().
+		StringSlice("tag", nil, "Limit setup, fetch, pull, and sync to repositories with any of these tags")
 	ProjectCmd.PersistentFlags().Bool("dry-run", false, "Perform a dry run without making actual changes")
 	ProjectCmd.PersistentFlags().Bool("force", false, "Force overwrite tags on fetch conflicts (bypasses prompts)")
 
@@ -84,4 +87,8 @@ func init() {
 	ProjectCmd.AddCommand(FetchCmd)
 	ProjectCmd.AddCommand(PullCmd)
 	ProjectCmd.AddCommand(SyncCmd)
+	ProjectCmd.AddCommand(ExportCmd)
+	ProjectCmd.AddCommand(ImportCmd)
+	ProjectCmd.AddCommand(DiscoverCmd)
+	ProjectCmd.AddCommand(WorktreeCmd)
 }
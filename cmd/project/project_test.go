@@ -2,7 +2,9 @@ package project
 
 import (
 	"context"
+	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -514,3 +516,163 @@ func TestRemoveCmd_NoProjects(t *testing.T) {
 	out := buf.String()
 	assert.Contains(t, out, "No projects configured")
 }
+
+func TestListCmd_JSONOutput(t *testing.T) {
+	var out, errOut ThreadSafeBuffer
+	log.SetWriters(&out, &errOut)
+	defer log.ResetWriters()
+
+	workspace, _, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	testProjects := []config.Project{
+		{
+			Name: "JSONProject",
+			Repos: []config.ProjectRepo{
+				{URL: "git@github.com:org/repo1.git"},
+			},
+		},
+	}
+	require.NoError(t, config.SaveProjects(testProjects))
+	require.NoError(t, os.MkdirAll(filepath.Join(workspace, "JSONProject"), 0o755))
+
+	require.NoError(t, ListCmd.Flags().Set("output", "json"))
+	defer func() { _ = ListCmd.Flags().Set("output", "table") }()
+
+	ListCmd.Run(ListCmd, []string{})
+
+	var reports []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &reports), "stdout should contain only JSON")
+	require.Len(t, reports, 1)
+	assert.Equal(t, "JSONProject", reports[0]["name"])
+
+	repos, ok := reports[0]["repos"].([]any)
+	require.True(t, ok)
+	require.Len(t, repos, 1)
+	repoReport := repos[0].(map[string]any)
+	assert.Equal(t, "git@github.com:org/repo1.git", repoReport["url"])
+	assert.Equal(t, false, repoReport["cloned"])
+}
+
+func TestExportImportCmd_RoundTrip(t *testing.T) {
+	var out, errOut ThreadSafeBuffer
+	log.SetWriters(&out, &errOut)
+	defer log.ResetWriters()
+
+	_, _, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	require.NoError(t, config.SaveProjects([]config.Project{
+		{
+			Name:   "Team",
+			Branch: "develop",
+			Repos: []config.ProjectRepo{
+				{URL: "git@github.com:org/api.git"},
+				{URL: "git@github.com:org/web.git", Path: "frontend"},
+			},
+		},
+	}))
+
+	manifestPath := filepath.Join(t.TempDir(), "team.json")
+	require.NoError(t, ExportCmd.Flags().Set("format", "json"))
+	require.NoError(t, ExportCmd.Flags().Set("file", manifestPath))
+	defer func() {
+		_ = ExportCmd.Flags().Set("format", "yaml")
+		_ = ExportCmd.Flags().Set("file", "")
+	}()
+
+	ExportCmd.Run(ExportCmd, []string{"Team"})
+	assert.Contains(t, out.String(), "Exported 1 project to")
+
+	var manifest config.ProjectManifest
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	require.Len(t, manifest.Projects, 1)
+	assert.Equal(t, "develop", manifest.Projects[0].Branch)
+
+	// Import into a configuration that has one conflicting and one identical repository, and
+	// whose project keeps the default branch.
+	require.NoError(t, config.SaveProjects([]config.Project{
+		{
+			Name: "Team",
+			Repos: []config.ProjectRepo{
+				{URL: "git@github.com:org/api.git"},
+				{URL: "git@github.com:other/frontend.git"},
+			},
+		},
+	}))
+
+	ImportCmd.Run(ImportCmd, []string{manifestPath})
+	assert.Contains(t, out.String(), `Team: branch "develop" differs from the configured (unset)`)
+	assert.Contains(t, errOut.String(), "Import aborted: 2 conflict(s)")
+	assert.Len(t, config.GetProjectByName("Team").Repos, 2, "aborted import must not modify the configuration")
+
+	require.NoError(t, ImportCmd.Flags().Set("skip-conflicts", "true"))
+	defer func() { _ = ImportCmd.Flags().Set("skip-conflicts", "false") }()
+
+	ImportCmd.Run(ImportCmd, []string{"file://" + manifestPath})
+	assert.Contains(
+		t,
+		out.String(),
+		"0 new project(s), 0 repository(ies) to add, 1 already configured, 2 conflict(s) skipped",
+	)
+	assert.Contains(t, out.String(), "Nothing to import.")
+
+	// A fresh configuration receives the whole manifest.
+	require.NoError(t, config.SaveProjects([]config.Project{}))
+	ImportCmd.Run(ImportCmd, []string{manifestPath})
+
+	imported := config.GetProjectByName("Team")
+	require.NotNil(t, imported)
+	assert.Equal(t, "develop", imported.Branch)
+	assert.Len(t, imported.Repos, 2)
+}
+
+func TestDiscoverCmd_SavesSelectedProjects(t *testing.T) {
+	var out, errOut ThreadSafeBuffer
+	log.SetWriters(&out, &errOut)
+	defer log.ResetWriters()
+
+	workspace, _, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	for dir, origin := range map[string]string{
+		"Team/api":   "git@github.com:org/api.git",
+		"Team/web":   "git@github.com:org/web.git",
+		"Other/tool": "git@github.com:org/tool.git",
+	} {
+		repoDir := filepath.Join(workspace, dir)
+		require.NoError(t, os.MkdirAll(repoDir, 0o755))
+		for _, args := range [][]string{{"init", "-q"}, {"remote", "add", "origin", origin}} {
+			c := exec.Command("git", args...)
+			c.Dir = repoDir
+			output, err := c.CombinedOutput()
+			require.NoError(t, err, string(output))
+		}
+	}
+
+	oldMultiSelect := ui.MultiSelect
+	var offered []string
+	ui.MultiSelect = func(_ string, options, _ []string) ([]string, error) {
+		offered = options
+		return options[1:], nil // Only accept "Team"
+	}
+	defer func() { ui.MultiSelect = oldMultiSelect }()
+
+	DiscoverCmd.Run(DiscoverCmd, []string{})
+
+	assert.Equal(t, []string{
+		"Other (new project): tool",
+		"Team (new project): api, web",
+	}, offered)
+
+	projects := config.GetProjects()
+	require.Len(t, projects, 1)
+	assert.Equal(t, "Team", projects[0].Name)
+	assert.Equal(t, []config.ProjectRepo{
+		{URL: "git@github.com:org/api.git"},
+		{URL: "git@github.com:org/web.git"},
+	}, projects[0].Repos)
+	assert.Contains(t, out.String(), "Saved Team with 2 new repositories")
+}
@@ -46,11 +46,13 @@ Example:
 
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		projectFilter, _ := cmd.Flags().GetString("project")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
 
 		opts := internalProject.PullOptions{
 			DryRun:        dryRun,
 			IsVerbose:     cmdutil.IsVerbose(cmd),
 			ProjectFilter: projectFilter,
+			Tags:          tags,
 			DevPath:       os.ExpandEnv(devPath),
 			Projects:      config.GetProjects(),
 		}
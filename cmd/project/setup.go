@@ -23,6 +23,14 @@ var SetupCmd = &cobra.Command{
 set up and all repositories are cloned.
 
 It is safe to run multiple times - existing repositories will be skipped.
+
+Projects with a 'source' (a GitHub organization or GitLab group) are enumerated through
+the provider's REST API first. Repositories that are not archived or forks (unless the
+source includes them) and not yet configured are added to the project and cloned. Set
+GITHUB_TOKEN (or GH_TOKEN) to list private GitHub repositories. GitLab groups use the token
+of the 'eng gitlab' commands: GITLAB_TOKEN, the Bitwarden item named by gitlab.tokenItem,
+or gitlab.token; sources without a host use gitlab.host.
+
 Use this command when:
   - Setting up a new development machine
   - A new repository has been added to a project's configuration
@@ -31,7 +39,7 @@ Use this command when:
 Example:
   eng project setup                  # Setup all projects
   eng project setup -p MyProject     # Setup only the specified project
-  eng project setup --dry-run        # Preview what would be done`,
+  eng project setup --dry-run        # Preview what would be done, including new source repositories`,
 	Run: func(cmd *cobra.Command, args []string) {
 		headerStyle := lipgloss.NewStyle().
 			Bold(true).
@@ -50,13 +58,16 @@ Example:
 
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		projectFilter, _ := cmd.Flags().GetString("project")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
 
 		opts := internalProject.SetupOptions{
 			DryRun:        dryRun,
 			IsVerbose:     cmdutil.IsVerbose(cmd),
 			ProjectFilter: projectFilter,
+			Tags:          tags,
 			DevPath:       os.ExpandEnv(devPath),
 			Projects:      config.GetProjects(),
+			SaveProjects:  config.SaveProjects,
 		}
 
 		ctx := cmd.Context()
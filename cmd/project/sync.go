@@ -25,11 +25,24 @@ var SyncCmd = &cobra.Command{
   2. Pulling changes for the current branch (git pull)
 
 Repositories with uncommitted changes will have fetch performed but pull will be skipped.
+Use --autostash-dirty to stash those changes (including untracked files), pull, and re-apply
+them. If re-applying conflicts, the changes are kept in a named stash and the conflicted
+files are listed per repository.
+
+Per-project and per-repository sync policies from the config file are honored: repositories
+with skip_sync are left alone, a pinned branch (or auto_switch to the default branch) is checked
+out before pulling, and pull_strategy selects rebase, ff-only, or merge. Use --tag to limit the
+sync to repositories carrying one of the given tags.
+
+Linked worktrees (see 'eng project worktree') share their repository's fetch: after the main
+clone is synced, each clean worktree is updated from its already fetched upstream branch.
 
 Example:
-  eng project sync                  # Sync all projects
-  eng project sync -p MyProject     # Sync only the specified project
-  eng project sync --dry-run        # Preview what would be synced`,
+  eng project sync                    # Sync all projects
+  eng project sync -p MyProject       # Sync only the specified project
+  eng project sync --dry-run          # Preview what would be synced
+  eng project sync --autostash-dirty  # Also pull repositories with uncommitted changes
+  eng project sync --tag backend      # Sync only repositories tagged "backend"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		headerStyle := lipgloss.NewStyle().
 			Bold(true).
@@ -47,14 +60,18 @@ Example:
 		}
 
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		autostash, _ := cmd.Flags().GetBool("autostash-dirty")
 		projectFilter, _ := cmd.Flags().GetString("project")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
 
 		opts := internalProject.SyncOptions{
-			DryRun:        dryRun,
-			IsVerbose:     cmdutil.IsVerbose(cmd),
-			ProjectFilter: projectFilter,
-			DevPath:       os.ExpandEnv(devPath),
-			Projects:      config.GetProjects(),
+			DryRun:         dryRun,
+			IsVerbose:      cmdutil.IsVerbose(cmd),
+			AutostashDirty: autostash,
+			ProjectFilter:  projectFilter,
+			Tags:           tags,
+			DevPath:        os.ExpandEnv(devPath),
+			Projects:       config.GetProjects(),
 		}
 
 		ctx := cmd.Context()
@@ -65,3 +82,7 @@ Example:
 		internalProject.Sync(ctx, opts)
 	},
 }
+
+func init() {
+	SyncCmd.Flags().Bool("autostash-dirty", false, "Stash uncommitted changes, pull, and re-apply them instead of skipping")
+}
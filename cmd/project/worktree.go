@@ -0,0 +1,204 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	internalProject "github.com/eng618/eng/internal/project"
+)
+
+// WorktreeCmd groups the commands for managing git worktrees of project repositories.
+var WorktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "Manage git worktrees of project repositories",
+	Long: `This command manages linked git worktrees for repositories in configured projects.
+
+Worktrees are created next to the repository's clone in the project folder, named
+<repo>@<branch> (slashes in the branch become dashes), so several branches of the
+same repository can be checked out side by side:
+
+  ~/Development/MyProject/api           # main clone
+  ~/Development/MyProject/api@feature-x # worktree on feature/x
+
+'eng project sync' fetches each repository once and then updates its worktrees from
+the fetched upstream branches. Worktrees are also shown by 'eng project list -v' and
+in the dashboard.
+
+Example:
+  eng project worktree add api feature/x
+  eng project worktree list
+  eng project worktree remove api feature/x`,
+}
+
+// WorktreeAddCmd creates a worktree for a branch of a project repository.
+var WorktreeAddCmd = &cobra.Command{
+	Use:   "add <repo> <branch>",
+	Short: "Check out a branch of a repository in a sibling worktree",
+	Long: `This command checks out <branch> of <repo> in a new worktree next to the clone.
+
+An existing local branch is used as is. Otherwise a branch tracking origin/<branch> is
+created when the remote has one, or a new branch is started from the clone's HEAD.
+<repo> is the repository's path in the project; use --project when the same name is
+configured in several projects.
+
+Example:
+  eng project worktree add api feature/x
+  eng project worktree add api hotfix -p MyProject`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		opts, ok := worktreeOptions(cmd)
+		if !ok {
+			return
+		}
+
+		path, err := internalProject.AddWorktree(cmd.Context(), opts, args[0], args[1])
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+		if !opts.DryRun {
+			log.Success("Checked out %s of %s in %s", args[1], args[0], path)
+		}
+	},
+}
+
+// WorktreeListCmd lists the worktrees of project repositories.
+var WorktreeListCmd = &cobra.Command{
+	Use:   "list [repo]",
+	Short: "List worktrees of project repositories",
+	Long: `This command lists the linked worktrees of a repository, or of every cloned
+repository in the configured projects when no repository is given.
+
+Example:
+  eng project worktree list
+  eng project worktree list api
+  eng project worktree list -o json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := cmdutil.GetOutputFormat(cmd)
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		opts, ok := worktreeOptions(cmd)
+		if !ok {
+			return
+		}
+
+		repoName := ""
+		if len(args) == 1 {
+			repoName = args[0]
+		}
+
+		if format.IsStructured() {
+			out, restore := cmdutil.RedirectLogsForStructuredOutput()
+			defer restore()
+
+			result, err := internalProject.ListWorktrees(cmd.Context(), opts, repoName)
+			if err != nil {
+				log.Error("%s", err)
+				return
+			}
+			if result == nil {
+				result = []internalProject.RepoWorktrees{}
+			}
+			if err := cmdutil.WriteStructured(out, format, result); err != nil {
+				log.Error("Failed to write %s output: %s", format, err)
+			}
+			return
+		}
+
+		result, err := internalProject.ListWorktrees(cmd.Context(), opts, repoName)
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+
+		count := 0
+		for _, r := range result {
+			log.Info("%s/%s", r.Project, r.Repo)
+			if len(r.Worktrees) == 0 {
+				log.Info("  (no worktrees)")
+			}
+			for _, wt := range r.Worktrees {
+				branch := wt.Branch
+				if branch == "" {
+					branch = "detached"
+				}
+				log.Info("  %s  %s", filepath.Base(wt.Path), branch)
+				count++
+			}
+		}
+		if len(result) == 0 {
+			log.Info("No worktrees found. Use 'eng project worktree add <repo> <branch>' to create one.")
+			return
+		}
+		log.Info("")
+		log.Info("%d worktree(s)", count)
+	},
+}
+
+// WorktreeRemoveCmd removes a worktree of a project repository.
+var WorktreeRemoveCmd = &cobra.Command{
+	Use:   "remove <repo> <branch>",
+	Short: "Remove the worktree of a repository checked out on a branch",
+	Long: `This command removes the worktree of <repo> that has <branch> checked out.
+The branch itself is kept.
+
+Worktrees with uncommitted changes are refused unless --force is given.
+
+Example:
+  eng project worktree remove api feature/x
+  eng project worktree remove api feature/x --force`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		opts, ok := worktreeOptions(cmd)
+		if !ok {
+			return
+		}
+		force, _ := cmd.Flags().GetBool("force")
+
+		path, err := internalProject.RemoveWorktree(cmd.Context(), opts, args[0], args[1], force)
+		if err != nil {
+			log.Error("%s", err)
+			return
+		}
+		if !opts.DryRun {
+			log.Success("Removed worktree %s", path)
+		}
+	},
+}
+
+// worktreeOptions builds the shared options for the worktree subcommands.
+func worktreeOptions(cmd *cobra.Command) (internalProject.WorktreeOptions, bool) {
+	devPath := config.GetGitConfig().DevPath
+	if devPath == "" {
+		log.Error("Development folder path is not set. Use 'eng config git-dev-path' to set it.")
+		return internalProject.WorktreeOptions{}, false
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	projectFilter, _ := cmd.Flags().GetString("project")
+
+	return internalProject.WorktreeOptions{
+		DryRun:        dryRun,
+		IsVerbose:     cmdutil.IsVerbose(cmd),
+		ProjectFilter: projectFilter,
+		DevPath:       os.ExpandEnv(devPath),
+		Projects:      config.GetProjects(),
+	}, true
+}
+
+func init() {
+	cmdutil.AddOutputFlag(WorktreeListCmd)
+
+	WorktreeCmd.AddCommand(WorktreeAddCmd)
+	WorktreeCmd.AddCommand(WorktreeListCmd)
+	WorktreeCmd.AddCommand(WorktreeRemoveCmd)
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/eng618/eng/internal/cmdutil"
 	configUtils "github.com/eng618/eng/internal/config"
 	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
 	"github.com/eng618/eng/internal/ui"
 	"github.com/eng618/eng/internal/ui/theme"
 )
@@ -200,4 +201,22 @@ func initConfig() {
 
 	// Run migration to ensure keys are standardized
 	configUtils.MigrateConfig()
+
+	configureStatusCache()
+}
+
+// configureStatusCache installs the on-disk repository status cache when it is enabled in config.
+func configureStatusCache() {
+	gitCfg := configUtils.GetGitConfig()
+	if !gitCfg.StatusCache {
+		repo.SetStatusCache(nil)
+		return
+	}
+
+	dir, err := repo.DefaultStatusCacheDir()
+	if err != nil {
+		log.Verbose(cmdutil.IsVerbose(rootCmd), "Status cache disabled: %s", err)
+		return
+	}
+	repo.SetStatusCache(repo.NewStatusCache(dir, gitCfg.StatusCacheTTL))
 }
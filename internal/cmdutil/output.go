@@ -0,0 +1,91 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/eng618/eng/internal/log"
+)
+
+// OutputFormat identifies how a command renders its results.
+type OutputFormat string
+
+const (
+	// OutputTable renders styled, human-readable output (the default).
+	OutputTable OutputFormat = "table"
+	// OutputJSON renders indented JSON suitable for scripts and CI pipelines.
+	OutputJSON OutputFormat = "json"
+	// OutputYAML renders YAML suitable for scripts and CI pipelines.
+	OutputYAML OutputFormat = "yaml"
+)
+
+// IsStructured reports whether the format is machine-readable (json or yaml).
+func (f OutputFormat) IsStructured() bool {
+	return f == OutputJSON || f == OutputYAML
+}
+
+// ParseOutputFormat converts a user-supplied string into an OutputFormat.
+// An empty string resolves to OutputTable.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case "", OutputTable:
+		return OutputTable, nil
+	case OutputJSON:
+		return OutputJSON, nil
+	case OutputYAML, "yml":
+		return OutputYAML, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q (expected json, yaml, or table)", value)
+	}
+}
+
+// AddOutputFlag registers the standard --output/-o flag on the given command.
+func AddOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", string(OutputTable), "Output format: table, json, or yaml")
+}
+
+// GetOutputFormat reads and validates the --output flag of the given command.
+// Commands without the flag resolve to OutputTable.
+func GetOutputFormat(cmd *cobra.Command) (OutputFormat, error) {
+	if cmd == nil || cmd.Flags().Lookup("output") == nil {
+		return OutputTable, nil
+	}
+	value, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+	return ParseOutputFormat(value)
+}
+
+// WriteStructured encodes v to w using the given structured format.
+func WriteStructured(w io.Writer, format OutputFormat, v any) error {
+	switch format {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case OutputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("output format %q is not a structured format", format)
+	}
+}
+
+// RedirectLogsForStructuredOutput routes informational log output to the error writer
+// so that the normal output stream carries only machine-readable data.
+// It returns the original output writer and a function that restores it.
+func RedirectLogsForStructuredOutput() (io.Writer, func()) {
+	out := log.Out
+	log.SetWriters(log.Err, nil)
+	return out, func() { log.SetWriters(out, nil) }
+}
@@ -0,0 +1,81 @@
+package cmdutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/eng618/eng/internal/cmdutil"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    cmdutil.OutputFormat
+		wantErr bool
+	}{
+		{input: "", want: cmdutil.OutputTable},
+		{input: "table", want: cmdutil.OutputTable},
+		{input: "JSON", want: cmdutil.OutputJSON},
+		{input: "yaml", want: cmdutil.OutputYAML},
+		{input: "yml", want: cmdutil.OutputYAML},
+		{input: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := cmdutil.ParseOutputFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetOutputFormat(t *testing.T) {
+	cmd := &cobra.Command{}
+	format, err := cmdutil.GetOutputFormat(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, cmdutil.OutputTable, format, "commands without the flag default to table")
+
+	cmdutil.AddOutputFlag(cmd)
+	require.NoError(t, cmd.Flags().Set("output", "json"))
+	format, err = cmdutil.GetOutputFormat(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, cmdutil.OutputJSON, format)
+	assert.True(t, format.IsStructured())
+
+	require.NoError(t, cmd.Flags().Set("output", "csv"))
+	_, err = cmdutil.GetOutputFormat(cmd)
+	assert.Error(t, err)
+}
+
+func TestWriteStructured(t *testing.T) {
+	type item struct {
+		Name  string `json:"name" yaml:"name"`
+		Count int    `json:"count" yaml:"count"`
+	}
+	data := []item{{Name: "a", Count: 1}}
+
+	var jsonBuf bytes.Buffer
+	require.NoError(t, cmdutil.WriteStructured(&jsonBuf, cmdutil.OutputJSON, data))
+	var fromJSON []item
+	require.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &fromJSON))
+	assert.Equal(t, data, fromJSON)
+
+	var yamlBuf bytes.Buffer
+	require.NoError(t, cmdutil.WriteStructured(&yamlBuf, cmdutil.OutputYAML, data))
+	var fromYAML []item
+	require.NoError(t, yaml.Unmarshal(yamlBuf.Bytes(), &fromYAML))
+	assert.Equal(t, data, fromYAML)
+
+	assert.Error(t, cmdutil.WriteStructured(&bytes.Buffer{}, cmdutil.OutputTable, data))
+}
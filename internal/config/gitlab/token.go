@@ -0,0 +1,42 @@
+package gitlab
+
+import (
+	"os"
+
+	"github.com/eng618/eng/internal/bitwarden"
+	"github.com/eng618/eng/internal/log"
+)
+
+// ResolveToken returns the GitLab token from GITLAB_TOKEN, then from the Bitwarden item named
+// tokenItem (its login password, or a custom field named token), then configToken. It returns an
+// empty string when none is set; Bitwarden failures are logged as warnings.
+func ResolveToken(tokenItem, configToken string) string {
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		return token
+	}
+
+	if tokenItem != "" {
+		if _, err := bitwarden.EnsureBitwardenSession(); err != nil {
+			log.Warn("Bitwarden session not available: %v", err)
+		} else if item, err := bitwarden.GetBitwardenItem(tokenItem); err != nil {
+			log.Warn("Failed to read Bitwarden item '%s': %v", tokenItem, err)
+		} else if token := itemToken(item); token != "" {
+			return token
+		}
+	}
+
+	return configToken
+}
+
+// itemToken returns the login password of item, or the value of its custom field named token.
+func itemToken(item *bitwarden.BitwardenItem) string {
+	if item.Login != nil && item.Login.Password != "" {
+		return item.Login.Password
+	}
+	for _, f := range item.Fields {
+		if f.Name == "token" && f.Value != "" {
+			return f.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/eng618/eng/internal/bitwarden"
+)
+
+func TestResolveToken(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "glpat-env")
+	if got := ResolveToken("", "glpat-config"); got != "glpat-env" {
+		t.Errorf("expected GITLAB_TOKEN to take precedence, got %q", got)
+	}
+
+	t.Setenv("GITLAB_TOKEN", "")
+	if got := ResolveToken("", "glpat-config"); got != "glpat-config" {
+		t.Errorf("expected the config token without GITLAB_TOKEN or a Bitwarden item, got %q", got)
+	}
+}
+
+func TestItemToken(t *testing.T) {
+	tests := []struct {
+		name string
+		item bitwarden.BitwardenItem
+		want string
+	}{
+		{
+			name: "login password",
+			item: bitwarden.BitwardenItem{Login: &bitwarden.BitwardenLogin{Password: "glpat-login"}},
+			want: "glpat-login",
+		},
+		{
+			name: "token field",
+			item: bitwarden.BitwardenItem{Fields: []bitwarden.BitwardenField{{Name: "token", Value: "glpat-field"}}},
+			want: "glpat-field",
+		},
+		{name: "empty", item: bitwarden.BitwardenItem{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := itemToken(&tt.item); got != tt.want {
+				t.Errorf("itemToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
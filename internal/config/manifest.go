@@ -0,0 +1,307 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestVersion is the project manifest format written by ExportProjects.
+const ManifestVersion = 1
+
+// ProjectManifest is a standalone, shareable set of project definitions.
+// It is the format produced by `eng project export` and consumed by `eng project import`.
+type ProjectManifest struct {
+	Version  int       `yaml:"version"  json:"version"`
+	Projects []Project `yaml:"projects" json:"projects"`
+}
+
+// ImportConflict describes an incoming repository, or project policy, that clashes with the
+// existing configuration. URL and Path are empty for a project policy conflict.
+type ImportConflict struct {
+	Project string
+	URL     string
+	Path    string
+	Reason  string
+}
+
+// MergeResult summarizes what MergeProjects changed.
+type MergeResult struct {
+	AddedProjects []string
+	AddedRepos    int
+	Unchanged     int
+	Conflicts     []ImportConflict
+}
+
+// ExportProjects builds a manifest containing the named projects, or every project when names is empty.
+func ExportProjects(projects []Project, names []string) (ProjectManifest, error) {
+	manifest := ProjectManifest{Version: ManifestVersion}
+	if len(names) == 0 {
+		manifest.Projects = append(manifest.Projects, projects...)
+		return manifest, nil
+	}
+
+	for _, name := range names {
+		found := false
+		for _, p := range projects {
+			if strings.EqualFold(p.Name, name) {
+				manifest.Projects = append(manifest.Projects, p)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ProjectManifest{}, fmt.Errorf("project %s not found", name)
+		}
+	}
+	return manifest, nil
+}
+
+// ReadManifest loads a manifest from a local path or a file:// URL.
+func ReadManifest(source string) (ProjectManifest, error) {
+	path := source
+	if strings.Contains(source, "://") {
+		u, err := url.Parse(source)
+		if err != nil {
+			return ProjectManifest{}, fmt.Errorf("failed to parse manifest location: %w", err)
+		}
+		if u.Scheme != "file" {
+			return ProjectManifest{}, fmt.Errorf(
+				"unsupported manifest location %s (use a local path or file:// URL)",
+				source,
+			)
+		}
+		path = u.Path
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ProjectManifest{}, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return ParseManifest(data)
+}
+
+// ParseManifest decodes a YAML or JSON manifest and validates its contents.
+func ParseManifest(data []byte) (ProjectManifest, error) {
+	var manifest ProjectManifest
+	// JSON is a subset of YAML, so a single decoder handles both formats.
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return ProjectManifest{}, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if manifest.Version > ManifestVersion {
+		return ProjectManifest{}, fmt.Errorf(
+			"manifest version %d is newer than supported version %d",
+			manifest.Version,
+			ManifestVersion,
+		)
+	}
+	if len(manifest.Projects) == 0 {
+		return ProjectManifest{}, fmt.Errorf("manifest contains no projects")
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range manifest.Projects {
+		if strings.TrimSpace(p.Name) == "" {
+			return ProjectManifest{}, fmt.Errorf("manifest contains a project without a name")
+		}
+		// Projects and repositories are cloned to <dev_path>/<project>/<path>, so both must stay
+		// inside the development folder.
+		if !filepath.IsLocal(p.Name) {
+			return ProjectManifest{}, fmt.Errorf("project name %q is not a local path", p.Name)
+		}
+		if seen[strings.ToLower(p.Name)] {
+			return ProjectManifest{}, fmt.Errorf("manifest defines project %s more than once", p.Name)
+		}
+		seen[strings.ToLower(p.Name)] = true
+		if p.Source != nil {
+			if err := p.Source.Validate(); err != nil {
+				return ProjectManifest{}, fmt.Errorf("project %s: %w", p.Name, err)
+			}
+		}
+
+		for _, r := range p.Repos {
+			if strings.TrimSpace(r.URL) == "" {
+				return ProjectManifest{}, fmt.Errorf("project %s contains a repository without a url", p.Name)
+			}
+			if path, err := r.GetEffectivePath(); err == nil && !filepath.IsLocal(path) {
+				return ProjectManifest{}, fmt.Errorf(
+					"project %s, repository %s: path %q is not a local path", p.Name, r.URL, path)
+			}
+			if err := p.PolicyFor(r).Validate(); err != nil {
+				return ProjectManifest{}, fmt.Errorf("project %s, repository %s: %w", p.Name, r.URL, err)
+			}
+		}
+	}
+	return manifest, nil
+}
+
+// MergeProjects merges incoming project definitions into existing ones.
+// New projects are added whole; repositories are added to existing projects unless they
+// conflict. A repository conflicts when its URL is already configured with a different path
+// or in a different project, or when its path is already used by a different URL in the
+// same project. Repositories already configured identically are counted as unchanged.
+// The policy and source of an existing project are kept; incoming values that differ from
+// them are reported as conflicts. The existing slice is not modified.
+func MergeProjects(existing, incoming []Project) ([]Project, MergeResult) {
+	merged := make([]Project, len(existing))
+	for i, p := range existing {
+		merged[i] = p
+		merged[i].Repos = append([]ProjectRepo(nil), p.Repos...)
+	}
+
+	var result MergeResult
+	for _, in := range incoming {
+		idx := -1
+		for i := range merged {
+			if strings.EqualFold(merged[i].Name, in.Name) {
+				idx = i
+				break
+			}
+		}
+
+		if idx == -1 {
+			project := in
+			project.Repos = nil
+			merged = append(merged, project)
+			idx = len(merged) - 1
+			result.AddedProjects = append(result.AddedProjects, in.Name)
+		} else {
+			result.Conflicts = append(result.Conflicts, projectPolicyConflicts(merged[idx], in)...)
+		}
+
+		for _, r := range in.Repos {
+			conflict, unchanged := checkRepoConflict(merged, idx, in.Name, r)
+			switch {
+			case conflict != nil:
+				result.Conflicts = append(result.Conflicts, *conflict)
+			case unchanged:
+				result.Unchanged++
+			default:
+				merged[idx].Repos = append(merged[idx].Repos, r)
+				result.AddedRepos++
+			}
+		}
+	}
+	return merged, result
+}
+
+// projectPolicyConflicts reports the policy and source values of the incoming project that differ
+// from those of the existing project. Values the incoming project leaves unset are not conflicts.
+func projectPolicyConflicts(existing, in Project) []ImportConflict {
+	var reasons []string
+	differs := func(field string, incoming, configured any) {
+		reasons = append(reasons, fmt.Sprintf("%s %v differs from the configured %v", field, incoming, configured))
+	}
+
+	if in.Branch != "" && in.Branch != existing.Branch {
+		differs("branch", strconv.Quote(in.Branch), quoteOrUnset(existing.Branch))
+	}
+	if in.AutoSwitch && !existing.AutoSwitch {
+		differs("auto_switch", true, false)
+	}
+	if in.PullStrategy != "" && in.PullStrategy != existing.PullStrategy {
+		differs("pull_strategy", strconv.Quote(in.PullStrategy), quoteOrUnset(existing.PullStrategy))
+	}
+	if in.SkipSync && !existing.SkipSync {
+		differs("skip_sync", true, false)
+	}
+	if len(in.Tags) > 0 && !slices.Equal(sortedTags(in.Tags), sortedTags(existing.Tags)) {
+		differs("tags", in.Tags, existing.Tags)
+	}
+	if in.Source != nil && (existing.Source == nil || !reflect.DeepEqual(*in.Source, *existing.Source)) {
+		reasons = append(reasons, "source differs from the configured source")
+	}
+
+	conflicts := make([]ImportConflict, len(reasons))
+	for i, reason := range reasons {
+		conflicts[i] = ImportConflict{Project: in.Name, Reason: reason}
+	}
+	return conflicts
+}
+
+// quoteOrUnset quotes a configured value, or returns "(unset)" when it is empty.
+func quoteOrUnset(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return strconv.Quote(value)
+}
+
+// sortedTags returns a sorted, lowercased copy of tags.
+func sortedTags(tags []string) []string {
+	sorted := make([]string, len(tags))
+	for i, t := range tags {
+		sorted[i] = strings.ToLower(t)
+	}
+	slices.Sort(sorted)
+	return sorted
+}
+
+// checkRepoConflict compares r against the merged configuration. projectIdx is the index of
+// the project r is being added to. It returns a conflict, or whether r is already configured.
+func checkRepoConflict(
+	merged []Project,
+	projectIdx int,
+	projectName string,
+	r ProjectRepo,
+) (*ImportConflict, bool) {
+	path, err := r.GetEffectivePath()
+	if err != nil {
+		return &ImportConflict{Project: projectName, URL: r.URL, Reason: err.Error()}, false
+	}
+
+	for i, p := range merged {
+		for j := range p.Repos {
+			existing := p.Repos[j]
+			existingPath, _ := existing.GetEffectivePath()
+
+			if sameRepoURL(existing.URL, r.URL) {
+				if i != projectIdx {
+					return &ImportConflict{
+						Project: projectName,
+						URL:     r.URL,
+						Path:    path,
+						Reason:  fmt.Sprintf("url already configured in project %s", p.Name),
+					}, false
+				}
+				if existingPath != path {
+					return &ImportConflict{
+						Project: projectName,
+						URL:     r.URL,
+						Path:    path,
+						Reason:  fmt.Sprintf("url already configured at path %s", existingPath),
+					}, false
+				}
+				return nil, true
+			}
+
+			if i == projectIdx && existingPath == path {
+				return &ImportConflict{
+					Project: projectName,
+					URL:     r.URL,
+					Path:    path,
+					Reason:  fmt.Sprintf("path already used by %s", existing.URL),
+				}, false
+			}
+		}
+	}
+	return nil, false
+}
+
+// sameRepoURL reports whether two repository URLs refer to the same remote,
+// ignoring a trailing slash or .git suffix.
+func sameRepoURL(a, b string) bool {
+	normalize := func(u string) string {
+		u = strings.TrimSuffix(strings.TrimSpace(u), "/")
+		return strings.ToLower(strings.TrimSuffix(u, ".git"))
+	}
+	return normalize(a) == normalize(b)
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportProjects(t *testing.T) {
+	projects := []Project{
+		{Name: "Alpha", Repos: []ProjectRepo{{URL: "git@github.com:org/a.git"}}},
+		{Name: "Beta", Repos: []ProjectRepo{{URL: "git@github.com:org/b.git"}}},
+	}
+
+	all, err := ExportProjects(projects, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ManifestVersion, all.Version)
+	assert.Len(t, all.Projects, 2)
+
+	one, err := ExportProjects(projects, []string{"beta"})
+	require.NoError(t, err)
+	require.Len(t, one.Projects, 1)
+	assert.Equal(t, "Beta", one.Projects[0].Name)
+
+	_, err = ExportProjects(projects, []string{"Gamma"})
+	assert.Error(t, err)
+}
+
+func TestParseManifest(t *testing.T) {
+	yamlManifest := `
+version: 1
+projects:
+  - name: Platform
+    branch: develop
+    repos:
+      - url: git@github.com:org/api.git
+      - url: git@github.com:org/web.git
+        path: frontend
+        pull_strategy: ff-only
+`
+	manifest, err := ParseManifest([]byte(yamlManifest))
+	require.NoError(t, err)
+	require.Len(t, manifest.Projects, 1)
+	assert.Equal(t, "develop", manifest.Projects[0].Branch)
+	assert.Equal(t, "frontend", manifest.Projects[0].Repos[1].Path)
+	assert.Equal(t, PullStrategyFFOnly, manifest.Projects[0].Repos[1].PullStrategy)
+
+	jsonManifest := `{"version": 1, "projects": [{"name": "Tools", "repos": [{"url": "https://github.com/org/cli.git"}]}]}`
+	manifest, err = ParseManifest([]byte(jsonManifest))
+	require.NoError(t, err)
+	assert.Equal(t, "Tools", manifest.Projects[0].Name)
+
+	invalid := map[string]string{
+		"future version":   "version: 2\nprojects: [{name: A}]",
+		"no projects":      "version: 1\nprojects: []",
+		"missing name":     "projects: [{repos: [{url: git@github.com:org/a.git}]}]",
+		"duplicate name":   "projects: [{name: A}, {name: a}]",
+		"missing url":      "projects: [{name: A, repos: [{path: x}]}]",
+		"invalid strategy": "projects: [{name: A, pull_strategy: squash, repos: [{url: git@github.com:org/a.git}]}]",
+		"parent name":      "projects: [{name: '..', repos: [{url: git@github.com:org/a.git}]}]",
+		"absolute name":    "projects: [{name: /etc, repos: [{url: git@github.com:org/a.git}]}]",
+		"escaping path":    "projects: [{name: A, repos: [{url: git@github.com:org/a.git, path: ../../a}]}]",
+		"absolute path":    "projects: [{name: A, repos: [{url: git@github.com:org/a.git, path: /tmp/a}]}]",
+	}
+	for name, data := range invalid {
+		_, err := ParseManifest([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("projects: [{name: A}]"), 0o644))
+
+	manifest, err := ReadManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, "A", manifest.Projects[0].Name)
+
+	manifest, err = ReadManifest("file://" + path)
+	require.NoError(t, err)
+	assert.Equal(t, "A", manifest.Projects[0].Name)
+
+	_, err = ReadManifest("https://example.com/team.yaml")
+	assert.ErrorContains(t, err, "unsupported manifest location")
+}
+
+func TestMergeProjects(t *testing.T) {
+	existing := []Project{
+		{
+			Name: "Platform",
+			Repos: []ProjectRepo{
+				{URL: "git@github.com:org/api.git"},
+				{URL: "git@github.com:org/web.git", Path: "frontend"},
+			},
+		},
+		{Name: "Tools", Repos: []ProjectRepo{{URL: "git@github.com:org/cli.git"}}},
+	}
+	incoming := []Project{
+		{
+			Name: "platform",
+			Repos: []ProjectRepo{
+				{URL: "git@github.com:org/api"},                       // unchanged (same remote)
+				{URL: "git@github.com:org/web.git"},                   // url at different path
+				{URL: "git@github.com:other/frontend.git"},            // path already used
+				{URL: "git@github.com:org/cli.git", Path: "cli-tool"}, // url in another project
+				{URL: "git@github.com:org/worker.git"},                // added
+			},
+		},
+		{
+			Name: "Data",
+			Repos: []ProjectRepo{
+				{URL: "git@github.com:org/etl.git"},
+				{URL: "git@github.com:fork/etl.git"}, // path collides with the previous repo
+			},
+		},
+	}
+
+	merged, result := MergeProjects(existing, incoming)
+
+	assert.Equal(t, []string{"Data"}, result.AddedProjects)
+	assert.Equal(t, 2, result.AddedRepos)
+	assert.Equal(t, 1, result.Unchanged)
+	require.Len(t, result.Conflicts, 4)
+	assert.Contains(t, result.Conflicts[0].Reason, "already configured at path frontend")
+	assert.Contains(t, result.Conflicts[1].Reason, "path already used by git@github.com:org/web.git")
+	assert.Contains(t, result.Conflicts[2].Reason, "already configured in project Tools")
+	assert.Contains(t, result.Conflicts[3].Reason, "path already used by git@github.com:org/etl.git")
+
+	require.Len(t, merged, 3)
+	assert.Len(t, merged[0].Repos, 3)
+	assert.Equal(t, "git@github.com:org/worker.git", merged[0].Repos[2].URL)
+	assert.Equal(t, "Data", merged[2].Name)
+	assert.Len(t, merged[2].Repos, 1)
+
+	// The input configuration is left untouched.
+	assert.Len(t, existing[0].Repos, 2)
+}
+
+func TestMergeProjectsPolicyConflicts(t *testing.T) {
+	existing := []Project{
+		{
+			Name:         "Platform",
+			Branch:       "main",
+			PullStrategy: PullStrategyRebase,
+			Tags:         []string{"Work", "go"},
+			Source:       &ProjectSource{Provider: "github", Group: "org"},
+		},
+	}
+
+	// Unset values and tags differing only in case or order are not conflicts.
+	_, result := MergeProjects(existing, []Project{{Name: "platform", Tags: []string{"GO", "work"}}})
+	assert.Empty(t, result.Conflicts)
+
+	incoming := []Project{
+		{
+			Name:         "platform",
+			Branch:       "develop",
+			AutoSwitch:   true,
+			PullStrategy: PullStrategyFFOnly,
+			SkipSync:     true,
+			Tags:         []string{"ops"},
+			Source:       &ProjectSource{Provider: "gitlab", Group: "org"},
+		},
+	}
+
+	merged, result := MergeProjects(existing, incoming)
+
+	require.Len(t, result.Conflicts, 6)
+	for _, c := range result.Conflicts {
+		assert.Equal(t, "platform", c.Project)
+		assert.Empty(t, c.URL)
+	}
+	assert.Equal(t, `branch "develop" differs from the configured "main"`, result.Conflicts[0].Reason)
+	assert.Contains(t, result.Conflicts[1].Reason, "auto_switch")
+	assert.Contains(t, result.Conflicts[2].Reason, "pull_strategy")
+	assert.Contains(t, result.Conflicts[3].Reason, "skip_sync")
+	assert.Contains(t, result.Conflicts[4].Reason, "tags")
+	assert.Contains(t, result.Conflicts[5].Reason, "source")
+
+	// The existing project's policy is kept.
+	assert.Equal(t, "main", merged[0].Branch)
+	assert.Equal(t, PullStrategyRebase, merged[0].PullStrategy)
+	assert.Equal(t, "github", merged[0].Source.Provider)
+}
@@ -13,15 +13,156 @@ import (
 )
 
 // Project represents a collection of related repositories.
+// The policy fields apply to every repository in the project unless a repository overrides them.
 type Project struct {
-	Name  string        `mapstructure:"name"`
-	Repos []ProjectRepo `mapstructure:"repos"`
+	Name  string        `mapstructure:"name"  yaml:"name"  json:"name"`
+	Repos []ProjectRepo `mapstructure:"repos" yaml:"repos" json:"repos"`
+
+	// Branch is the branch repositories are kept on during sync (e.g. develop).
+	Branch string `mapstructure:"branch,omitempty" yaml:"branch,omitempty" json:"branch,omitempty"`
+	// AutoSwitch checks out the default branch before pulling when Branch is not set.
+	AutoSwitch bool `mapstructure:"auto_switch,omitempty" yaml:"auto_switch,omitempty" json:"auto_switch,omitempty"`
+	// PullStrategy is one of rebase (the default), ff-only, or merge.
+	PullStrategy string `mapstructure:"pull_strategy,omitempty" yaml:"pull_strategy,omitempty" json:"pull_strategy,omitempty"`
+	// SkipSync leaves repositories out of fetch, pull, and sync.
+	SkipSync bool     `mapstructure:"skip_sync,omitempty" yaml:"skip_sync,omitempty" json:"skip_sync,omitempty"`
+	Tags     []string `mapstructure:"tags,omitempty"      yaml:"tags,omitempty"      json:"tags,omitempty"`
+
+	// Source mirrors a GitHub organization or GitLab group into the project during setup.
+	Source *ProjectSource `mapstructure:"source,omitempty" yaml:"source,omitempty" json:"source,omitempty"`
+}
+
+// Remote providers accepted by ProjectSource.
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// ProjectSource references a GitHub organization (or user) or GitLab group whose repositories
+// belong to the project. Repositories found there are added to the project by `eng project setup`.
+type ProjectSource struct {
+	Provider string `mapstructure:"provider"           yaml:"provider"           json:"provider"`
+	Group    string `mapstructure:"group"              yaml:"group"              json:"group"`
+	Host     string `mapstructure:"host,omitempty"     yaml:"host,omitempty"     json:"host,omitempty"`
+	APIURL   string `mapstructure:"api_url,omitempty"  yaml:"api_url,omitempty"  json:"api_url,omitempty"`
+	Protocol string `mapstructure:"protocol,omitempty" yaml:"protocol,omitempty" json:"protocol,omitempty"` // ssh (default) or https
+
+	IncludeArchived  bool `mapstructure:"include_archived,omitempty"  yaml:"include_archived,omitempty"  json:"include_archived,omitempty"`
+	IncludeForks     bool `mapstructure:"include_forks,omitempty"     yaml:"include_forks,omitempty"     json:"include_forks,omitempty"`
+	IncludeSubgroups bool `mapstructure:"include_subgroups,omitempty" yaml:"include_subgroups,omitempty" json:"include_subgroups,omitempty"`
+	// Exclude lists repository name globs to ignore.
+	Exclude []string `mapstructure:"exclude,omitempty" yaml:"exclude,omitempty" json:"exclude,omitempty"`
+}
+
+// Validate checks that the source names a supported provider and a group.
+func (s *ProjectSource) Validate() error {
+	switch s.Provider {
+	case ProviderGitHub, ProviderGitLab:
+	default:
+		return fmt.Errorf("unknown source provider %q (expected github or gitlab)", s.Provider)
+	}
+	if strings.TrimSpace(s.Group) == "" {
+		return fmt.Errorf("source for %s is missing a group", s.Provider)
+	}
+	switch s.Protocol {
+	case "", "ssh", "https":
+	default:
+		return fmt.Errorf("unknown source protocol %q (expected ssh or https)", s.Protocol)
+	}
+	return nil
 }
 
 // ProjectRepo represents a single repository within a project.
+// Policy fields left unset inherit the project's value; Tags are added to the project's tags.
 type ProjectRepo struct {
-	URL  string `mapstructure:"url"`
-	Path string `mapstructure:"path,omitempty"` // Optional, defaults to repo name from URL
+	URL  string `mapstructure:"url"            yaml:"url"            json:"url"`
+	Path string `mapstructure:"path,omitempty" yaml:"path,omitempty" json:"path,omitempty"` // Optional, defaults to repo name from URL
+
+	Branch       string   `mapstructure:"branch,omitempty"        yaml:"branch,omitempty"        json:"branch,omitempty"`
+	AutoSwitch   *bool    `mapstructure:"auto_switch,omitempty"   yaml:"auto_switch,omitempty"   json:"auto_switch,omitempty"`
+	PullStrategy string   `mapstructure:"pull_strategy,omitempty" yaml:"pull_strategy,omitempty" json:"pull_strategy,omitempty"`
+	SkipSync     *bool    `mapstructure:"skip_sync,omitempty"     yaml:"skip_sync,omitempty"     json:"skip_sync,omitempty"`
+	Tags         []string `mapstructure:"tags,omitempty"          yaml:"tags,omitempty"          json:"tags,omitempty"`
+}
+
+// Pull strategies accepted by the pull_strategy policy and repo.PullWithStrategy.
+const (
+	PullStrategyRebase = "rebase"
+	PullStrategyFFOnly = "ff-only"
+	PullStrategyMerge  = "merge"
+)
+
+// RepoPolicy is the effective sync policy for a repository after applying
+// repository-level overrides on top of the project's policy.
+type RepoPolicy struct {
+	Branch       string
+	AutoSwitch   bool
+	PullStrategy string
+	SkipSync     bool
+	Tags         []string
+}
+
+// PolicyFor resolves the effective policy for r. Repository values override project values,
+// and tags are the union of both. A repository's auto_switch: true without a branch of its own
+// clears the project's branch.
+func (p Project) PolicyFor(r ProjectRepo) RepoPolicy {
+	policy := RepoPolicy{
+		Branch:       p.Branch,
+		AutoSwitch:   p.AutoSwitch,
+		PullStrategy: p.PullStrategy,
+		SkipSync:     p.SkipSync,
+	}
+	if r.Branch != "" {
+		policy.Branch = r.Branch
+	}
+	if r.AutoSwitch != nil {
+		policy.AutoSwitch = *r.AutoSwitch
+		// A repository that asks for its default branch no longer follows the project's branch.
+		if policy.AutoSwitch && r.Branch == "" {
+			policy.Branch = ""
+		}
+	}
+	if r.PullStrategy != "" {
+		policy.PullStrategy = r.PullStrategy
+	}
+	if r.SkipSync != nil {
+		policy.SkipSync = *r.SkipSync
+	}
+
+	seen := make(map[string]bool)
+	for _, tag := range append(append([]string{}, p.Tags...), r.Tags...) {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			policy.Tags = append(policy.Tags, tag)
+		}
+	}
+	return policy
+}
+
+// MatchesTags reports whether the policy carries at least one of tags.
+// An empty tag list matches every repository.
+func (rp RepoPolicy) MatchesTags(tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, want := range tags {
+		for _, have := range rp.Tags {
+			if strings.EqualFold(want, have) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate checks that the policy values are recognized.
+func (rp RepoPolicy) Validate() error {
+	switch rp.PullStrategy {
+	case "", PullStrategyRebase, PullStrategyFFOnly, PullStrategyMerge:
+		return nil
+	default:
+		return fmt.Errorf("unknown pull_strategy %q (expected rebase, ff-only, or merge)", rp.PullStrategy)
+	}
 }
 
 // GetProjects retrieves the list of configured projects from the config file.
@@ -195,6 +336,36 @@ func (r *ProjectRepo) GetEffectivePath() (string, error) {
 	return RepoNameFromURL(r.URL)
 }
 
+// FindProjectForPath returns the name of the project whose repository lives at repoPath.
+// Paths are resolved relative to devPath, the configured git.dev_path, using the
+// <devPath>/<project>/<repo> layout. Returns an empty string when devPath is empty or
+// the path does not belong to any project.
+func FindProjectForPath(projects []Project, devPath, repoPath string) string {
+	if devPath == "" {
+		return ""
+	}
+	root, err := filepath.Abs(devPath)
+	if err != nil {
+		return ""
+	}
+	target, err := filepath.Abs(repoPath)
+	if err != nil {
+		return ""
+	}
+	for _, p := range projects {
+		for i := range p.Repos {
+			effectivePath, err := p.Repos[i].GetEffectivePath()
+			if err != nil {
+				continue
+			}
+			if filepath.Join(root, p.Name, effectivePath) == target {
+				return p.Name
+			}
+		}
+	}
+	return ""
+}
+
 // GetProjectNames returns a list of all configured project names.
 func GetProjectNames() []string {
 	projects := GetProjects()
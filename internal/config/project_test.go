@@ -393,3 +393,136 @@ func TestGetProjectNames(t *testing.T) {
 	// Names should be in the order they were saved
 	assert.Equal(t, []string{"Zebra", "Alpha", "Beta"}, names)
 }
+
+func TestFindProjectForPath(t *testing.T) {
+	devPath := filepath.Join("dev")
+	projects := []Project{
+		{Name: "Alpha", Repos: []ProjectRepo{{URL: "git@github.com:org/api.git"}}},
+		{Name: "Beta", Repos: []ProjectRepo{{URL: "git@github.com:org/web.git", Path: "frontend"}}},
+	}
+
+	assert.Equal(t, "Alpha", FindProjectForPath(projects, devPath, filepath.Join(devPath, "Alpha", "api")))
+	assert.Equal(t, "Beta", FindProjectForPath(projects, devPath, filepath.Join(devPath, "Beta", "frontend")))
+	assert.Empty(t, FindProjectForPath(projects, devPath, filepath.Join(devPath, "Beta", "web")))
+	assert.Empty(t, FindProjectForPath(projects, devPath, filepath.Join(devPath, "api")))
+}
+
+func TestProject_PolicyFor(t *testing.T) {
+	enabled, disabled := true, false
+	project := Project{
+		Name:         "Platform",
+		Branch:       "develop",
+		PullStrategy: PullStrategyFFOnly,
+		SkipSync:     true,
+		Tags:         []string{"backend"},
+	}
+
+	inherited := project.PolicyFor(ProjectRepo{URL: "git@github.com:org/api.git"})
+	assert.Equal(t, RepoPolicy{
+		Branch:       "develop",
+		PullStrategy: PullStrategyFFOnly,
+		SkipSync:     true,
+		Tags:         []string{"backend"},
+	}, inherited)
+
+	overridden := project.PolicyFor(ProjectRepo{
+		URL:          "git@github.com:org/web.git",
+		Branch:       "main",
+		AutoSwitch:   &enabled,
+		PullStrategy: PullStrategyMerge,
+		SkipSync:     &disabled,
+		Tags:         []string{"frontend", "backend"},
+	})
+	assert.Equal(t, RepoPolicy{
+		Branch:       "main",
+		AutoSwitch:   true,
+		PullStrategy: PullStrategyMerge,
+		SkipSync:     false,
+		Tags:         []string{"backend", "frontend"},
+	}, overridden)
+
+	// auto_switch without a branch of its own follows the default branch, not the project's.
+	defaultBranch := project.PolicyFor(ProjectRepo{URL: "git@github.com:org/cli.git", AutoSwitch: &enabled})
+	assert.Empty(t, defaultBranch.Branch)
+	assert.True(t, defaultBranch.AutoSwitch)
+}
+
+func TestRepoPolicy_MatchesTags(t *testing.T) {
+	policy := RepoPolicy{Tags: []string{"backend", "go"}}
+
+	assert.True(t, policy.MatchesTags(nil))
+	assert.True(t, policy.MatchesTags([]string{"Backend"}))
+	assert.True(t, policy.MatchesTags([]string{"frontend", "go"}))
+	assert.False(t, policy.MatchesTags([]string{"frontend"}))
+	assert.False(t, RepoPolicy{}.MatchesTags([]string{"backend"}))
+}
+
+func TestRepoPolicy_Validate(t *testing.T) {
+	for _, strategy := range []string{"", PullStrategyRebase, PullStrategyFFOnly, PullStrategyMerge} {
+		assert.NoError(t, RepoPolicy{PullStrategy: strategy}.Validate(), strategy)
+	}
+
+	err := RepoPolicy{PullStrategy: "squash"}.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown pull_strategy")
+}
+
+func TestSaveProjects_PolicyRoundTrip(t *testing.T) {
+	configPath, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	skip := true
+	err := SaveProjects([]Project{
+		{
+			Name:         "Platform",
+			Branch:       "develop",
+			PullStrategy: PullStrategyFFOnly,
+			Tags:         []string{"backend"},
+			Repos: []ProjectRepo{
+				{URL: "git@github.com:org/api.git"},
+				{URL: "git@github.com:org/legacy.git", SkipSync: &skip, Tags: []string{"legacy"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "pull_strategy: ff-only")
+	assert.Contains(t, string(data), "skip_sync: true")
+	assert.NotContains(t, string(data), "auto_switch")
+
+	// Reload from disk to make sure the written keys decode back into the same fields.
+	viper.Reset()
+	viper.SetConfigFile(configPath)
+	require.NoError(t, viper.ReadInConfig())
+
+	projects := GetProjects()
+	require.Len(t, projects, 1)
+	assert.Equal(t, "develop", projects[0].Branch)
+	assert.Equal(t, PullStrategyFFOnly, projects[0].PullStrategy)
+	require.Len(t, projects[0].Repos, 2)
+	assert.Nil(t, projects[0].Repos[0].SkipSync)
+	require.NotNil(t, projects[0].Repos[1].SkipSync)
+	assert.True(t, *projects[0].Repos[1].SkipSync)
+	assert.Equal(t, []string{"backend", "legacy"}, projects[0].PolicyFor(projects[0].Repos[1]).Tags)
+}
+
+func TestProjectSource_Validate(t *testing.T) {
+	valid := []ProjectSource{
+		{Provider: ProviderGitHub, Group: "acme"},
+		{Provider: ProviderGitLab, Group: "platform/team", Protocol: "https"},
+	}
+	for _, src := range valid {
+		assert.NoError(t, src.Validate(), src.Group)
+	}
+
+	invalid := map[string]ProjectSource{
+		"unknown provider": {Provider: "bitbucket", Group: "acme"},
+		"missing group":    {Provider: ProviderGitHub},
+		"unknown protocol": {Provider: ProviderGitLab, Group: "acme", Protocol: "git"},
+	}
+	for name, src := range invalid {
+		assert.Error(t, src.Validate(), name)
+	}
+}
@@ -1,18 +1,76 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultProtectedBranches are the branch patterns protected when git.protected_branches is not set.
+var DefaultProtectedBranches = []string{"main", "master", "develop"}
 
 // GitConfig holds all git-related configuration.
 type GitConfig struct {
-	DevPath string `mapstructure:"dev_path"`
-	Editor  string `mapstructure:"editor"`
+	DevPath           string        `mapstructure:"dev_path"`
+	Editor            string        `mapstructure:"editor"`
+	StatusCache       bool          `mapstructure:"status_cache"`
+	StatusCacheTTL    time.Duration `mapstructure:"status_cache_ttl"`
+	ProtectedBranches []string      `mapstructure:"protected_branches"`
 }
 
 // GetGitConfig retrieves the git configuration from Viper.
+// The status cache is only enabled when git.status_cache is set to true, and protected branches
+// default to DefaultProtectedBranches.
 func GetGitConfig() GitConfig {
+	protected := append([]string(nil), DefaultProtectedBranches...)
+	if viper.IsSet("git.protected_branches") {
+		protected = viper.GetStringSlice("git.protected_branches")
+	}
+
 	return GitConfig{
-		DevPath: viper.GetString("git.dev_path"),
-		Editor:  viper.GetString("git.editor"),
+		DevPath:           viper.GetString("git.dev_path"),
+		Editor:            viper.GetString("git.editor"),
+		StatusCache:       viper.GetBool("git.status_cache"),
+		StatusCacheTTL:    viper.GetDuration("git.status_cache_ttl"),
+		ProtectedBranches: protected,
+	}
+}
+
+// IsProtectedBranch reports whether branch matches one of the protected branch patterns.
+// Patterns are globs such as "release/*"; matching is case-insensitive.
+func (g GitConfig) IsProtectedBranch(branch string) bool {
+	branch = strings.ToLower(branch)
+	for _, pattern := range g.ProtectedBranches {
+		if ok, _ := path.Match(strings.ToLower(strings.TrimSpace(pattern)), branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DashboardConfig holds the interactive dashboard configuration.
+type DashboardConfig struct {
+	AutoRefresh         bool                `mapstructure:"auto_refresh"`
+	ConventionalCommits bool                `mapstructure:"conventional_commits"`
+	Keymap              map[string][]string `mapstructure:"keymap"` // Action name -> keys, replacing the defaults
+	Layout              string              `mapstructure:"layout"` // horizontal, vertical, or single
+}
+
+// GetDashboardConfig retrieves the dashboard configuration from Viper.
+// Auto-refresh is enabled unless dashboard.auto_refresh is explicitly set to false.
+func GetDashboardConfig() DashboardConfig {
+	autoRefresh := true
+	if viper.IsSet("dashboard.auto_refresh") {
+		autoRefresh = viper.GetBool("dashboard.auto_refresh")
+	}
+
+	return DashboardConfig{
+		AutoRefresh:         autoRefresh,
+		ConventionalCommits: viper.GetBool("dashboard.conventional_commits"),
+		Keymap:              viper.GetStringMapStringSlice("dashboard.keymap"),
+		Layout:              viper.GetString("dashboard.layout"),
 	}
 }
 
@@ -38,13 +96,43 @@ func GetDotfilesConfig() DotfilesConfig {
 
 // ContainersConfig holds container-related configuration.
 type ContainersConfig struct {
-	Path string `mapstructure:"path"`
+	Path            string `mapstructure:"path"`
+	BackupPath      string `mapstructure:"backup_path"`
+	BackupRetention int    `mapstructure:"backup_retention"`
 }
 
+// DefaultBackupRetention is the number of backups kept per stack unless containers.backup_retention is set.
+const DefaultBackupRetention = 7
+
 // GetContainersConfig retrieves the containers configuration from Viper.
 func GetContainersConfig() ContainersConfig {
+	retention := DefaultBackupRetention
+	if viper.IsSet("containers.backup_retention") {
+		retention = viper.GetInt("containers.backup_retention")
+	}
+
 	return ContainersConfig{
-		Path: viper.GetString("containers.path"),
+		Path:            viper.GetString("containers.path"),
+		BackupPath:      viper.GetString("containers.backup_path"),
+		BackupRetention: retention,
+	}
+}
+
+// GitLabConfig holds the GitLab defaults set by `eng gitlab auth set`.
+type GitLabConfig struct {
+	Host      string `mapstructure:"host"`
+	Project   string `mapstructure:"project"`
+	Token     string `mapstructure:"token"`
+	TokenItem string `mapstructure:"tokenItem"`
+}
+
+// GetGitLabConfig retrieves the GitLab configuration from Viper.
+func GetGitLabConfig() GitLabConfig {
+	return GitLabConfig{
+		Host:      viper.GetString("gitlab.host"),
+		Project:   viper.GetString("gitlab.project"),
+		Token:     viper.GetString("gitlab.token"),
+		TokenItem: viper.GetString("gitlab.tokenItem"),
 	}
 }
 
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGitConfig_ProtectedBranches(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	cfg := GetGitConfig()
+	assert.Equal(t, DefaultProtectedBranches, cfg.ProtectedBranches)
+	assert.True(t, cfg.IsProtectedBranch("main"))
+	assert.True(t, cfg.IsProtectedBranch("Develop"))
+	assert.False(t, cfg.IsProtectedBranch("feature/login"))
+
+	viper.Set("git.protected_branches", []string{"main", "release/*"})
+	cfg = GetGitConfig()
+	assert.True(t, cfg.IsProtectedBranch("release/1.2"))
+	assert.False(t, cfg.IsProtectedBranch("develop"))
+	assert.False(t, cfg.IsProtectedBranch("release/1.2/hotfix"))
+
+	viper.Set("git.protected_branches", []string{})
+	assert.False(t, GetGitConfig().IsProtectedBranch("main"), "an explicit empty list protects nothing")
+}
@@ -0,0 +1,666 @@
+package containers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// backupImage is the image of the helper containers that archive and restore volume data.
+const backupImage = "alpine:3"
+
+// backupTimeFormat names backup directories, so that they sort chronologically.
+const backupTimeFormat = "20060102-150405"
+
+const (
+	backupManifestFile = "manifest.json"
+	backupChecksumFile = "SHA256SUMS"
+)
+
+// Kinds of StackVolume.
+const (
+	VolumeNamed = "volume"
+	VolumeBind  = "bind"
+)
+
+// StackVolume is a named volume or bind mount that the services of a stack store data in.
+type StackVolume struct {
+	Name     string   `json:"name"`           // Volume key in the compose file, or the bind mount's host path
+	Kind     string   `json:"kind"`           // VolumeNamed or VolumeBind
+	Source   string   `json:"source"`         // Docker volume name, or absolute host path
+	File     bool     `json:"file,omitempty"` // The bind mount is a single file
+	Services []string `json:"services"`
+	Archive  string   `json:"archive,omitempty"` // Archive file name within the backup directory
+	SHA256   string   `json:"sha256,omitempty"`
+	Size     int64    `json:"size,omitempty"`
+}
+
+// BackupManifest describes a backup. It is stored as manifest.json next to the archives.
+type BackupManifest struct {
+	Stack     string        `json:"stack"`
+	Project   string        `json:"project"` // Compose project name, which prefixes the stack's volume names
+	CreatedAt time.Time     `json:"created_at"`
+	Volumes   []StackVolume `json:"volumes"`
+}
+
+// Backup is a backup directory of a stack.
+type Backup struct {
+	Path     string
+	Manifest BackupManifest
+}
+
+// BackupOptions configures Backup.
+type BackupOptions struct {
+	Dir   string // Root directory of backups; defaults to <base path>/backups
+	Keep  int    // Number of backups of the stack to keep, newest first; 0 keeps all
+	Pause bool   // Pause the stack's containers instead of stopping them
+	Live  bool   // Neither stop nor pause the stack
+}
+
+// BackupResult is the outcome of Backup.
+type BackupResult struct {
+	Path     string
+	Manifest BackupManifest
+	Skipped  []string // Volumes that were not backed up, with the reason
+	Removed  []string // Old backups removed by the retention policy
+}
+
+// composeVolumesFile is the part of a compose file that declares volumes.
+type composeVolumesFile struct {
+	Name     string `yaml:"name"`
+	Services map[string]struct {
+		Volumes []any `yaml:"volumes"`
+	} `yaml:"services"`
+	Volumes map[string]*struct {
+		Name     string `yaml:"name"`
+		External any    `yaml:"external"`
+	} `yaml:"volumes"`
+}
+
+// StackVolumes returns the named volumes and bind mounts of a stack's services. Mounts that cannot
+// be backed up, such as sockets or paths using variables, are returned as skipped, with the reason.
+func (m *Manager) StackVolumes(stackName string) ([]StackVolume, []string, error) {
+	s, err := m.findStack(stackName)
+	if err != nil {
+		return nil, nil, err
+	}
+	volumes, skipped, _, err := stackVolumes(s, false)
+	return volumes, skipped, err
+}
+
+// stackVolumes parses the volumes of s, and returns them with the compose project name. With
+// includeMissing, bind mounts whose host path does not exist are returned too, so they can be restored.
+func stackVolumes(s Stack, includeMissing bool) ([]StackVolume, []string, string, error) {
+	data, err := os.ReadFile(s.File)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	var file composeVolumesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to parse compose file of stack %s: %w", s.Name, err)
+	}
+
+	project := file.Name
+	if project == "" {
+		project = filepath.Base(s.Path)
+	}
+	project = strings.ToLower(project)
+
+	serviceNames := make([]string, 0, len(file.Services))
+	for name := range file.Services {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+
+	var volumes []StackVolume
+	var skipped []string
+	bySource := map[string]int{}
+	for _, service := range serviceNames {
+		for _, entry := range file.Services[service].Volumes {
+			kind, source := parseVolumeEntry(entry)
+			if source == "" {
+				continue // Anonymous volumes and tmpfs mounts hold no data worth keeping
+			}
+
+			v := StackVolume{Name: source, Kind: kind}
+			switch {
+			case strings.Contains(source, "$"):
+				skipped = append(skipped, fmt.Sprintf("%s (%s): uses variable interpolation", source, service))
+				continue
+			case kind == VolumeNamed:
+				decl, ok := file.Volumes[source]
+				if !ok {
+					skipped = append(skipped, fmt.Sprintf("%s (%s): not declared under volumes", source, service))
+					continue
+				}
+				v.Source = project + "_" + source
+				if decl != nil && isExternal(decl.External) {
+					v.Source = source
+				}
+				if decl != nil && decl.Name != "" {
+					v.Source = decl.Name
+				}
+			default:
+				v.Source = resolveBindSource(s.Path, source)
+				v.Name = v.Source
+				info, err := os.Stat(v.Source)
+				switch {
+				case err != nil && !includeMissing:
+					skipped = append(skipped, fmt.Sprintf("%s (%s): does not exist", v.Source, service))
+					continue
+				case err != nil:
+					// Kept for Restore, which creates the missing path.
+				case !info.IsDir() && !info.Mode().IsRegular():
+					skipped = append(skipped, fmt.Sprintf("%s (%s): not a file or directory", v.Source, service))
+					continue
+				default:
+					v.File = !info.IsDir()
+				}
+			}
+
+			if i, ok := bySource[v.Source]; ok {
+				volumes[i].Services = append(volumes[i].Services, service)
+				continue
+			}
+			v.Services = []string{service}
+			bySource[v.Source] = len(volumes)
+			volumes = append(volumes, v)
+		}
+	}
+
+	seen := map[string]bool{}
+	for i := range volumes {
+		volumes[i].Archive = archiveName(volumes[i], seen)
+	}
+	return volumes, skipped, project, nil
+}
+
+// parseVolumeEntry returns the kind and source of a service's volumes entry, in short ("src:dst:ro")
+// or long syntax. The source is empty for anonymous volumes and tmpfs mounts.
+func parseVolumeEntry(entry any) (string, string) {
+	var kind, source string
+	switch e := entry.(type) {
+	case string:
+		parts := strings.Split(e, ":")
+		if len(parts) < 2 {
+			return "", ""
+		}
+		source = parts[0]
+	case map[string]any:
+		kind, _ = e["type"].(string)
+		source, _ = e["source"].(string)
+		if kind != "" && kind != VolumeNamed && kind != VolumeBind {
+			return "", ""
+		}
+	}
+	if source == "" {
+		return "", ""
+	}
+	if kind == "" {
+		kind = VolumeNamed
+		if strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~") {
+			kind = VolumeBind
+		}
+	}
+	return kind, source
+}
+
+// resolveBindSource returns the absolute host path of a bind mount source.
+func resolveBindSource(stackPath, source string) string {
+	if strings.HasPrefix(source, "~") {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, source[1:])
+	}
+	if !filepath.IsAbs(source) {
+		return filepath.Join(stackPath, source)
+	}
+	return filepath.Clean(source)
+}
+
+// isExternal reports whether the external setting of a volume, true or a map, is set.
+func isExternal(external any) bool {
+	switch e := external.(type) {
+	case bool:
+		return e
+	case map[string]any:
+		return true
+	}
+	return false
+}
+
+// unsafeArchiveChars are replaced in archive file names.
+var unsafeArchiveChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// validArchiveName matches the archive file names archiveName creates.
+var validArchiveName = regexp.MustCompile(`^(volume|bind)-[A-Za-z0-9._-]+\.tar\.gz$`)
+
+// archiveName returns a file name for the archive of v that is not in seen yet.
+func archiveName(v StackVolume, seen map[string]bool) string {
+	base := v.Kind + "-" + strings.Trim(unsafeArchiveChars.ReplaceAllString(v.Name, "_"), "_.")
+	name := base + ".tar.gz"
+	for i := 2; seen[name]; i++ {
+		name = fmt.Sprintf("%s-%d.tar.gz", base, i)
+	}
+	seen[name] = true
+	return name
+}
+
+// Backup archives every volume and bind mount of a stack to a timestamped directory under the
+// backup root, with a manifest.json and SHA256SUMS of the archives. The stack is stopped, or paused,
+// while its data is archived, and resumed afterwards if it was running. Older backups beyond
+// opts.Keep are removed. If resuming the stack or removing old backups fails, the new backup is kept
+// and its result is returned along with the error.
+func (m *Manager) Backup(stackName string, opts BackupOptions) (result *BackupResult, err error) {
+	s, err := m.findStack(stackName)
+	if err != nil {
+		return nil, err
+	}
+	volumes, skipped, project, err := stackVolumes(s, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Named volumes are created when the stack first starts, so some may not exist yet.
+	var existing []StackVolume
+	for _, v := range volumes {
+		if v.Kind == VolumeNamed && execCommand("docker", "volume", "inspect", v.Source).Run() != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: docker volume %s does not exist", v.Name, v.Source))
+			continue
+		}
+		existing = append(existing, v)
+	}
+	if len(existing) == 0 {
+		return nil, fmt.Errorf("stack %s has no volumes or bind mounts to back up", s.Name)
+	}
+
+	stackDir := filepath.Join(m.backupRoot(opts.Dir), s.Name)
+	created := time.Now()
+	if err := os.MkdirAll(stackDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	// Backups made within the same second get a numbered suffix, which still sorts after the first.
+	dir := filepath.Join(stackDir, created.Format(backupTimeFormat))
+	for i := 2; ; i++ {
+		err = os.Mkdir(dir, 0o755)
+		if !errors.Is(err, os.ErrExist) {
+			break
+		}
+		dir = filepath.Join(stackDir, fmt.Sprintf("%s-%d", created.Format(backupTimeFormat), i))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	// Only an incomplete backup is removed; once its manifest and checksums are written, errors
+	// resuming the stack or removing old backups are returned with the result instead.
+	completed := false
+	defer func() {
+		if !completed {
+			_ = os.RemoveAll(dir)
+		}
+	}()
+
+	resume := func() error { return nil }
+	if !opts.Live {
+		if resume, err = m.quiesce(s, opts.Pause); err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		if resumeErr := resume(); resumeErr != nil && err == nil {
+			err = resumeErr
+		}
+	}()
+
+	manifest := BackupManifest{Stack: s.Name, Project: project, CreatedAt: created}
+	var sums strings.Builder
+	for _, v := range existing {
+		source, target := v.Source, "/source"
+		if v.File {
+			target = "/source/" + filepath.Base(v.Source)
+		}
+		if err := runDocker("run", "--rm", "-v", source+":"+target+":ro", "-v", dir+":/backup",
+			backupImage, "tar", "czf", "/backup/"+v.Archive, "-C", "/source", "."); err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", v.Name, err)
+		}
+
+		v.SHA256, v.Size, err = checksumFile(filepath.Join(dir, v.Archive))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&sums, "%s  %s\n", v.SHA256, v.Archive)
+		manifest.Volumes = append(manifest.Volumes, v)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, backupManifestFile), data, 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, backupChecksumFile), []byte(sums.String()), 0o644); err != nil {
+		return nil, err
+	}
+	completed = true
+
+	result = &BackupResult{Path: dir, Manifest: manifest, Skipped: skipped}
+	result.Removed, err = m.pruneBackups(s.Name, opts.Dir, opts.Keep)
+	return result, err
+}
+
+// ListBackups returns the backups of a stack under the backup root, newest first.
+func (m *Manager) ListBackups(stackName, root string) ([]Backup, error) {
+	stackDir := filepath.Join(m.backupRoot(root), stackName)
+	entries, err := os.ReadDir(stackDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		path := filepath.Join(stackDir, e.Name())
+		manifest, err := readBackupManifest(path)
+		if err != nil {
+			continue // Not a backup, or an incomplete one
+		}
+		backups = append(backups, Backup{Path: path, Manifest: manifest})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return filepath.Base(backups[i].Path) > filepath.Base(backups[j].Path)
+	})
+	return backups, nil
+}
+
+// restoreDirScript replaces the contents of the directory mounted at /target with the archive named
+// by its first argument. The archive is extracted to the helper container first, so a broken
+// archive leaves the current data in place.
+const restoreDirScript = `set -e
+mkdir -p /restore
+tar xzf "/backup/$1" -C /restore
+find /target -mindepth 1 -delete
+cp -a /restore/. /target/`
+
+// restoreFileScript replaces the file mounted at /target/$2 with the same file from the archive named
+// by its first argument, writing through the mount so that the host file keeps its inode.
+const restoreFileScript = `set -e
+mkdir -p /restore
+tar xzf "/backup/$1" -C /restore
+cat "/restore/$2" > "/target/$2"`
+
+// Restore replaces the data of a stack's volumes and bind mounts with a backup: the latest when
+// backup is empty, or a backup directory's name or path. Every checksum is verified before any data
+// is touched. Only volumes and bind mounts the stack's current compose file declares are restored,
+// to the paths it declares, whatever the backup's manifest says. The stack is stopped while its data
+// is restored, and started again if it was running.
+func (m *Manager) Restore(stackName, backup, root string) (manifest *BackupManifest, err error) {
+	s, err := m.findStack(stackName)
+	if err != nil {
+		return nil, err
+	}
+	b, err := m.FindBackup(s.Name, backup, root)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := restoreTargets(s, b.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyBackup(b); err != nil {
+		return nil, err
+	}
+	_, _, project, err := stackVolumes(s, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resume, err := m.quiesce(s, false)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if resumeErr := resume(); resumeErr != nil && err == nil {
+			err = resumeErr
+		}
+	}()
+
+	for _, v := range targets {
+		mount, script, args := v.Source+":/target", restoreDirScript, []string{v.Archive}
+		switch {
+		case v.Kind == VolumeNamed:
+			if err := runDocker("volume", "create",
+				"--label", "com.docker.compose.project="+project,
+				"--label", "com.docker.compose.volume="+v.Name,
+				v.Source); err != nil {
+				return nil, fmt.Errorf("failed to create volume %s: %w", v.Source, err)
+			}
+		case v.File:
+			// Mount only the file, so the archive cannot touch its siblings. Docker would create a
+			// directory for a missing mount source, so the file is created first.
+			if err := os.MkdirAll(filepath.Dir(v.Source), 0o755); err != nil {
+				return nil, err
+			}
+			f, err := os.OpenFile(v.Source, os.O_CREATE|os.O_WRONLY, 0o644)
+			if err != nil {
+				return nil, err
+			}
+			_ = f.Close()
+			base := filepath.Base(v.Source)
+			mount, script, args = v.Source+":/target/"+base, restoreFileScript, []string{v.Archive, base}
+		default:
+			if err := os.MkdirAll(v.Source, 0o755); err != nil {
+				return nil, err
+			}
+		}
+
+		dockerArgs := []string{"run", "--rm", "-v", mount, "-v", b.Path + ":/backup:ro",
+			backupImage, "sh", "-c", script, "restore"}
+		if err := runDocker(append(dockerArgs, args...)...); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", v.Name, err)
+		}
+	}
+	return &b.Manifest, nil
+}
+
+// restoreTargets matches the volumes of a backup's manifest, by kind and name, with the volumes and
+// bind mounts of the stack's current compose file. The returned targets have the compose file's
+// sources and the manifest's archive names, which must be ones archiveName could have created.
+func restoreTargets(s Stack, manifest BackupManifest) ([]StackVolume, error) {
+	current, _, _, err := stackVolumes(s, true)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]StackVolume, 0, len(manifest.Volumes))
+	for _, v := range manifest.Volumes {
+		if !validArchiveName.MatchString(v.Archive) || !strings.HasPrefix(v.Archive, v.Kind+"-") {
+			return nil, fmt.Errorf("invalid archive name %q in backup of stack %s", v.Archive, s.Name)
+		}
+		i := slices.IndexFunc(current, func(c StackVolume) bool { return c.Kind == v.Kind && c.Name == v.Name })
+		if i < 0 {
+			return nil, fmt.Errorf("backup %s %s is not declared by the compose file of stack %s",
+				v.Kind, v.Name, s.Name)
+		}
+
+		target := current[i]
+		target.Archive, target.SHA256, target.Size = v.Archive, v.SHA256, v.Size
+		if _, err := os.Stat(target.Source); target.Kind == VolumeBind && err != nil {
+			target.File = v.File // The mount does not exist yet, so the backup tells what it was
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// FindBackup returns a backup of a stack: the latest when backup is empty, or the one with the
+// given directory name or path.
+func (m *Manager) FindBackup(stackName, backup, root string) (Backup, error) {
+	if backup == "" {
+		backups, err := m.ListBackups(stackName, root)
+		if err != nil {
+			return Backup{}, err
+		}
+		if len(backups) == 0 {
+			return Backup{}, fmt.Errorf("no backups of stack %s in %s", stackName, m.backupRoot(root))
+		}
+		return backups[0], nil
+	}
+
+	path := backup
+	if !strings.ContainsRune(backup, filepath.Separator) {
+		path = filepath.Join(m.backupRoot(root), stackName, backup)
+	}
+	manifest, err := readBackupManifest(path)
+	if err != nil {
+		return Backup{}, fmt.Errorf("invalid backup %s: %w", path, err)
+	}
+	if !strings.EqualFold(manifest.Stack, stackName) {
+		return Backup{}, fmt.Errorf("backup %s is of stack %s, not %s", path, manifest.Stack, stackName)
+	}
+	return Backup{Path: path, Manifest: manifest}, nil
+}
+
+// VerifyBackup checks the archives of a backup against the checksums in its manifest.
+func VerifyBackup(b Backup) error {
+	for _, v := range b.Manifest.Volumes {
+		sum, _, err := checksumFile(filepath.Join(b.Path, v.Archive))
+		if err != nil {
+			return fmt.Errorf("backup %s is incomplete: %w", b.Path, err)
+		}
+		if sum != v.SHA256 {
+			return fmt.Errorf("checksum mismatch for %s in backup %s", v.Archive, b.Path)
+		}
+	}
+	return nil
+}
+
+// pruneBackups removes the backups of a stack beyond the keep newest ones.
+func (m *Manager) pruneBackups(stackName, root string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	backups, err := m.ListBackups(stackName, root)
+	if err != nil || len(backups) <= keep {
+		return nil, err
+	}
+
+	var removed []string
+	for _, b := range backups[keep:] {
+		if err := os.RemoveAll(b.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove old backup %s: %w", b.Path, err)
+		}
+		removed = append(removed, b.Path)
+	}
+	return removed, nil
+}
+
+// quiesce stops, or pauses, the containers of s if any are running. The returned function starts, or
+// unpauses, them again.
+func (m *Manager) quiesce(s Stack, pause bool) (func() error, error) {
+	details, err := m.ContainerDetails([]string{s.Name})
+	if err != nil {
+		return nil, err
+	}
+	running := false
+	for _, c := range details[s.Name] {
+		if strings.EqualFold(c.State, "running") {
+			running = true
+		}
+	}
+	if !running {
+		return func() error { return nil }, nil
+	}
+
+	stop, start := "stop", "start"
+	if pause {
+		stop, start = "pause", "unpause"
+	}
+	cmd := execCommand("docker", "compose", "-f", s.File, stop)
+	m.attachOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running %s on stack %s: %w", stop, s.Name, err)
+	}
+	return func() error {
+		cmd := execCommand("docker", "compose", "-f", s.File, start)
+		m.attachOutput(cmd)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("error running %s on stack %s: %w", start, s.Name, err)
+		}
+		return nil
+	}, nil
+}
+
+// backupRoot returns root, or the default backup root under the base path.
+func (m *Manager) backupRoot(root string) string {
+	if root != "" {
+		return root
+	}
+	return filepath.Join(m.BasePath, "backups")
+}
+
+// findStack returns the stack with the given name.
+func (m *Manager) findStack(name string) (Stack, error) {
+	stacks, err := m.resolveStacks([]string{name})
+	if err != nil {
+		return Stack{}, err
+	}
+	return stacks[0], nil
+}
+
+// readBackupManifest reads the manifest of the backup in dir.
+func readBackupManifest(dir string) (BackupManifest, error) {
+	var manifest BackupManifest
+	data, err := os.ReadFile(filepath.Join(dir, backupManifestFile))
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+// checksumFile returns the hex SHA-256 checksum and size of a file.
+func checksumFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// runDocker runs a docker command, including its output in the returned error.
+func runDocker(args ...string) error {
+	out, err := execCommand("docker", args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
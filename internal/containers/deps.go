@@ -0,0 +1,128 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stackMetaFile is the optional metadata file next to a stack's compose file.
+const stackMetaFile = "stack.yaml"
+
+// stackMeta is the content of a stack.yaml file.
+type stackMeta struct {
+	// DependsOn names the stacks that must be up and healthy before this stack starts.
+	DependsOn []string `yaml:"depends_on"`
+}
+
+// readStackMeta reads the stack.yaml file in dir. A missing file yields empty metadata.
+func readStackMeta(dir string) (stackMeta, error) {
+	var meta stackMeta
+	data, err := os.ReadFile(filepath.Join(dir, stackMetaFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return meta, nil
+	}
+	if err != nil {
+		return meta, err
+	}
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return meta, err
+	}
+	return meta, nil
+}
+
+// OrderStacks resolves stackNames and sorts them so that every stack comes after the stacks it
+// depends on. With withDependencies, the stacks they depend on are included, transitively.
+func (m *Manager) OrderStacks(stackNames []string, withDependencies bool) ([]Stack, error) {
+	all, err := m.DiscoverStacks()
+	if err != nil {
+		return nil, err
+	}
+	targets, err := m.resolveStacks(stackNames)
+	if err != nil {
+		return nil, err
+	}
+	return orderStacks(all, targets, withDependencies)
+}
+
+// orderStacks topologically sorts targets by their depends_on declarations, dependencies first. The
+// whole dependency graph is walked, so targets are ordered even when they only depend on each other
+// through stacks that are not targeted.
+func orderStacks(all, targets []Stack, withDependencies bool) ([]Stack, error) {
+	byName := make(map[string]Stack, len(all))
+	for _, s := range all {
+		byName[strings.ToLower(s.Name)] = s
+	}
+	targeted := make(map[string]bool, len(targets))
+	for _, s := range targets {
+		targeted[strings.ToLower(s.Name)] = true
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var path []string
+	var ordered []Stack
+
+	var visit func(s Stack) error
+	visit = func(s Stack) error {
+		key := strings.ToLower(s.Name)
+		switch state[key] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for i, name := range path {
+				if strings.EqualFold(name, s.Name) {
+					start = i
+				}
+			}
+			cycle := append(append([]string{}, path[start:]...), s.Name)
+			return fmt.Errorf("stack dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[key] = visiting
+		path = append(path, s.Name)
+		for _, dep := range s.DependsOn {
+			d, ok := byName[strings.ToLower(dep)]
+			if !ok {
+				return fmt.Errorf("stack %s depends on unknown stack %s", s.Name, dep)
+			}
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+
+		if withDependencies || targeted[key] {
+			ordered = append(ordered, s)
+		}
+		return nil
+	}
+
+	for _, s := range targets {
+		if err := visit(byName[strings.ToLower(s.Name)]); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// isDependency reports whether any of stacks depends on s.
+func isDependency(s Stack, stacks []Stack) bool {
+	for _, other := range stacks {
+		for _, dep := range other.DependsOn {
+			if strings.EqualFold(dep, s.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
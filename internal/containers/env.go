@@ -0,0 +1,361 @@
+package containers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VarRef is a variable reference in a compose file, such as ${VAR} or ${VAR:-default}.
+type VarRef struct {
+	Name string
+	// Operator is the interpolation operator: "" for ${VAR} and $VAR, ":-" or "-" for a default,
+	// ":?" or "?" for a required variable, and ":+" or "+" for an alternate value.
+	Operator string
+	Value    string // Default, error message, or alternate value following the operator
+	Nested   bool   // The reference is inside the default or alternate value of another reference
+	Line     int    // Line of the compose file the reference is on
+}
+
+// EnvCheck is the result of checking a stack's compose file against its env file.
+type EnvCheck struct {
+	Stack     string
+	File      string   // Compose file
+	EnvFile   string   // Env file Up uses, empty when the stack has none
+	Fallback  bool     // EnvFile is .env because .env.<env> does not exist
+	Missing   []VarRef // References without a default whose variable is not set
+	Defaulted []VarRef // References whose variable is not set, so their default applies
+	Unused    []string // Env file keys no reference uses
+}
+
+// EnvDiffEntry compares a key of two env files.
+type EnvDiffEntry struct {
+	Key    string
+	A, B   string
+	InA    bool
+	InB    bool
+	Differ bool // The key is in both files with different values, or in only one of them
+}
+
+// EnvDiff compares the env files of a stack for two environments.
+type EnvDiff struct {
+	Stack        string
+	FileA, FileB string // Env files Up uses for each environment, empty when the stack has none
+	Entries      []EnvDiffEntry
+}
+
+// EnvFile returns the env file Up passes to docker compose for envName: .env.<envName>, or .env
+// when it does not exist. It returns an empty string when the stack has neither.
+func (s Stack) EnvFile(envName string) string {
+	envFile := filepath.Join(s.Path, fmt.Sprintf(".env.%s", envName))
+	if _, err := os.Stat(envFile); err == nil {
+		return envFile
+	}
+	defaultEnv := filepath.Join(s.Path, ".env")
+	if _, err := os.Stat(defaultEnv); err == nil {
+		return defaultEnv
+	}
+	return ""
+}
+
+// CheckEnv compares the variables referenced by the compose files of target stack(s) with the env
+// files Up would use for envName.
+func (m *Manager) CheckEnv(stackNames []string, envName string) ([]EnvCheck, error) {
+	targetStacks, err := m.resolveStacks(stackNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []EnvCheck
+	for _, s := range targetStacks {
+		refs, err := ComposeVariables(s.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse compose file of stack %s: %w", s.Name, err)
+		}
+
+		check := EnvCheck{Stack: s.Name, File: s.File, EnvFile: s.EnvFile(envName)}
+		vars := map[string]string{}
+		if check.EnvFile != "" {
+			check.Fallback = filepath.Base(check.EnvFile) == ".env"
+			if vars, err = ReadEnvFile(check.EnvFile); err != nil {
+				return nil, err
+			}
+		}
+
+		checkRefs(&check, refs, vars)
+		results = append(results, check)
+	}
+	return results, nil
+}
+
+// checkRefs sorts refs into the missing and defaulted references of check, and records the keys of
+// vars that no reference uses.
+func checkRefs(check *EnvCheck, refs []VarRef, vars map[string]string) {
+	missing := map[string]bool{}
+	referenced := map[string]bool{}
+	var defaulted []VarRef
+
+	for _, ref := range refs {
+		referenced[ref.Name] = true
+		value, set := vars[ref.Name]
+		if ref.Nested {
+			if !set {
+				defaulted = append(defaulted, ref)
+			}
+			continue
+		}
+		switch ref.Operator {
+		case "", "?":
+			if !set && !missing[ref.Name] {
+				missing[ref.Name] = true
+				check.Missing = append(check.Missing, ref)
+			}
+		case ":?":
+			if (!set || value == "") && !missing[ref.Name] {
+				missing[ref.Name] = true
+				check.Missing = append(check.Missing, ref)
+			}
+		case ":-":
+			if !set || value == "" {
+				defaulted = append(defaulted, ref)
+			}
+		case "-":
+			if !set {
+				defaulted = append(defaulted, ref)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, ref := range defaulted {
+		if !missing[ref.Name] && !seen[ref.Name] {
+			seen[ref.Name] = true
+			check.Defaulted = append(check.Defaulted, ref)
+		}
+	}
+
+	for key := range vars {
+		// Compose reads its own settings, such as COMPOSE_PROJECT_NAME, from the env file.
+		if !referenced[key] && !strings.HasPrefix(key, "COMPOSE_") && !strings.HasPrefix(key, "DOCKER_") {
+			check.Unused = append(check.Unused, key)
+		}
+	}
+	sort.Strings(check.Unused)
+}
+
+// DiffEnv compares, key by key, the env files Up would use for envA and envB in target stack(s).
+func (m *Manager) DiffEnv(stackNames []string, envA, envB string) ([]EnvDiff, error) {
+	targetStacks, err := m.resolveStacks(stackNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []EnvDiff
+	for _, s := range targetStacks {
+		diff := EnvDiff{Stack: s.Name, FileA: s.EnvFile(envA), FileB: s.EnvFile(envB)}
+		a, b := map[string]string{}, map[string]string{}
+		if diff.FileA != "" {
+			if a, err = ReadEnvFile(diff.FileA); err != nil {
+				return nil, err
+			}
+		}
+		if diff.FileB != "" {
+			if b, err = ReadEnvFile(diff.FileB); err != nil {
+				return nil, err
+			}
+		}
+		diff.Entries = diffEnvVars(a, b)
+		results = append(results, diff)
+	}
+	return results, nil
+}
+
+// diffEnvVars compares the keys of a and b, sorted by key.
+func diffEnvVars(a, b map[string]string) []EnvDiffEntry {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	entries := make([]EnvDiffEntry, 0, len(keys))
+	for k := range keys {
+		e := EnvDiffEntry{Key: k}
+		e.A, e.InA = a[k]
+		e.B, e.InB = b[k]
+		e.Differ = e.InA != e.InB || e.A != e.B
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// ReadEnvFile reads the KEY=VALUE lines of an env file. Blank lines and comments are skipped, an
+// "export " prefix is allowed, and quotes around values are removed.
+func ReadEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	vars := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, _ := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		vars[key] = unquoteEnvValue(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// unquoteEnvValue removes the quotes around a value, or the comment after an unquoted one.
+func unquoteEnvValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+// ComposeVariables returns the variable references in the values of a compose file, in file order.
+// Comments are ignored, and so are escaped dollar signs ($$).
+func ComposeVariables(composeFile string) ([]VarRef, error) {
+	data, err := os.ReadFile(composeFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var refs []VarRef
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		switch n.Kind {
+		case yaml.ScalarNode:
+			for _, ref := range parseInterpolations(n.Value, false) {
+				ref.Line = n.Line
+				refs = append(refs, ref)
+			}
+		case yaml.MappingNode:
+			for i := 1; i < len(n.Content); i += 2 {
+				walk(n.Content[i])
+			}
+		default:
+			for _, c := range n.Content {
+				walk(c)
+			}
+		}
+	}
+	walk(&doc)
+	return refs, nil
+}
+
+// interpolationOperators are the operators of braced references, two-character ones first.
+var interpolationOperators = []string{":-", ":?", ":+", "-", "?", "+"}
+
+// parseInterpolations returns the variable references in s.
+func parseInterpolations(s string, nested bool) []VarRef {
+	var refs []VarRef
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] != '$' {
+			continue
+		}
+		switch next := s[i+1]; {
+		case next == '$':
+			i++
+		case next == '{':
+			end := closingBrace(s, i+1)
+			if end < 0 {
+				return refs
+			}
+			refs = append(refs, parseBraced(s[i+2:end], nested)...)
+			i = end
+		case isVarNameStart(next):
+			j := i + 1
+			for j < len(s) && isVarNameChar(s[j]) {
+				j++
+			}
+			refs = append(refs, VarRef{Name: s[i+1 : j], Nested: nested})
+			i = j - 1
+		}
+	}
+	return refs
+}
+
+// parseBraced parses the expression inside ${...}, including references nested in its value.
+func parseBraced(expr string, nested bool) []VarRef {
+	n := 0
+	for n < len(expr) && isVarNameChar(expr[n]) {
+		n++
+	}
+	if n == 0 || !isVarNameStart(expr[0]) {
+		return nil
+	}
+
+	ref := VarRef{Name: expr[:n], Nested: nested}
+	rest := expr[n:]
+	for _, op := range interpolationOperators {
+		if strings.HasPrefix(rest, op) {
+			ref.Operator = op
+			ref.Value = rest[len(op):]
+			break
+		}
+	}
+
+	refs := []VarRef{ref}
+	if ref.Operator != "" {
+		refs = append(refs, parseInterpolations(ref.Value, true)...)
+	}
+	return refs
+}
+
+// closingBrace returns the index of the brace closing the one at open, or -1.
+func closingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isVarNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isVarNameChar(c byte) bool {
+	return isVarNameStart(c) || (c >= '0' && c <= '9')
+}
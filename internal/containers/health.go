@@ -0,0 +1,142 @@
+package containers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultHealthTimeout bounds how long stacks are waited on to become healthy when no timeout is given.
+const DefaultHealthTimeout = 2 * time.Minute
+
+// healthPollInterval is how often container health is polled. Tests may shorten it.
+var healthPollInterval = 2 * time.Second
+
+// UnhealthyError reports the containers that were not ready when waiting for stacks timed out.
+type UnhealthyError struct {
+	Timeout time.Duration
+	// Pending holds the containers that were not ready, keyed by stack name. A stack without
+	// containers maps to an empty list.
+	Pending map[string][]ContainerDetail
+}
+
+func (e *UnhealthyError) Error() string {
+	names := make([]string, 0, len(e.Pending))
+	for name := range e.Pending {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		pending := e.Pending[name]
+		if len(pending) == 0 {
+			parts = append(parts, fmt.Sprintf("stack %s did not become healthy within %s: no containers found",
+				name, e.Timeout))
+			continue
+		}
+		containers := make([]string, len(pending))
+		for i, c := range pending {
+			state := c.State
+			if c.Health != "" {
+				state += ", " + c.Health
+			}
+			containers[i] = fmt.Sprintf("%s (%s)", c.Name, state)
+		}
+		parts = append(parts, fmt.Sprintf("stack %s did not become healthy within %s: %s",
+			name, e.Timeout, strings.Join(containers, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Services returns the names of the services of a stack that were not ready.
+func (e *UnhealthyError) Services(stackName string) []string {
+	var services []string
+	for _, c := range e.Pending[stackName] {
+		if c.Service != "" && !slices.Contains(services, c.Service) {
+			services = append(services, c.Service)
+		}
+	}
+	return services
+}
+
+// ContainerReady reports whether a container is running and, if it has a health check, healthy.
+// A container that exited with code 0, such as a one-shot init or migration service, is also ready.
+func ContainerReady(c ContainerDetail) bool {
+	if strings.EqualFold(c.State, "exited") {
+		return c.ExitCode == 0
+	}
+	return strings.EqualFold(c.State, "running") && (c.Health == "" || strings.EqualFold(c.Health, "healthy"))
+}
+
+// WaitHealthy polls the containers of target stack(s) until all of them are ready, or returns an
+// *UnhealthyError once timeout passes. A zero timeout selects DefaultHealthTimeout. onPoll, if not
+// nil, receives the containers of each poll, for example to render progress.
+func (m *Manager) WaitHealthy(
+	stackNames []string,
+	timeout time.Duration,
+	onPoll func(details map[string][]ContainerDetail),
+) error {
+	targetStacks, err := m.resolveStacks(stackNames)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(targetStacks))
+	for i, s := range targetStacks {
+		names[i] = s.Name
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultHealthTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		details, err := m.ContainerDetails(names)
+		if err != nil {
+			return err
+		}
+		if onPoll != nil {
+			onPoll(details)
+		}
+
+		pending := make(map[string][]ContainerDetail)
+		for _, name := range names {
+			if len(details[name]) == 0 {
+				pending[name] = []ContainerDetail{}
+			}
+			for _, c := range details[name] {
+				if !ContainerReady(c) {
+					pending[name] = append(pending[name], c)
+				}
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return &UnhealthyError{Timeout: timeout, Pending: pending}
+		}
+		time.Sleep(healthPollInterval)
+	}
+}
+
+// ServiceLogs returns the last tail lines of logs of the given services of a stack, or of all its
+// services when none are given.
+func (m *Manager) ServiceLogs(stackName string, services []string, tail int) (string, error) {
+	targetStacks, err := m.resolveStacks([]string{stackName})
+	if err != nil || len(targetStacks) == 0 {
+		return "", fmt.Errorf("stack %q not found", stackName)
+	}
+
+	args := []string{"compose", "-f", targetStacks[0].File, "logs", "--no-color", "--tail", strconv.Itoa(tail)}
+	args = append(args, services...)
+	out, err := execCommand("docker", args...).CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("error reading logs of stack %s: %w", stackName, err)
+	}
+	return string(out), nil
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -22,11 +25,17 @@ type Stack struct {
 	Services   []string `json:"services"`
 	Status     string   `json:"status"`
 	Containers int      `json:"containers"`
+	DependsOn  []string `json:"depends_on,omitempty"` // Stacks declared in stack.yaml that must start first
 }
 
 // Manager handles Docker Compose stack operations.
 type Manager struct {
 	BasePath string
+	// Output receives the output of compose commands. It defaults to the process's stdout and stderr.
+	Output io.Writer
+	// HealthTimeout bounds how long Up waits for the dependencies of a stack to become healthy.
+	// It defaults to two minutes.
+	HealthTimeout time.Duration
 }
 
 // NewManager creates a new containers Manager targeting the specified base path.
@@ -54,12 +63,17 @@ func (m *Manager) DiscoverStacks() ([]Stack, error) {
 				}
 				if _, err := os.Stat(composeFile); err == nil {
 					services, _ := parseServices(composeFile)
+					meta, err := readStackMeta(filepath.Dir(composeFile))
+					if err != nil {
+						return nil, fmt.Errorf("invalid %s of stack %s: %w", stackMetaFile, stackName, err)
+					}
 					stacks = append(stacks, Stack{
-						Name:     stackName,
-						Path:     filepath.Dir(composeFile),
-						File:     composeFile,
-						Services: services,
-						Status:   "Unknown",
+						Name:      stackName,
+						Path:      filepath.Dir(composeFile),
+						File:      composeFile,
+						Services:  services,
+						Status:    "Unknown",
+						DependsOn: meta.DependsOn,
 					})
 				}
 			}
@@ -96,28 +110,24 @@ func (m *Manager) EnsureSharedNetwork() error {
 	return nil
 }
 
-// Up starts target stack(s) using docker compose up.
+// Up starts target stack(s) using docker compose up. The stacks they depend on are started too,
+// dependencies first. When detached, Up waits for a stack to become healthy before starting the
+// stacks that depend on it.
 func (m *Manager) Up(stackNames []string, envName string, detach, build bool) error {
 	if err := m.EnsureSharedNetwork(); err != nil {
 		// Log warning or continue
 	}
 
-	targetStacks, err := m.resolveStacks(stackNames)
+	targetStacks, err := m.OrderStacks(stackNames, true)
 	if err != nil {
 		return err
 	}
 
-	for _, s := range targetStacks {
+	for i, s := range targetStacks {
 		args := []string{"compose", "-f", s.File}
 
-		envFile := filepath.Join(s.Path, fmt.Sprintf(".env.%s", envName))
-		if _, err := os.Stat(envFile); err == nil {
+		if envFile := s.EnvFile(envName); envFile != "" {
 			args = append(args, "--env-file", envFile)
-		} else {
-			defaultEnv := filepath.Join(s.Path, ".env")
-			if _, err := os.Stat(defaultEnv); err == nil {
-				args = append(args, "--env-file", defaultEnv)
-			}
 		}
 
 		args = append(args, "up")
@@ -129,21 +139,27 @@ func (m *Manager) Up(stackNames []string, envName string, detach, build bool) er
 		}
 
 		cmd := execCommand("docker", args...)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+		m.attachOutput(cmd)
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("error starting stack %s: %w", s.Name, err)
 		}
+
+		if detach && isDependency(s, targetStacks[i+1:]) {
+			if err := m.WaitHealthy([]string{s.Name}, m.HealthTimeout, nil); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
-// Down stops target stack(s) using docker compose down.
+// Down stops target stack(s) using docker compose down, stacks that depend on others first.
 func (m *Manager) Down(stackNames []string, removeVolumes bool) error {
-	targetStacks, err := m.resolveStacks(stackNames)
+	targetStacks, err := m.OrderStacks(stackNames, false)
 	if err != nil {
 		return err
 	}
+	slices.Reverse(targetStacks)
 
 	for _, s := range targetStacks {
 		args := []string{"compose", "-f", s.File, "down"}
@@ -152,8 +168,7 @@ func (m *Manager) Down(stackNames []string, removeVolumes bool) error {
 		}
 
 		cmd := execCommand("docker", args...)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+		m.attachOutput(cmd)
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("error stopping stack %s: %w", s.Name, err)
 		}
@@ -161,6 +176,23 @@ func (m *Manager) Down(stackNames []string, removeVolumes bool) error {
 	return nil
 }
 
+// Restart restarts the containers of target stack(s) using docker compose restart.
+func (m *Manager) Restart(stackNames []string) error {
+	targetStacks, err := m.resolveStacks(stackNames)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range targetStacks {
+		cmd := execCommand("docker", "compose", "-f", s.File, "restart")
+		m.attachOutput(cmd)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("error restarting stack %s: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
 // Pull pulls latest images for target stack(s).
 func (m *Manager) Pull(stackNames []string) error {
 	targetStacks, err := m.resolveStacks(stackNames)
@@ -170,8 +202,7 @@ func (m *Manager) Pull(stackNames []string) error {
 
 	for _, s := range targetStacks {
 		cmd := execCommand("docker", "compose", "-f", s.File, "pull")
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+		m.attachOutput(cmd)
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("error pulling stack %s: %w", s.Name, err)
 		}
@@ -221,9 +252,19 @@ func (m *Manager) Logs(stackName string, follow bool, tail string) error {
 	}
 
 	cmd := execCommand("docker", args...)
+	m.attachOutput(cmd)
+	return cmd.Run()
+}
+
+// attachOutput sends the command's output to m.Output, or to the process's stdout and stderr.
+func (m *Manager) attachOutput(cmd *exec.Cmd) {
+	if m.Output != nil {
+		cmd.Stdout = m.Output
+		cmd.Stderr = m.Output
+		return
+	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
 }
 
 func (m *Manager) resolveStacks(names []string) ([]Stack, error) {
@@ -288,6 +329,7 @@ type ContainerDetail struct {
 	State      string      `json:"State"`
 	Status     string      `json:"Status"`
 	Health     string      `json:"Health"`
+	ExitCode   int         `json:"ExitCode"`
 	Image      string      `json:"Image"`
 	Publishers []Publisher `json:"Publishers"`
 }
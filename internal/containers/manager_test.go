@@ -1,9 +1,16 @@
 package containers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDiscoverStacks(t *testing.T) {
@@ -100,3 +107,680 @@ func TestParseContainerDetailsJSON(t *testing.T) {
 		t.Errorf("unexpected publisher mapping: %+v", details[0].Publishers)
 	}
 }
+
+func TestRestartWritesToOutput(t *testing.T) {
+	tempDir := t.TempDir()
+	webDir := filepath.Join(tempDir, "stacks", "web")
+	_ = os.MkdirAll(webDir, 0o755)
+	if err := os.WriteFile(filepath.Join(webDir, "docker-compose.yml"), []byte("services:\n  app:\n    image: nginx\n"), 0o644); err != nil {
+		t.Fatalf("failed to write mock compose: %v", err)
+	}
+
+	oldExec := execCommand
+	defer func() { execCommand = oldExec }()
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		return exec.Command("echo", append([]string{name}, arg...)...)
+	}
+
+	var out bytes.Buffer
+	mgr := NewManager(tempDir)
+	mgr.Output = &out
+	if err := mgr.Restart([]string{"web"}); err != nil {
+		t.Fatalf("unexpected error restarting stack: %v", err)
+	}
+
+	want := "docker compose -f " + filepath.Join(webDir, "docker-compose.yml") + " restart"
+	if got := strings.TrimSpace(out.String()); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if err := mgr.Restart([]string{"missing"}); err == nil {
+		t.Error("expected an error for an unknown stack")
+	}
+}
+
+// writeStack creates a stack with a single service and, when deps are given, a stack.yaml.
+func writeStack(t *testing.T, basePath, name string, deps ...string) {
+	t.Helper()
+	dir := filepath.Join(basePath, "stacks", name)
+	_ = os.MkdirAll(dir, 0o755)
+	compose := []byte("services:\n  app:\n    image: nginx\n")
+	if err := os.WriteFile(filepath.Join(dir, "docker-compose.yml"), compose, 0o644); err != nil {
+		t.Fatalf("failed to write mock compose: %v", err)
+	}
+	if len(deps) > 0 {
+		meta := "depends_on:\n  - " + strings.Join(deps, "\n  - ") + "\n"
+		if err := os.WriteFile(filepath.Join(dir, "stack.yaml"), []byte(meta), 0o644); err != nil {
+			t.Fatalf("failed to write mock stack.yaml: %v", err)
+		}
+	}
+}
+
+func stackNames(stacks []Stack) string {
+	var names []string
+	for _, s := range stacks {
+		names = append(names, s.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestStackDependencyOrder(t *testing.T) {
+	tempDir := t.TempDir()
+	writeStack(t, tempDir, "app", "db", "proxy")
+	writeStack(t, tempDir, "db", "proxy")
+	writeStack(t, tempDir, "proxy")
+	writeStack(t, tempDir, "worker", "db")
+
+	oldExec, oldInterval := execCommand, healthPollInterval
+	defer func() { execCommand, healthPollInterval = oldExec, oldInterval }()
+	healthPollInterval = time.Millisecond
+
+	var calls []string
+	health := "healthy"
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		call := strings.Join(append([]string{name}, arg...), " ")
+		if strings.Contains(call, " ps ") {
+			return exec.Command("echo", `{"Name":"c1","State":"running","Health":"`+health+`"}`)
+		}
+		if !strings.Contains(call, " network ") {
+			calls = append(calls, filepath.Base(filepath.Dir(arg[2]))+" "+arg[len(arg)-1])
+		}
+		return exec.Command("true")
+	}
+
+	mgr := NewManager(tempDir)
+	mgr.Output = &bytes.Buffer{}
+
+	order, err := mgr.OrderStacks([]string{"app"}, true)
+	if err != nil {
+		t.Fatalf("unexpected error ordering stacks: %v", err)
+	}
+	if got := stackNames(order); got != "proxy,db,app" {
+		t.Errorf("expected app with its dependencies in order proxy,db,app, got %s", got)
+	}
+
+	if err := mgr.Up([]string{"worker", "app"}, "prod", true, false); err != nil {
+		t.Fatalf("unexpected error starting stacks: %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "proxy -d,db -d,worker -d,app -d" {
+		t.Errorf("expected dependencies to start first, got %s", got)
+	}
+
+	// Down only stops the targets, dependents first.
+	calls = nil
+	if err := mgr.Down([]string{"proxy", "app"}, false); err != nil {
+		t.Fatalf("unexpected error stopping stacks: %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "app down,proxy down" {
+		t.Errorf("expected dependents to stop first, got %s", got)
+	}
+
+	// A dependency that never becomes healthy stops the stacks depending on it from starting.
+	calls = nil
+	health = "unhealthy"
+	mgr.HealthTimeout = 5 * time.Millisecond
+	err = mgr.Up([]string{"db"}, "prod", true, false)
+	if err == nil || !strings.Contains(err.Error(), "proxy did not become healthy") ||
+		!strings.Contains(err.Error(), "c1 (running, unhealthy)") {
+		t.Errorf("expected a health timeout for proxy, got %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "proxy -d" {
+		t.Errorf("expected only proxy to start, got %s", got)
+	}
+}
+
+func TestStackDependencyErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	writeStack(t, tempDir, "a", "b")
+	writeStack(t, tempDir, "b", "c")
+	writeStack(t, tempDir, "c", "a")
+	writeStack(t, tempDir, "d", "missing")
+
+	mgr := NewManager(tempDir)
+	_, err := mgr.OrderStacks([]string{"a"}, true)
+	if err == nil || !strings.Contains(err.Error(), "cycle: a -> b -> c -> a") {
+		t.Errorf("expected a dependency cycle error, got %v", err)
+	}
+	_, err = mgr.OrderStacks([]string{"d"}, true)
+	if err == nil || !strings.Contains(err.Error(), "unknown stack missing") {
+		t.Errorf("expected an unknown dependency error, got %v", err)
+	}
+
+	invalid := []byte("depends_on: [b")
+	if err := os.WriteFile(filepath.Join(tempDir, "stacks", "a", "stack.yaml"), invalid, 0o644); err != nil {
+		t.Fatalf("failed to write mock stack.yaml: %v", err)
+	}
+	_, err = mgr.DiscoverStacks()
+	if err == nil || !strings.Contains(err.Error(), "invalid stack.yaml of stack a") {
+		t.Errorf("expected an invalid stack.yaml error, got %v", err)
+	}
+}
+
+func TestWaitHealthy(t *testing.T) {
+	tempDir := t.TempDir()
+	writeStack(t, tempDir, "api")
+	writeStack(t, tempDir, "web")
+
+	oldExec := execCommand
+	defer func() { execCommand = oldExec }()
+	dbHealth := "starting"
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		call := strings.Join(arg, " ")
+		switch {
+		case strings.Contains(call, "stacks/web/") && strings.Contains(call, " ps "):
+			return exec.Command("echo", `{"Name":"web-app-1","Service":"app","State":"running"}
+{"Name":"web-db-1","Service":"db","State":"running","Health":"`+dbHealth+`"}
+{"Name":"web-migrate-1","Service":"migrate","State":"exited","ExitCode":0}`)
+		case strings.Contains(call, "stacks/api/") && strings.Contains(call, " ps "):
+			return exec.Command("true")
+		}
+		return exec.Command("echo", append([]string{name}, arg...)...)
+	}
+
+	mgr := NewManager(tempDir)
+	polls := 0
+	err := mgr.WaitHealthy([]string{"web", "api"}, time.Millisecond, func(map[string][]ContainerDetail) {
+		polls++
+	})
+	var unhealthy *UnhealthyError
+	if !errors.As(err, &unhealthy) {
+		t.Fatalf("expected an UnhealthyError, got %v", err)
+	}
+	if polls == 0 {
+		t.Error("expected onPoll to be called")
+	}
+	if got := unhealthy.Services("web"); len(got) != 1 || got[0] != "db" {
+		t.Errorf("expected only the db service of web to be pending, got %v", got)
+	}
+	if failed := (ContainerDetail{State: "exited", ExitCode: 1}); ContainerReady(failed) {
+		t.Error("expected a container that exited with code 1 not to be ready")
+	}
+	if pending, ok := unhealthy.Pending["api"]; !ok || len(pending) != 0 {
+		t.Errorf("expected api to be pending without containers, got %v", unhealthy.Pending)
+	}
+	for _, want := range []string{
+		"stack api did not become healthy within 1ms: no containers found",
+		"web-db-1 (running, starting)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %q", want, err.Error())
+		}
+	}
+
+	dbHealth = "healthy"
+	if err := mgr.WaitHealthy([]string{"web"}, time.Millisecond, nil); err != nil {
+		t.Errorf("expected web to be healthy, got %v", err)
+	}
+
+	logs, err := mgr.ServiceLogs("web", []string{"db"}, 20)
+	if err != nil {
+		t.Fatalf("unexpected error reading logs: %v", err)
+	}
+	composeFile := filepath.Join(tempDir, "stacks", "web", "docker-compose.yml")
+	want := "docker compose -f " + composeFile + " logs --no-color --tail 20 db"
+	if got := strings.TrimSpace(logs); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestNewStack(t *testing.T) {
+	tempDir := t.TempDir()
+	writeStack(t, tempDir, "proxy")
+
+	mgr := NewManager(tempDir)
+	written, err := mgr.NewStack("web", NewStackOptions{
+		Services:  []string{"redis", "app", "postgres"},
+		DependsOn: []string{"proxy"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error scaffolding stack: %v", err)
+	}
+	if len(written) != 5 {
+		t.Errorf("expected compose, three env files, and stack.yaml to be written, got %v", written)
+	}
+
+	stacks, err := mgr.OrderStacks([]string{"web"}, true)
+	if err != nil {
+		t.Fatalf("unexpected error discovering scaffolded stack: %v", err)
+	}
+	if got := stackNames(stacks); got != "proxy,web" {
+		t.Errorf("expected web to depend on proxy, got %s", got)
+	}
+	if got := strings.Join(stacks[1].Services, ","); len(stacks[1].Services) != 3 ||
+		!strings.Contains(got, "app") || !strings.Contains(got, "postgres") || !strings.Contains(got, "redis") {
+		t.Errorf("expected app, postgres, and redis services, got %s", got)
+	}
+
+	stackDir := filepath.Join(tempDir, "stacks", "web")
+	compose, err := os.ReadFile(filepath.Join(stackDir, "docker-compose.yml"))
+	if err != nil {
+		t.Fatalf("failed to read compose file: %v", err)
+	}
+	for _, want := range []string{
+		"name: web",
+		"eng-shared-net:\n    external: true",
+		"- web-postgres",
+		"- web-redis",
+		"postgres-data:",
+		"redis-data:",
+	} {
+		if !strings.Contains(string(compose), want) {
+			t.Errorf("expected compose file to contain %q, got:\n%s", want, compose)
+		}
+	}
+
+	for _, env := range []string{"example", "dev", "prod"} {
+		data, err := os.ReadFile(filepath.Join(stackDir, ".env."+env))
+		if err != nil {
+			t.Fatalf("failed to read .env.%s: %v", env, err)
+		}
+		if !strings.Contains(string(data), "POSTGRES_PASSWORD=") || !strings.Contains(string(data), "APP_IMAGE=") {
+			t.Errorf("expected .env.%s to contain the postgres and app variables, got:\n%s", env, data)
+		}
+	}
+
+	// Existing stacks are only regenerated with Force, and their dev and prod files are kept.
+	if err := os.WriteFile(filepath.Join(stackDir, ".env.prod"), []byte("SECRET=1\n"), 0o600); err != nil {
+		t.Fatalf("failed to write .env.prod: %v", err)
+	}
+	if _, err := mgr.NewStack("web", NewStackOptions{}); !errors.Is(err, ErrStackExists) {
+		t.Errorf("expected ErrStackExists, got %v", err)
+	}
+	if _, err := mgr.NewStack("web", NewStackOptions{Services: []string{"traefik"}, Force: true}); err != nil {
+		t.Fatalf("unexpected error regenerating stack: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(stackDir, ".env.prod")); string(data) != "SECRET=1\n" {
+		t.Errorf("expected .env.prod to be kept, got:\n%s", data)
+	}
+
+	for name, opts := range map[string]NewStackOptions{
+		"Web":   {},
+		"cache": {Services: []string{"mysql"}},
+		"edge":  {Services: []string{"traefik", "caddy"}},
+		"api":   {DependsOn: []string{"missing"}},
+	} {
+		if _, err := mgr.NewStack(name, opts); err == nil {
+			t.Errorf("expected an error scaffolding %s with %+v", name, opts)
+		}
+	}
+}
+
+func TestComposeVariables(t *testing.T) {
+	tempDir := t.TempDir()
+	compose := `# ${IGNORED} in comments
+services:
+  app:
+    image: ${IMAGE}:${TAG:-latest}
+    command: ["sh", "-c", "echo $$ESCAPED $HOME"]
+    environment:
+      DB_URL: postgres://${DB_USER:?db user required}@${DB_HOST-db}/${DB_NAME:-${STACK_NAME}}
+      DEBUG: ${DEBUG:+--verbose}
+      OPTIONAL: ${OPTIONAL?}
+`
+	file := filepath.Join(tempDir, "docker-compose.yml")
+	if err := os.WriteFile(file, []byte(compose), 0o644); err != nil {
+		t.Fatalf("failed to write mock compose: %v", err)
+	}
+
+	refs, err := ComposeVariables(file)
+	if err != nil {
+		t.Fatalf("unexpected error parsing compose variables: %v", err)
+	}
+
+	var got []string
+	for _, ref := range refs {
+		s := ref.Name + ref.Operator + ref.Value
+		if ref.Nested {
+			s += " (nested)"
+		}
+		got = append(got, s)
+	}
+	want := []string{
+		"IMAGE", "TAG:-latest", "HOME", "DB_USER:?db user required", "DB_HOST-db",
+		"DB_NAME:-${STACK_NAME}", "STACK_NAME (nested)", "DEBUG:+--verbose", "OPTIONAL?",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected references %v, got %v", want, got)
+	}
+	if refs[0].Line != 4 || refs[3].Line != 7 {
+		t.Errorf("expected references on lines 4 and 7, got %d and %d", refs[0].Line, refs[3].Line)
+	}
+}
+
+func TestCheckAndDiffEnv(t *testing.T) {
+	tempDir := t.TempDir()
+	stackDir := filepath.Join(tempDir, "stacks", "web")
+	_ = os.MkdirAll(stackDir, 0o755)
+	compose := `services:
+  app:
+    image: ${IMAGE}:${TAG:-latest}
+    environment:
+      PASSWORD: ${PASSWORD:?required}
+      LEVEL: ${LEVEL-info}
+`
+	files := map[string]string{
+		"docker-compose.yml": compose,
+		".env.dev":           "IMAGE=web\nPASSWORD=dev\nLEVEL=debug\n",
+		".env.prod":          "# production\nexport IMAGE=\"web\"\nPASSWORD=\nTAG=1.2 # pinned\nOLD_KEY='x'\n",
+		".env":               "IMAGE=web\nPASSWORD=local\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(stackDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	mgr := NewManager(tempDir)
+	names := func(refs []VarRef) string {
+		var s []string
+		for _, ref := range refs {
+			s = append(s, ref.Name)
+		}
+		return strings.Join(s, ",")
+	}
+
+	checks, err := mgr.CheckEnv(nil, "prod")
+	if err != nil {
+		t.Fatalf("unexpected error checking env: %v", err)
+	}
+	c := checks[0]
+	if filepath.Base(c.EnvFile) != ".env.prod" || c.Fallback {
+		t.Errorf("expected .env.prod to be checked, got %s (fallback %v)", c.EnvFile, c.Fallback)
+	}
+	if got := names(c.Missing); got != "PASSWORD" {
+		t.Errorf("expected the empty required PASSWORD to be missing, got %s", got)
+	}
+	if got := names(c.Defaulted); got != "LEVEL" {
+		t.Errorf("expected LEVEL to use its default, got %s", got)
+	}
+	if got := strings.Join(c.Unused, ","); got != "OLD_KEY" {
+		t.Errorf("expected OLD_KEY to be unused, got %s", got)
+	}
+
+	checks, err = mgr.CheckEnv([]string{"web"}, "staging")
+	if err != nil {
+		t.Fatalf("unexpected error checking env: %v", err)
+	}
+	if c := checks[0]; !c.Fallback || len(c.Missing) != 0 || names(c.Defaulted) != "TAG,LEVEL" {
+		t.Errorf("expected staging to fall back to .env with defaults for TAG and LEVEL, got %+v", c)
+	}
+
+	diffs, err := mgr.DiffEnv([]string{"web"}, "dev", "prod")
+	if err != nil {
+		t.Fatalf("unexpected error diffing env: %v", err)
+	}
+	var differ []string
+	for _, e := range diffs[0].Entries {
+		if e.Differ {
+			differ = append(differ, e.Key)
+		}
+	}
+	if got := strings.Join(differ, ","); got != "LEVEL,OLD_KEY,PASSWORD,TAG" {
+		t.Errorf("expected LEVEL, OLD_KEY, PASSWORD, and TAG to differ, got %s", got)
+	}
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	tempDir := t.TempDir()
+	stackDir := filepath.Join(tempDir, "stacks", "web")
+	_ = os.MkdirAll(filepath.Join(stackDir, "config"), 0o755)
+	_ = os.WriteFile(filepath.Join(stackDir, "Caddyfile"), []byte(":80\n"), 0o644)
+	compose := `name: Web
+services:
+  app:
+    image: app
+    volumes:
+      - ./config:/etc/app:ro
+      - ./Caddyfile:/etc/caddy/Caddyfile
+      - /var/run/app.sock:/var/run/app.sock
+      - ${DATA_DIR}/uploads:/uploads
+      - /cache
+  db:
+    image: postgres
+    volumes:
+      - db-data:/var/lib/postgresql/data
+      - type: volume
+        source: shared
+        target: /shared
+      - type: tmpfs
+        target: /tmp
+  worker:
+    image: app
+    volumes:
+      - db-data:/data
+      - missing:/missing
+volumes:
+  db-data:
+  shared:
+    external: true
+  missing:
+`
+	if err := os.WriteFile(filepath.Join(stackDir, "docker-compose.yml"), []byte(compose), 0o644); err != nil {
+		t.Fatalf("failed to write mock compose: %v", err)
+	}
+
+	oldExec := execCommand
+	defer func() { execCommand = oldExec }()
+
+	var calls []string
+	execCommand = func(name string, arg ...string) *exec.Cmd {
+		call := strings.Join(arg, " ")
+		switch {
+		case strings.Contains(call, " ps "):
+			return exec.Command("echo", `{"Name":"web-app-1","State":"running"}`)
+		case strings.HasPrefix(call, "volume inspect"):
+			if arg[len(arg)-1] == "web_missing" {
+				return exec.Command("false")
+			}
+			return exec.Command("true")
+		case strings.Contains(call, "tar czf"):
+			// Write the archive the helper container would create in the directory mounted at /backup.
+			var backupDir, archive string
+			for i, a := range arg {
+				if strings.HasSuffix(a, ":/backup") {
+					backupDir = strings.TrimSuffix(a, ":/backup")
+				}
+				if strings.HasPrefix(a, "/backup/") && arg[i-1] == "czf" {
+					archive = strings.TrimPrefix(a, "/backup/")
+				}
+			}
+			calls = append(calls, "archive "+archive)
+			return exec.Command("sh", "-c", "echo data > "+filepath.Join(backupDir, archive))
+		}
+		calls = append(calls, call)
+		return exec.Command("true")
+	}
+
+	mgr := NewManager(tempDir)
+	mgr.Output = &bytes.Buffer{}
+	backupRoot := filepath.Join(tempDir, "backups")
+
+	res, err := mgr.Backup("web", BackupOptions{Keep: 2})
+	if err != nil {
+		t.Fatalf("unexpected error backing up stack: %v", err)
+	}
+
+	var sources []string
+	for _, v := range res.Manifest.Volumes {
+		sources = append(sources, v.Kind+":"+v.Source+":"+strings.Join(v.Services, "+"))
+	}
+	wantSources := []string{
+		"bind:" + filepath.Join(stackDir, "config") + ":app",
+		"bind:" + filepath.Join(stackDir, "Caddyfile") + ":app",
+		"volume:web_db-data:db+worker",
+		"volume:shared:db",
+	}
+	if strings.Join(sources, ",") != strings.Join(wantSources, ",") {
+		t.Errorf("expected volumes %v, got %v", wantSources, sources)
+	}
+	if len(res.Skipped) != 3 {
+		t.Errorf("expected the socket, interpolated path, and missing volume to be skipped, got %v", res.Skipped)
+	}
+	if res.Manifest.Project != "web" || !res.Manifest.Volumes[1].File {
+		t.Errorf("expected project web and Caddyfile as a file mount, got %+v", res.Manifest)
+	}
+
+	configMount := StackVolume{Kind: VolumeBind, Name: filepath.Join(stackDir, "config")}
+	configArchive := archiveName(configMount, map[string]bool{})
+	wantCalls := "compose -f " + filepath.Join(stackDir, "docker-compose.yml") + " stop," +
+		"archive " + configArchive + ","
+	if got := strings.Join(calls, ","); !strings.HasPrefix(got, wantCalls) || !strings.HasSuffix(got, " start") {
+		t.Errorf("expected the stack to stop, be archived, and start again, got %s", got)
+	}
+
+	sums, err := os.ReadFile(filepath.Join(res.Path, "SHA256SUMS"))
+	if err != nil || strings.Count(string(sums), ".tar.gz\n") != 4 {
+		t.Errorf("expected checksums of 4 archives, got %q (%v)", sums, err)
+	}
+
+	// Only the two newest backups are kept.
+	for _, name := range []string{"20200101-000000", "20210101-000000"} {
+		old := filepath.Join(backupRoot, "web", name)
+		_ = os.MkdirAll(old, 0o755)
+		manifest := []byte(`{"stack":"web","volumes":[]}`)
+		if err := os.WriteFile(filepath.Join(old, "manifest.json"), manifest, 0o644); err != nil {
+			t.Fatalf("failed to write old manifest: %v", err)
+		}
+	}
+	res, err = mgr.Backup("web", BackupOptions{Keep: 2, Live: true})
+	if err != nil {
+		t.Fatalf("unexpected error backing up stack: %v", err)
+	}
+	if len(res.Removed) != 2 || filepath.Base(res.Removed[0]) != "20210101-000000" ||
+		filepath.Base(res.Removed[1]) != "20200101-000000" {
+		t.Errorf("expected the two old backups to be removed, got %v", res.Removed)
+	}
+
+	// Restore verifies checksums, stops the stack, recreates volumes, and starts it again.
+	calls = nil
+	manifest, err := mgr.Restore("web", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error restoring stack: %v", err)
+	}
+	if len(manifest.Volumes) != 4 {
+		t.Errorf("expected 4 volumes to be restored, got %d", len(manifest.Volumes))
+	}
+	got := strings.Join(calls, "\n")
+	for _, want := range []string{
+		"volume create --label com.docker.compose.project=web --label com.docker.compose.volume=db-data web_db-data",
+		"-v web_db-data:/target",
+		"-v " + filepath.Join(stackDir, "config") + ":/target -v",
+		"-v " + filepath.Join(stackDir, "Caddyfile") + ":/target/Caddyfile -v",
+		"sh -c " + restoreFileScript + " restore bind-",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected restore call containing %q, got:\n%s", want, got)
+		}
+	}
+	if !strings.HasSuffix(calls[0], " stop") || !strings.HasSuffix(got, " start") {
+		t.Errorf("expected the stack to be stopped and started again, got:\n%s", got)
+	}
+
+	latest, err := mgr.FindBackup("web", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error finding backup: %v", err)
+	}
+
+	// Sources come from the compose file, whatever the manifest says, and unknown volumes or archive
+	// names that are not archiveName's are rejected before anything is touched.
+	manifestFile := filepath.Join(latest.Path, "manifest.json")
+	original, _ := os.ReadFile(manifestFile)
+	for _, tc := range []struct {
+		name    string
+		edit    func(m *BackupManifest)
+		wantErr string
+	}{
+		{"foreign source", func(m *BackupManifest) { m.Volumes[0].Source = "/etc" }, ""},
+		{"unknown volume", func(m *BackupManifest) { m.Volumes[2].Name = "other" }, "not declared"},
+		{"shell archive", func(m *BackupManifest) { m.Volumes[0].Archive = "bind-x;rm -rf ~.tar.gz" }, "invalid"},
+		{"path archive", func(m *BackupManifest) { m.Volumes[0].Archive = "bind-../../x.tar.gz" }, "invalid"},
+	} {
+		tampered := latest.Manifest
+		tampered.Volumes = slices.Clone(latest.Manifest.Volumes)
+		tc.edit(&tampered)
+		data, _ := json.Marshal(tampered)
+		if err := os.WriteFile(manifestFile, data, 0o644); err != nil {
+			t.Fatalf("failed to write manifest: %v", err)
+		}
+
+		calls = nil
+		_, err := mgr.Restore("web", latest.Path, "")
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		case tc.wantErr == "" && strings.Contains(strings.Join(calls, "\n"), "/etc:"):
+			t.Errorf("%s: expected the compose file's source to be restored, got:\n%s", tc.name, calls)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+		case tc.wantErr != "" && len(calls) != 0:
+			t.Errorf("%s: expected nothing to be touched, got %v", tc.name, calls)
+		}
+	}
+	_ = os.WriteFile(manifestFile, original, 0o644)
+
+	archive := filepath.Join(latest.Path, latest.Manifest.Volumes[0].Archive)
+	if err := os.WriteFile(archive, []byte("tampered\n"), 0o644); err != nil {
+		t.Fatalf("failed to tamper with archive: %v", err)
+	}
+	calls = nil
+	if _, err := mgr.Restore("web", filepath.Base(latest.Path), ""); err == nil ||
+		!strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected a checksum mismatch, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected nothing to be touched after a checksum mismatch, got %v", calls)
+	}
+}
+
+func TestBackupResumeFailureKeepsBackup(t *testing.T) {
+	for _, pause := range []bool{false, true} {
+		resumeCmd := map[bool]string{false: "start", true: "unpause"}[pause]
+		t.Run(resumeCmd, func(t *testing.T) {
+			tempDir := t.TempDir()
+			stackDir := filepath.Join(tempDir, "stacks", "web")
+			_ = os.MkdirAll(stackDir, 0o755)
+			compose := "services:\n  db:\n    image: postgres\n    volumes:\n      - db-data:/data\n" +
+				"volumes:\n  db-data:\n"
+			if err := os.WriteFile(filepath.Join(stackDir, "docker-compose.yml"), []byte(compose), 0o644); err != nil {
+				t.Fatalf("failed to write mock compose: %v", err)
+			}
+
+			oldExec := execCommand
+			defer func() { execCommand = oldExec }()
+			execCommand = func(name string, arg ...string) *exec.Cmd {
+				call := strings.Join(arg, " ")
+				switch {
+				case strings.Contains(call, " ps "):
+					return exec.Command("echo", `{"Name":"web-db-1","State":"running"}`)
+				case strings.Contains(call, "tar czf"):
+					var backupDir, archive string
+					for i, a := range arg {
+						if dir, ok := strings.CutSuffix(a, ":/backup"); ok {
+							backupDir = dir
+						}
+						if a == "czf" {
+							archive = strings.TrimPrefix(arg[i+1], "/backup/")
+						}
+					}
+					return exec.Command("sh", "-c", "echo data > "+filepath.Join(backupDir, archive))
+				case strings.HasSuffix(call, " "+resumeCmd):
+					return exec.Command("false")
+				}
+				return exec.Command("true")
+			}
+
+			mgr := NewManager(tempDir)
+			mgr.Output = &bytes.Buffer{}
+			res, err := mgr.Backup("web", BackupOptions{Pause: pause})
+			if err == nil || !strings.Contains(err.Error(), resumeCmd) {
+				t.Fatalf("expected the %s failure to be reported, got %v", resumeCmd, err)
+			}
+			if res == nil {
+				t.Fatal("expected the completed backup to be returned with the error")
+			}
+			if _, statErr := os.Stat(filepath.Join(res.Path, "SHA256SUMS")); statErr != nil {
+				t.Errorf("expected the completed backup to be kept: %v", statErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package containers
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// ServiceTemplate describes a service that NewStack can scaffold.
+type ServiceTemplate struct {
+	Name        string
+	Description string
+	Volumes     []string // Named volumes the service stores its data in
+}
+
+// serviceTemplates are the available service templates, in the order they are written to a stack.
+var serviceTemplates = []ServiceTemplate{
+	{Name: "traefik", Description: "Traefik reverse proxy routing containers on eng-shared-net"},
+	{
+		Name:        "caddy",
+		Description: "Caddy reverse proxy with automatic HTTPS",
+		Volumes:     []string{"caddy-data", "caddy-config"},
+	},
+	{Name: "app", Description: "Generic application container"},
+	{Name: "postgres", Description: "PostgreSQL database", Volumes: []string{"postgres-data"}},
+	{Name: "redis", Description: "Redis cache", Volumes: []string{"redis-data"}},
+}
+
+// DefaultServiceTemplate is scaffolded when no service templates are requested.
+const DefaultServiceTemplate = "app"
+
+// ErrStackExists is returned by NewStack when the stack already has a compose file.
+var ErrStackExists = errors.New("stack already exists")
+
+// stackNamePattern matches the names Docker Compose accepts as project names.
+var stackNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// scaffoldEnvs are the environment files NewStack writes. The dev and prod files match the
+// .env.<env> lookup of Up.
+var scaffoldEnvs = []string{"example", "dev", "prod"}
+
+// NewStackOptions configures NewStack.
+type NewStackOptions struct {
+	Services  []string // Service templates to include; defaults to DefaultServiceTemplate
+	DependsOn []string // Stacks to declare in stack.yaml
+	Force     bool     // Overwrite the compose file and .env.example of an existing stack
+}
+
+// ServiceTemplates returns the service templates NewStack can scaffold.
+func ServiceTemplates() []ServiceTemplate {
+	return slices.Clone(serviceTemplates)
+}
+
+// NewStack scaffolds a stack at stacks/<name> under the base path: a docker-compose.yml with the
+// requested services on eng-shared-net, a .env.example, and .env.dev and .env.prod skeletons. The
+// dev and prod files may hold secrets, so existing ones are never overwritten. It returns the paths
+// of the files it wrote.
+func (m *Manager) NewStack(name string, opts NewStackOptions) ([]string, error) {
+	if !stackNamePattern.MatchString(name) {
+		return nil, fmt.Errorf(
+			"invalid stack name %q: use lowercase letters, digits, dashes, and underscores", name)
+	}
+
+	templates, err := lookupServiceTemplates(opts.Services)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := m.DiscoverStacks()
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range opts.DependsOn {
+		if !slices.ContainsFunc(existing, func(s Stack) bool { return strings.EqualFold(s.Name, dep) }) {
+			return nil, fmt.Errorf("stack %s cannot depend on unknown stack %s", name, dep)
+		}
+	}
+
+	dir := filepath.Join(m.BasePath, "stacks", name)
+	composeFile := filepath.Join(dir, "docker-compose.yml")
+	if !opts.Force {
+		for _, file := range []string{composeFile, filepath.Join(dir, "docker-compose.yaml")} {
+			if _, err := os.Stat(file); err == nil {
+				return nil, fmt.Errorf("%w: %s at %s", ErrStackExists, name, dir)
+			}
+		}
+	}
+
+	compose, err := renderCompose(name, templates)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create stack directory: %w", err)
+	}
+
+	var written []string
+	if err := os.WriteFile(composeFile, compose, 0o644); err != nil {
+		return nil, err
+	}
+	written = append(written, composeFile)
+
+	for _, env := range scaffoldEnvs {
+		file := filepath.Join(dir, ".env."+env)
+		if env != "example" {
+			if _, err := os.Stat(file); err == nil {
+				continue
+			}
+		}
+		content, err := renderEnvFile(name, env, templates)
+		if err != nil {
+			return written, err
+		}
+		perm := os.FileMode(0o600)
+		if env == "example" {
+			perm = 0o644
+		}
+		if err := os.WriteFile(file, content, perm); err != nil {
+			return written, err
+		}
+		written = append(written, file)
+	}
+
+	if len(opts.DependsOn) > 0 {
+		meta, err := yaml.Marshal(stackMeta{DependsOn: opts.DependsOn})
+		if err != nil {
+			return written, err
+		}
+		file := filepath.Join(dir, stackMetaFile)
+		if err := os.WriteFile(file, meta, 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, file)
+	}
+
+	return written, nil
+}
+
+// lookupServiceTemplates returns the templates of names, without duplicates, in template order.
+func lookupServiceTemplates(names []string) ([]ServiceTemplate, error) {
+	if len(names) == 0 {
+		names = []string{DefaultServiceTemplate}
+	}
+
+	var available []string
+	for _, t := range serviceTemplates {
+		available = append(available, t.Name)
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(available, name) {
+			return nil, fmt.Errorf("unknown service template %q (available: %s)", name, strings.Join(available, ", "))
+		}
+		wanted[name] = true
+	}
+	if wanted["traefik"] && wanted["caddy"] {
+		return nil, errors.New("choose one reverse proxy: traefik or caddy")
+	}
+
+	var templates []ServiceTemplate
+	for _, t := range serviceTemplates {
+		if wanted[t.Name] {
+			templates = append(templates, t)
+		}
+	}
+	return templates, nil
+}
+
+// scaffoldData is the data the service and env file templates are executed with.
+type scaffoldData struct {
+	Stack string
+	Env   string
+}
+
+// renderCompose renders the compose file of a stack with the given services.
+func renderCompose(stack string, templates []ServiceTemplate) ([]byte, error) {
+	data := struct {
+		Stack    string
+		Services string
+		Volumes  []string
+	}{Stack: stack}
+
+	var services []string
+	for _, t := range templates {
+		service, err := executeTemplate(t.Name+".service.tmpl", scaffoldData{Stack: stack})
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, strings.TrimRight(service, "\n"))
+		data.Volumes = append(data.Volumes, t.Volumes...)
+	}
+
+	data.Services = strings.Join(services, "\n\n")
+
+	out, err := executeTemplate("docker-compose.yml.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+// renderEnvFile renders the .env.<env> file of a stack with the given services.
+func renderEnvFile(stack, env string, templates []ServiceTemplate) ([]byte, error) {
+	var buf bytes.Buffer
+	if env == "example" {
+		fmt.Fprintf(&buf, "# Variables of stack %s. Copy them to .env.dev or .env.prod and fill in values.\n", stack)
+	} else {
+		fmt.Fprintf(&buf, "# %s environment of stack %s, used by `eng compose up %s -e %s`.\n",
+			env, stack, stack, env)
+	}
+
+	for _, t := range templates {
+		section, err := executeTemplate(t.Name+".env.tmpl", scaffoldData{Stack: stack, Env: env})
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("\n" + section)
+	}
+	return buf.Bytes(), nil
+}
+
+// executeTemplate executes the embedded template name with data.
+func executeTemplate(name string, data any) (string, error) {
+	tmpl, err := template.ParseFS(templateFS, "templates/"+name)
+	if err != nil {
+		return "", fmt.Errorf("failed to load template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/exec"
@@ -36,6 +37,8 @@ type Manager struct {
 	BackupScript  string
 	RestoreScript string
 	HTTPClient    *http.Client
+	// Output receives streamed log output. It defaults to the process's stdout and stderr.
+	Output io.Writer
 }
 
 // SystemdUnitStatus represents systemd unit health.
@@ -545,8 +548,7 @@ func (m *Manager) Logs(ctx context.Context, service string, follow bool, tail in
 			args = append(args, "-n", strconv.Itoa(tail))
 		}
 		cmd := execCommandContext(ctx, "journalctl", args...)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+		m.attachOutput(cmd)
 		return cmd.Run()
 	}
 
@@ -563,9 +565,19 @@ func (m *Manager) Logs(ctx context.Context, service string, follow bool, tail in
 	}
 
 	cmd := execCommandContext(ctx, "docker", args...)
+	m.attachOutput(cmd)
+	return cmd.Run()
+}
+
+// attachOutput sends the command's output to m.Output, or to the process's stdout and stderr.
+func (m *Manager) attachOutput(cmd *exec.Cmd) {
+	if m.Output != nil {
+		cmd.Stdout = m.Output
+		cmd.Stderr = m.Output
+		return
+	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
 }
 
 // RenderStatus renders a comprehensive formatted status card.
@@ -0,0 +1,147 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
+)
+
+// DiscoverOptions holds the configuration for discovering projects from existing clones.
+type DiscoverOptions struct {
+	IsVerbose  bool
+	DevPath    string
+	Projects   []config.Project
+	RepoClient RepoClient
+}
+
+// DiscoveryCandidate is a project built from clones found under one directory of the dev path.
+// Project is the definition to save: the existing project (if any) with NewRepos appended.
+type DiscoveryCandidate struct {
+	Project   config.Project
+	NewRepos  []config.ProjectRepo
+	Existing  bool
+	Conflicts []config.ImportConflict
+}
+
+// DiscoveryResult lists the candidates found and the clones that could not be used.
+type DiscoveryResult struct {
+	Candidates []DiscoveryCandidate
+	NoOrigin   []string // Clones without an origin remote
+	Ungrouped  []string // Clones directly under the dev path, which have no project directory
+}
+
+// Label describes the candidate for selection prompts.
+func (c DiscoveryCandidate) Label() string {
+	names := make([]string, 0, len(c.NewRepos))
+	for i := range c.NewRepos {
+		name, err := c.NewRepos[i].GetEffectivePath()
+		if err != nil {
+			name = c.NewRepos[i].URL
+		}
+		names = append(names, name)
+	}
+
+	status := "new project"
+	if c.Existing {
+		status = "add to existing"
+	}
+	return fmt.Sprintf("%s (%s): %s", c.Project.Name, status, strings.Join(names, ", "))
+}
+
+// Discover walks <DevPath>/<group>/<repo>, reads each clone's origin remote, and groups the
+// clones by their parent directory into project candidates. Repositories that are already
+// configured are left out, so re-running discovery only proposes what is new.
+func Discover(ctx context.Context, opts DiscoverOptions) (DiscoveryResult, error) {
+	if opts.RepoClient == nil {
+		opts.RepoClient = &defaultRepoClient{}
+	}
+
+	var result DiscoveryResult
+
+	groups, err := os.ReadDir(opts.DevPath)
+	if err != nil {
+		return result, fmt.Errorf("failed to read development folder: %w", err)
+	}
+
+	var scanned []config.Project
+	for _, group := range groups {
+		if !group.IsDir() || strings.HasPrefix(group.Name(), ".") {
+			continue
+		}
+		groupPath := filepath.Join(opts.DevPath, group.Name())
+		if repo.IsCloned(groupPath) {
+			result.Ungrouped = append(result.Ungrouped, group.Name())
+			continue
+		}
+
+		project, noOrigin := scanGroup(ctx, opts, group.Name(), groupPath)
+		result.NoOrigin = append(result.NoOrigin, noOrigin...)
+		if len(project.Repos) > 0 {
+			scanned = append(scanned, project)
+		}
+	}
+
+	for _, project := range scanned {
+		merged, merge := config.MergeProjects(opts.Projects, []config.Project{project})
+		if merge.AddedRepos == 0 && len(merge.Conflicts) == 0 {
+			log.Verbose(opts.IsVerbose, "All repositories in %s are already configured", project.Name)
+			continue
+		}
+
+		candidate := DiscoveryCandidate{
+			Existing:  len(merge.AddedProjects) == 0,
+			Conflicts: merge.Conflicts,
+		}
+		for _, p := range merged {
+			if strings.EqualFold(p.Name, project.Name) {
+				candidate.Project = p
+				candidate.NewRepos = p.Repos[len(p.Repos)-merge.AddedRepos:]
+				break
+			}
+		}
+		if len(candidate.NewRepos) > 0 || len(candidate.Conflicts) > 0 {
+			result.Candidates = append(result.Candidates, candidate)
+		}
+	}
+	return result, nil
+}
+
+// scanGroup builds a project from the clones directly inside groupPath.
+// It returns the clones without an origin remote separately.
+func scanGroup(ctx context.Context, opts DiscoverOptions, name, groupPath string) (config.Project, []string) {
+	project := config.Project{Name: name}
+	var noOrigin []string
+
+	entries, err := os.ReadDir(groupPath)
+	if err != nil {
+		log.Verbose(opts.IsVerbose, "Skipping %s: %s", groupPath, err)
+		return project, nil
+	}
+
+	for _, entry := range entries {
+		repoPath := filepath.Join(groupPath, entry.Name())
+		if !entry.IsDir() || !repo.IsCloned(repoPath) {
+			continue
+		}
+
+		originURL, err := opts.RepoClient.GetOriginURL(ctx, repoPath)
+		if err != nil || originURL == "" {
+			noOrigin = append(noOrigin, filepath.Join(name, entry.Name()))
+			continue
+		}
+
+		projectRepo := config.ProjectRepo{URL: originURL}
+		// Only record a custom path when the directory differs from the name derived from the URL.
+		if derived, err := config.RepoNameFromURL(originURL); err != nil || derived != entry.Name() {
+			projectRepo.Path = entry.Name()
+		}
+		project.Repos = append(project.Repos, projectRepo)
+	}
+	return project, noOrigin
+}
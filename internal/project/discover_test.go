@@ -0,0 +1,92 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eng618/eng/internal/config"
+)
+
+func TestDiscover(t *testing.T) {
+	devPath := t.TempDir()
+	origins := map[string]string{
+		"Platform/api":      "git@github.com:org/api.git",
+		"Platform/frontend": "git@github.com:org/web.git", // custom directory name
+		"Platform/worker":   "git@github.com:org/worker.git",
+		"Tools/cli":         "https://github.com/org/cli.git",
+		"Tools/scratch":     "", // no origin
+		"standalone":        "git@github.com:org/standalone.git",
+	}
+	for dir := range origins {
+		require.NoError(t, os.MkdirAll(filepath.Join(devPath, dir, ".git"), 0o755))
+	}
+	// Plain directories and hidden folders are ignored.
+	require.NoError(t, os.MkdirAll(filepath.Join(devPath, "Platform", "notes"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(devPath, ".cache", "x", ".git"), 0o755))
+
+	client := &MockRepoClient{
+		GetOriginURLFunc: func(ctx context.Context, repoPath string) (string, error) {
+			rel, _ := filepath.Rel(devPath, repoPath)
+			if url := origins[filepath.ToSlash(rel)]; url != "" {
+				return url, nil
+			}
+			return "", errors.New("no git remote origin url found")
+		},
+	}
+
+	existing := []config.Project{
+		{Name: "Platform", Repos: []config.ProjectRepo{{URL: "git@github.com:org/api.git"}}},
+	}
+
+	result, err := Discover(context.Background(), DiscoverOptions{
+		DevPath:    devPath,
+		Projects:   existing,
+		RepoClient: client,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"standalone"}, result.Ungrouped)
+	assert.Equal(t, []string{filepath.Join("Tools", "scratch")}, result.NoOrigin)
+	require.Len(t, result.Candidates, 2)
+
+	platform := result.Candidates[0]
+	assert.True(t, platform.Existing)
+	assert.Equal(t, []config.ProjectRepo{
+		{URL: "git@github.com:org/web.git", Path: "frontend"},
+		{URL: "git@github.com:org/worker.git"},
+	}, platform.NewRepos)
+	assert.Len(t, platform.Project.Repos, 3, "existing repositories are kept in the saved definition")
+	assert.Equal(t, "Platform (add to existing): frontend, worker", platform.Label())
+
+	tools := result.Candidates[1]
+	assert.False(t, tools.Existing)
+	assert.Equal(t, "Tools", tools.Project.Name)
+	assert.Equal(t, []config.ProjectRepo{{URL: "https://github.com/org/cli.git"}}, tools.Project.Repos)
+
+	// Once everything is configured, nothing new is proposed.
+	var all []config.Project
+	for _, c := range result.Candidates {
+		all = append(all, c.Project)
+	}
+	result, err = Discover(context.Background(), DiscoverOptions{
+		DevPath:    devPath,
+		Projects:   all,
+		RepoClient: client,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Candidates)
+}
+
+func TestDiscover_MissingDevPath(t *testing.T) {
+	_, err := Discover(context.Background(), DiscoverOptions{
+		DevPath:    filepath.Join(t.TempDir(), "missing"),
+		RepoClient: &MockRepoClient{},
+	})
+	assert.Error(t, err)
+}
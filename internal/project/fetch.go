@@ -20,6 +20,7 @@ type FetchOptions struct {
 	Force         bool
 	IsVerbose     bool
 	ProjectFilter string
+	Tags          []string // Only fetch repositories carrying at least one of these tags
 	DevPath       string
 	Projects      []config.Project
 	RepoClient    RepoClient
@@ -49,7 +50,7 @@ func Fetch(ctx context.Context, opts FetchOptions) {
 
 	var mu sync.Mutex
 	var successCount, failedCount, skippedCount int
-	var failedRepos, skippedRepos []string
+	var failedRepos, skippedRepos, policySkippedRepos []string
 
 	multi, err := ui.NewMultiSpinner()
 	if err != nil {
@@ -67,8 +68,8 @@ func Fetch(ctx context.Context, opts FetchOptions) {
 	for _, project := range projects {
 		projectPath := filepath.Join(opts.DevPath, project.Name)
 
-		for _, repoItem := range project.Repos {
-			r := repoItem // explicitly capture loop variable for closure
+		for _, target := range selectRepos(project, opts.Tags) {
+			r, policy := target.Repo, target.Policy
 
 			eg.Go(func() error {
 				repoPath, err := r.GetEffectivePath()
@@ -82,6 +83,18 @@ func Fetch(ctx context.Context, opts FetchOptions) {
 
 				fullRepoPath := filepath.Join(projectPath, repoPath)
 
+				if policy.SkipSync {
+					if opts.IsVerbose {
+						spinner := multi.AddSpinner(fmt.Sprintf("Skipping %s (skip_sync policy)", repoPath))
+						spinner.Info()
+					}
+					mu.Lock()
+					skippedCount++
+					policySkippedRepos = append(policySkippedRepos, repoPath)
+					mu.Unlock()
+					return nil
+				}
+
 				// Check if repo exists
 				if !repo.IsCloned(fullRepoPath) {
 					if opts.IsVerbose {
@@ -135,6 +148,7 @@ func Fetch(ctx context.Context, opts FetchOptions) {
 			log.Warn("  - %s", r)
 		}
 	}
+	printPolicySkipped(policySkippedRepos)
 	if len(failedRepos) > 0 {
 		log.Error("Failed repositories (require manual resolution):")
 		for _, r := range failedRepos {
@@ -1,6 +1,8 @@
 package project
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -87,9 +89,14 @@ func List(opts ListOptions) {
 				if repoItem.Path != "" {
 					log.Info("      Custom path: %s", repoItem.Path)
 				}
+				if cloned {
+					for _, wt := range listLinkedWorktrees(opts.RepoClient, fullRepoPath) {
+						log.Info("      Worktree: %s (%s)", filepath.Base(wt.Path), worktreeLabel(wt))
+					}
+				}
 			}
 		} else {
-			clonedCount := 0
+			clonedCount, worktreeCount := 0, 0
 			for _, r := range project.Repos {
 				repoPath, err := r.GetEffectivePath()
 				if err != nil {
@@ -98,6 +105,7 @@ func List(opts ListOptions) {
 				fullRepoPath := filepath.Join(projectPath, repoPath)
 				if repo.IsCloned(fullRepoPath) {
 					clonedCount++
+					worktreeCount += len(listLinkedWorktrees(opts.RepoClient, fullRepoPath))
 				}
 			}
 
@@ -105,7 +113,18 @@ func List(opts ListOptions) {
 			if clonedCount < len(project.Repos) {
 				statusIcon = "○"
 			}
-			log.Info("%s %s (%d/%d repos cloned)", statusIcon, project.Name, clonedCount, len(project.Repos))
+			worktreeNote := ""
+			if worktreeCount > 0 {
+				worktreeNote = fmt.Sprintf(", %d worktrees", worktreeCount)
+			}
+			log.Info(
+				"%s %s (%d/%d repos cloned%s)",
+				statusIcon,
+				project.Name,
+				clonedCount,
+				len(project.Repos),
+				worktreeNote,
+			)
 		}
 		log.Info("")
 	}
@@ -114,3 +133,109 @@ func List(opts ListOptions) {
 		log.Info("Use -v for detailed repository information")
 	}
 }
+
+// RepoReport is the machine-readable state of a single repository within a project.
+type RepoReport struct {
+	Name      string           `json:"name" yaml:"name"`
+	URL       string           `json:"url" yaml:"url"`
+	Path      string           `json:"path" yaml:"path"`
+	Cloned    bool             `json:"cloned" yaml:"cloned"`
+	Status    *repo.StatusInfo `json:"status,omitempty" yaml:"status,omitempty"`
+	Worktrees []repo.Worktree  `json:"worktrees,omitempty" yaml:"worktrees,omitempty"`
+	Error     string           `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ProjectReport is the machine-readable state of a project and its repositories.
+type ProjectReport struct {
+	Name  string       `json:"name" yaml:"name"`
+	Path  string       `json:"path" yaml:"path"`
+	Repos []RepoReport `json:"repos" yaml:"repos"`
+}
+
+// BuildListReport collects clone state and detailed git status for every repository
+// in the configured projects. It honors the project filter and produces the same
+// alphabetical ordering as List.
+func BuildListReport(ctx context.Context, opts ListOptions) []ProjectReport {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.RepoClient == nil {
+		opts.RepoClient = &defaultRepoClient{}
+	}
+	devPath := os.ExpandEnv(opts.DevPath)
+
+	projects := filterProjects(opts.Projects, opts.ProjectFilter)
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	reports := make([]ProjectReport, 0, len(projects))
+	for _, p := range projects {
+		projectPath := filepath.Join(devPath, p.Name)
+		report := ProjectReport{
+			Name:  p.Name,
+			Path:  projectPath,
+			Repos: make([]RepoReport, 0, len(p.Repos)),
+		}
+
+		sortedRepos := make([]config.ProjectRepo, len(p.Repos))
+		copy(sortedRepos, p.Repos)
+		sort.Slice(sortedRepos, func(i, j int) bool {
+			return sortedRepos[i].URL < sortedRepos[j].URL
+		})
+
+		for _, repoItem := range sortedRepos {
+			repoReport := RepoReport{URL: repoItem.URL}
+
+			repoPath, err := repoItem.GetEffectivePath()
+			if err != nil {
+				repoReport.Error = "invalid path: " + err.Error()
+				report.Repos = append(report.Repos, repoReport)
+				continue
+			}
+
+			repoReport.Name = filepath.Base(repoPath)
+			repoReport.Path = filepath.Join(projectPath, repoPath)
+			repoReport.Cloned = repo.IsCloned(repoReport.Path)
+
+			if repoReport.Cloned {
+				status, err := opts.RepoClient.GetDetailedStatus(ctx, repoReport.Path)
+				if err != nil {
+					repoReport.Error = err.Error()
+				} else {
+					repoReport.Status = &status
+				}
+				if worktrees, err := opts.RepoClient.ListWorktrees(ctx, repoReport.Path); err == nil {
+					repoReport.Worktrees = linkedWorktrees(worktrees)
+				}
+			}
+
+			report.Repos = append(report.Repos, repoReport)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// listLinkedWorktrees returns the linked worktrees of repoPath, or nil when they cannot be listed.
+func listLinkedWorktrees(client RepoClient, repoPath string) []repo.Worktree {
+	worktrees, err := client.ListWorktrees(context.Background(), repoPath)
+	if err != nil {
+		return nil
+	}
+	return linkedWorktrees(worktrees)
+}
+
+// worktreeLabel describes what a worktree has checked out.
+func worktreeLabel(wt repo.Worktree) string {
+	switch {
+	case wt.Branch != "":
+		return wt.Branch
+	case wt.Detached && len(wt.Head) >= 7:
+		return "detached at " + wt.Head[:7]
+	default:
+		return "detached"
+	}
+}
@@ -2,6 +2,7 @@ package project
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/eng618/eng/internal/config"
 	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
 )
 
 func TestList(t *testing.T) {
@@ -126,3 +128,63 @@ func TestList(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildListReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "ProjectA", "repo1", ".git"), 0o755))
+
+	projects := []config.Project{
+		{
+			Name: "ProjectB",
+			Repos: []config.ProjectRepo{
+				{URL: "git@github.com:org/repo3.git"},
+			},
+		},
+		{
+			Name: "ProjectA",
+			Repos: []config.ProjectRepo{
+				{URL: "git@github.com:org/repo2.git"},
+				{URL: "git@github.com:org/repo1.git"},
+			},
+		},
+	}
+
+	mockClient := &MockRepoClient{
+		GetDetailedStatusFunc: func(_ context.Context, repoPath string) (repo.StatusInfo, error) {
+			return repo.StatusInfo{Branch: "feature", AheadCount: 2, UnstagedCount: 1, OngoingOp: "rebase"}, nil
+		},
+	}
+
+	reports := BuildListReport(context.Background(), ListOptions{
+		DevPath:    tmpDir,
+		Projects:   projects,
+		RepoClient: mockClient,
+	})
+
+	require.Len(t, reports, 2)
+	assert.Equal(t, "ProjectA", reports[0].Name)
+	assert.Equal(t, "ProjectB", reports[1].Name)
+
+	require.Len(t, reports[0].Repos, 2)
+	cloned := reports[0].Repos[0]
+	assert.Equal(t, "repo1", cloned.Name)
+	assert.True(t, cloned.Cloned)
+	require.NotNil(t, cloned.Status)
+	assert.Equal(t, "feature", cloned.Status.Branch)
+	assert.Equal(t, 2, cloned.Status.AheadCount)
+	assert.Equal(t, "rebase", cloned.Status.OngoingOp)
+
+	missing := reports[0].Repos[1]
+	assert.Equal(t, "repo2", missing.Name)
+	assert.False(t, missing.Cloned)
+	assert.Nil(t, missing.Status)
+
+	filtered := BuildListReport(context.Background(), ListOptions{
+		DevPath:       tmpDir,
+		Projects:      projects,
+		ProjectFilter: "ProjectB",
+		RepoClient:    mockClient,
+	})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "ProjectB", filtered[0].Name)
+}
@@ -2,14 +2,26 @@ package project
 
 import (
 	"context"
+
+	"github.com/eng618/eng/internal/repo"
 )
 
 type MockRepoClient struct {
-	CloneFunc            func(ctx context.Context, url, path string) error
-	IsDirtyFunc          func(ctx context.Context, repoPath string) (bool, error)
-	PullLatestCodeFunc   func(ctx context.Context, repoPath string) error
-	FetchAllPruneFunc    func(ctx context.Context, repoPath string) error
-	FetchWithOptionsFunc func(ctx context.Context, repoPath string, force bool) error
+	CloneFunc                 func(ctx context.Context, url, path string) error
+	IsDirtyFunc               func(ctx context.Context, repoPath string) (bool, error)
+	PullLatestCodeFunc        func(ctx context.Context, repoPath string) error
+	PullWithAutostashFunc     func(ctx context.Context, repoPath string) error
+	PullWithStrategyFunc      func(ctx context.Context, repoPath, strategy string, autostash bool) error
+	SwitchToBranchFunc        func(ctx context.Context, repoPath, branch string) (bool, error)
+	FetchAllPruneFunc         func(ctx context.Context, repoPath string) error
+	FetchWithOptionsFunc      func(ctx context.Context, repoPath string, force bool) error
+	GetDetailedStatusFunc     func(ctx context.Context, repoPath string) (repo.StatusInfo, error)
+	RefreshDetailedStatusFunc func(ctx context.Context, repoPath string) (repo.StatusInfo, error)
+	GetOriginURLFunc          func(ctx context.Context, repoPath string) (string, error)
+	ListWorktreesFunc         func(ctx context.Context, repoPath string) ([]repo.Worktree, error)
+	AddWorktreeFunc           func(ctx context.Context, repoPath, worktreePath, branch string) error
+	RemoveWorktreeFunc        func(ctx context.Context, repoPath, worktreePath string, force bool) error
+	IntegrateUpstreamFunc     func(ctx context.Context, repoPath, strategy string) error
 }
 
 func (m *MockRepoClient) Clone(ctx context.Context, url, path string) error {
@@ -33,6 +45,27 @@ func (m *MockRepoClient) PullLatestCode(ctx context.Context, repoPath string) er
 	return nil
 }
 
+func (m *MockRepoClient) PullWithAutostash(ctx context.Context, repoPath string) error {
+	if m.PullWithAutostashFunc != nil {
+		return m.PullWithAutostashFunc(ctx, repoPath)
+	}
+	return nil
+}
+
+func (m *MockRepoClient) PullWithStrategy(ctx context.Context, repoPath, strategy string, autostash bool) error {
+	if m.PullWithStrategyFunc != nil {
+		return m.PullWithStrategyFunc(ctx, repoPath, strategy, autostash)
+	}
+	return nil
+}
+
+func (m *MockRepoClient) SwitchToBranch(ctx context.Context, repoPath, branch string) (bool, error) {
+	if m.SwitchToBranchFunc != nil {
+		return m.SwitchToBranchFunc(ctx, repoPath, branch)
+	}
+	return false, nil
+}
+
 func (m *MockRepoClient) FetchAllPrune(ctx context.Context, repoPath string) error {
 	if m.FetchAllPruneFunc != nil {
 		return m.FetchAllPruneFunc(ctx, repoPath)
@@ -46,3 +79,52 @@ func (m *MockRepoClient) FetchWithOptions(ctx context.Context, repoPath string,
 	}
 	return nil
 }
+
+func (m *MockRepoClient) GetDetailedStatus(ctx context.Context, repoPath string) (repo.StatusInfo, error) {
+	if m.GetDetailedStatusFunc != nil {
+		return m.GetDetailedStatusFunc(ctx, repoPath)
+	}
+	return repo.StatusInfo{Branch: "main"}, nil
+}
+
+func (m *MockRepoClient) RefreshDetailedStatus(ctx context.Context, repoPath string) (repo.StatusInfo, error) {
+	if m.RefreshDetailedStatusFunc != nil {
+		return m.RefreshDetailedStatusFunc(ctx, repoPath)
+	}
+	return repo.StatusInfo{Branch: "main"}, nil
+}
+
+func (m *MockRepoClient) GetOriginURL(ctx context.Context, repoPath string) (string, error) {
+	if m.GetOriginURLFunc != nil {
+		return m.GetOriginURLFunc(ctx, repoPath)
+	}
+	return "", nil
+}
+
+func (m *MockRepoClient) ListWorktrees(ctx context.Context, repoPath string) ([]repo.Worktree, error) {
+	if m.ListWorktreesFunc != nil {
+		return m.ListWorktreesFunc(ctx, repoPath)
+	}
+	return nil, nil
+}
+
+func (m *MockRepoClient) AddWorktree(ctx context.Context, repoPath, worktreePath, branch string) error {
+	if m.AddWorktreeFunc != nil {
+		return m.AddWorktreeFunc(ctx, repoPath, worktreePath, branch)
+	}
+	return nil
+}
+
+func (m *MockRepoClient) RemoveWorktree(ctx context.Context, repoPath, worktreePath string, force bool) error {
+	if m.RemoveWorktreeFunc != nil {
+		return m.RemoveWorktreeFunc(ctx, repoPath, worktreePath, force)
+	}
+	return nil
+}
+
+func (m *MockRepoClient) IntegrateUpstream(ctx context.Context, repoPath, strategy string) error {
+	if m.IntegrateUpstreamFunc != nil {
+		return m.IntegrateUpstreamFunc(ctx, repoPath, strategy)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+)
+
+// policyTarget pairs a configured repository with its effective sync policy.
+type policyTarget struct {
+	Repo   config.ProjectRepo
+	Policy config.RepoPolicy
+}
+
+// selectRepos resolves the policy of each repository in p and keeps those matching tags.
+func selectRepos(p config.Project, tags []string) []policyTarget {
+	targets := make([]policyTarget, 0, len(p.Repos))
+	for _, r := range p.Repos {
+		policy := p.PolicyFor(r)
+		if !policy.MatchesTags(tags) {
+			continue
+		}
+		targets = append(targets, policyTarget{Repo: r, Policy: policy})
+	}
+	return targets
+}
+
+// wantsBranchSwitch reports whether the policy requires a checkout before pulling.
+func wantsBranchSwitch(policy config.RepoPolicy) bool {
+	return policy.Branch != "" || policy.AutoSwitch
+}
+
+// describePolicy summarizes the non-default parts of a policy for dry-run output.
+func describePolicy(policy config.RepoPolicy) string {
+	var parts []string
+	switch {
+	case policy.Branch != "":
+		parts = append(parts, "branch "+policy.Branch)
+	case policy.AutoSwitch:
+		parts = append(parts, "default branch")
+	}
+	if policy.PullStrategy != "" {
+		parts = append(parts, policy.PullStrategy)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(parts, ", "))
+}
+
+// switchForPolicy checks out the branch required by policy, if any.
+func switchForPolicy(
+	ctx context.Context,
+	client RepoClient,
+	repoPath string,
+	policy config.RepoPolicy,
+) (bool, error) {
+	if !wantsBranchSwitch(policy) {
+		return false, nil
+	}
+	return client.SwitchToBranch(ctx, repoPath, policy.Branch)
+}
+
+// pullForPolicy pulls repoPath using the policy's pull strategy. Without an explicit
+// strategy it falls back to the default rebase pull, autostashing when the tree is dirty.
+func pullForPolicy(
+	ctx context.Context,
+	client RepoClient,
+	repoPath string,
+	policy config.RepoPolicy,
+	autostash bool,
+) error {
+	if policy.PullStrategy != "" {
+		return client.PullWithStrategy(ctx, repoPath, policy.PullStrategy, autostash)
+	}
+	if autostash {
+		return client.PullWithAutostash(ctx, repoPath)
+	}
+	return client.PullLatestCode(ctx, repoPath)
+}
+
+// printPolicySkipped lists repositories left out because of their skip_sync policy.
+func printPolicySkipped(repos []string) {
+	if len(repos) == 0 {
+		return
+	}
+	sort.Strings(repos)
+	log.Info("Skipped repositories (skip_sync policy):")
+	for _, r := range repos {
+		log.Info("  - %s", r)
+	}
+}
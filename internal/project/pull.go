@@ -21,6 +21,7 @@ type PullOptions struct {
 	DryRun        bool
 	IsVerbose     bool
 	ProjectFilter string
+	Tags          []string // Only pull repositories carrying at least one of these tags
 	DevPath       string
 	Projects      []config.Project
 	RepoClient    RepoClient
@@ -50,7 +51,7 @@ func Pull(ctx context.Context, opts PullOptions) {
 
 	var mu sync.Mutex
 	var successCount, failedCount, skippedCount, dirtyCount int
-	var failedRepos, skippedRepos, dirtyRepos []string
+	var failedRepos, skippedRepos, policySkippedRepos, dirtyRepos []string
 
 	multi, err := ui.NewMultiSpinner()
 	if err != nil {
@@ -68,11 +69,19 @@ func Pull(ctx context.Context, opts PullOptions) {
 	for _, project := range projects {
 		projectPath := filepath.Join(opts.DevPath, project.Name)
 
-		for _, r := range project.Repos {
-			repoObj := r // capture loop variable
+		for _, target := range selectRepos(project, opts.Tags) {
+			repoObj, policy := target.Repo, target.Policy
 			eg.Go(func() error {
 				repoPath, err := repoObj.GetEffectivePath()
+				if err == nil {
+					err = policy.Validate()
+				}
 				if err != nil {
+					if repoPath == "" {
+						repoPath = repoObj.URL
+					}
+					spinner := multi.AddSpinner(fmt.Sprintf("Pulling %s...", repoPath))
+					spinner.Fail(fmt.Sprintf("Invalid configuration for %s: %s", repoPath, err))
 					mu.Lock()
 					failedCount++
 					failedRepos = append(failedRepos, repoPath)
@@ -82,6 +91,18 @@ func Pull(ctx context.Context, opts PullOptions) {
 
 				fullRepoPath := filepath.Join(projectPath, repoPath)
 
+				if policy.SkipSync {
+					if opts.IsVerbose {
+						spinner := multi.AddSpinner(fmt.Sprintf("Skipping %s (skip_sync policy)", repoPath))
+						spinner.Info()
+					}
+					mu.Lock()
+					skippedCount++
+					policySkippedRepos = append(policySkippedRepos, repoPath)
+					mu.Unlock()
+					return nil
+				}
+
 				// Check if repo exists
 				if !repo.IsCloned(fullRepoPath) {
 					if opts.IsVerbose {
@@ -96,7 +117,9 @@ func Pull(ctx context.Context, opts PullOptions) {
 				}
 
 				if opts.DryRun {
-					spinner := multi.AddSpinner(fmt.Sprintf("[DRY RUN] Would pull: %s", repoPath))
+					spinner := multi.AddSpinner(
+						fmt.Sprintf("[DRY RUN] Would pull: %s%s", repoPath, describePolicy(policy)),
+					)
 					spinner.Success()
 					mu.Lock()
 					successCount++
@@ -127,7 +150,18 @@ func Pull(ctx context.Context, opts PullOptions) {
 				}
 
 				spinner := multi.AddSpinner(fmt.Sprintf("Pulling %s...", repoPath))
-				if err := opts.RepoClient.PullLatestCode(egCtx, fullRepoPath); err != nil {
+
+				// Move to the branch required by the sync policy before pulling
+				if _, err := switchForPolicy(egCtx, opts.RepoClient, fullRepoPath, policy); err != nil {
+					spinner.Fail(fmt.Sprintf("Failed to switch branch for %s: %s", repoPath, err))
+					mu.Lock()
+					failedCount++
+					failedRepos = append(failedRepos, repoPath)
+					mu.Unlock()
+					return nil
+				}
+
+				if err := pullForPolicy(egCtx, opts.RepoClient, fullRepoPath, policy, false); err != nil {
 					// Check if it's just "already up to date"
 					if errors.Is(err, git.NoErrAlreadyUpToDate) {
 						spinner.Info(fmt.Sprintf("%s is already up to date", repoPath))
@@ -177,6 +211,7 @@ func Pull(ctx context.Context, opts PullOptions) {
 			log.Warn("  - %s", r)
 		}
 	}
+	printPolicySkipped(policySkippedRepos)
 	if len(failedRepos) > 0 {
 		log.Error("Failed repositories (require manual resolution):")
 		for _, r := range failedRepos {
@@ -0,0 +1,366 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eng618/eng/internal/config"
+	gitlabcfg "github.com/eng618/eng/internal/config/gitlab"
+	"github.com/eng618/eng/internal/log"
+)
+
+// remotePageSize is the number of repositories requested per API page (the maximum for both providers).
+const remotePageSize = 100
+
+// RemoteRepo is a repository listed by a GitHub organization or GitLab group.
+type RemoteRepo struct {
+	Name     string // Repository name
+	Path     string // Path relative to the group, e.g. "sub/name" for GitLab subgroups
+	SSHURL   string
+	HTTPURL  string
+	Archived bool
+	Fork     bool
+}
+
+// RemoteLister enumerates the repositories referenced by a project source.
+type RemoteLister interface {
+	ListRepos(ctx context.Context, src config.ProjectSource) ([]RemoteRepo, error)
+}
+
+// APIRemoteLister lists repositories through the GitHub and GitLab REST APIs.
+// Tokens are optional for public groups; private groups need GitHubToken or GitLabToken.
+type APIRemoteLister struct {
+	HTTPClient  *http.Client
+	GitHubToken string
+	GitLabToken string
+	GitLabHost  string // Host of GitLab sources without a host or api_url; defaults to gitlab.com
+
+	// ResolveGitLabToken, when set, is called once to look up GitLabToken the first time a GitLab
+	// source is listed without one, so Bitwarden is only unlocked when a GitLab group is mirrored.
+	ResolveGitLabToken func() string
+}
+
+// NewAPIRemoteLister returns a lister using the GitHub token from GITHUB_TOKEN (or GH_TOKEN), and
+// the GitLab host and token that the gitlab commands use: gitlab.host, and GITLAB_TOKEN, the
+// Bitwarden item named by gitlab.tokenItem, or gitlab.token.
+func NewAPIRemoteLister() *APIRemoteLister {
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	if githubToken == "" {
+		githubToken = os.Getenv("GH_TOKEN")
+	}
+	gitlabCfg := config.GetGitLabConfig()
+	return &APIRemoteLister{
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+		GitHubToken: githubToken,
+		GitLabHost:  gitlabCfg.Host,
+		ResolveGitLabToken: func() string {
+			return gitlabcfg.ResolveToken(gitlabCfg.TokenItem, gitlabCfg.Token)
+		},
+	}
+}
+
+// ListRepos returns every repository in the source's organization or group, before filtering.
+func (l *APIRemoteLister) ListRepos(ctx context.Context, src config.ProjectSource) ([]RemoteRepo, error) {
+	if err := src.Validate(); err != nil {
+		return nil, err
+	}
+	if src.Provider == config.ProviderGitLab {
+		return l.listGitLab(ctx, src)
+	}
+	return l.listGitHub(ctx, src)
+}
+
+type githubRepo struct {
+	Name     string `json:"name"`
+	SSHURL   string `json:"ssh_url"`
+	CloneURL string `json:"clone_url"`
+	Archived bool   `json:"archived"`
+	Fork     bool   `json:"fork"`
+}
+
+func (l *APIRemoteLister) listGitHub(ctx context.Context, src config.ProjectSource) ([]RemoteRepo, error) {
+	base := strings.TrimSuffix(src.APIURL, "/")
+	if base == "" {
+		base = "https://api.github.com"
+		if src.Host != "" && src.Host != "github.com" {
+			base = "https://" + src.Host + "/api/v3" // GitHub Enterprise Server
+		}
+	}
+
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+	if l.GitHubToken != "" {
+		headers["Authorization"] = "Bearer " + l.GitHubToken
+	}
+
+	owner := url.PathEscape(src.Group)
+	repos, err := l.listGitHubPages(ctx, base+"/orgs/"+owner+"/repos?type=all", headers)
+	var statusErr *apiStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		// Not an organization; fall back to a user account.
+		repos, err = l.listGitHubPages(ctx, base+"/users/"+owner+"/repos?type=owner", headers)
+	}
+	return repos, err
+}
+
+func (l *APIRemoteLister) listGitHubPages(
+	ctx context.Context,
+	endpoint string,
+	headers map[string]string,
+) ([]RemoteRepo, error) {
+	var repos []RemoteRepo
+	for page := 1; ; page++ {
+		var batch []githubRepo
+		pageURL := fmt.Sprintf("%s&per_page=%d&page=%d", endpoint, remotePageSize, page)
+		if _, err := l.getJSON(ctx, pageURL, headers, &batch); err != nil {
+			return nil, err
+		}
+		for _, r := range batch {
+			repos = append(repos, RemoteRepo{
+				Name:     r.Name,
+				Path:     r.Name,
+				SSHURL:   r.SSHURL,
+				HTTPURL:  r.CloneURL,
+				Archived: r.Archived,
+				Fork:     r.Fork,
+			})
+		}
+		if len(batch) < remotePageSize {
+			return repos, nil
+		}
+	}
+}
+
+type gitlabProject struct {
+	Path              string          `json:"path"`
+	PathWithNamespace string          `json:"path_with_namespace"`
+	SSHURLToRepo      string          `json:"ssh_url_to_repo"`
+	HTTPURLToRepo     string          `json:"http_url_to_repo"`
+	Archived          bool            `json:"archived"`
+	ForkedFromProject json.RawMessage `json:"forked_from_project"`
+}
+
+func (l *APIRemoteLister) listGitLab(ctx context.Context, src config.ProjectSource) ([]RemoteRepo, error) {
+	base := strings.TrimSuffix(src.APIURL, "/")
+	if base == "" {
+		host := src.Host
+		if host == "" {
+			host = l.GitLabHost
+		}
+		if host == "" {
+			host = "gitlab.com"
+		}
+		if !strings.Contains(host, "://") {
+			host = "https://" + host
+		}
+		base = strings.TrimSuffix(host, "/") + "/api/v4"
+	}
+
+	if l.GitLabToken == "" && l.ResolveGitLabToken != nil {
+		l.GitLabToken = l.ResolveGitLabToken()
+		l.ResolveGitLabToken = nil
+	}
+	headers := map[string]string{}
+	if l.GitLabToken != "" {
+		headers["PRIVATE-TOKEN"] = l.GitLabToken
+	}
+
+	group := strings.Trim(src.Group, "/")
+	endpoint := fmt.Sprintf(
+		"%s/groups/%s/projects?include_subgroups=%t&per_page=%d",
+		base,
+		url.PathEscape(group),
+		src.IncludeSubgroups,
+		remotePageSize,
+	)
+
+	var repos []RemoteRepo
+	for page := "1"; page != ""; {
+		var batch []gitlabProject
+		header, err := l.getJSON(ctx, endpoint+"&page="+page, headers, &batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range batch {
+			relPath := strings.TrimPrefix(p.PathWithNamespace, group+"/")
+			if relPath == p.PathWithNamespace {
+				relPath = p.Path
+			}
+			repos = append(repos, RemoteRepo{
+				Name:     p.Path,
+				Path:     relPath,
+				SSHURL:   p.SSHURLToRepo,
+				HTTPURL:  p.HTTPURLToRepo,
+				Archived: p.Archived,
+				Fork:     len(p.ForkedFromProject) > 0 && string(p.ForkedFromProject) != "null",
+			})
+		}
+		page = header.Get("X-Next-Page")
+		if _, err := strconv.Atoi(page); err != nil {
+			page = ""
+		}
+	}
+	return repos, nil
+}
+
+// apiStatusError is returned when a provider API responds with a non-200 status.
+type apiStatusError struct {
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *apiStatusError) Error() string {
+	return fmt.Sprintf("%s returned %d: %s", e.Path, e.StatusCode, e.Body)
+}
+
+// getJSON performs a GET request and decodes the JSON response into v.
+func (l *APIRemoteLister) getJSON(
+	ctx context.Context,
+	endpoint string,
+	headers map[string]string,
+	v any,
+) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, val := range headers {
+		req.Header.Set(k, val)
+	}
+
+	client := l.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &apiStatusError{
+			Path:       req.URL.Path,
+			StatusCode: resp.StatusCode,
+			Body:       strings.TrimSpace(string(body)),
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to decode response from %s: %w", req.URL.Path, err)
+	}
+	return resp.Header, nil
+}
+
+// filterRemoteRepos applies the source's archived, fork, and exclude filters.
+func filterRemoteRepos(repos []RemoteRepo, src config.ProjectSource) []RemoteRepo {
+	var kept []RemoteRepo
+	for _, r := range repos {
+		if (r.Archived && !src.IncludeArchived) || (r.Fork && !src.IncludeForks) {
+			continue
+		}
+		excluded := false
+		for _, pattern := range src.Exclude {
+			if ok, _ := filepath.Match(pattern, r.Name); ok {
+				excluded = true
+				break
+			}
+			if ok, _ := filepath.Match(pattern, r.Path); ok {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// projectRepoFor converts a remote repository into a project entry using the source's protocol.
+func projectRepoFor(r RemoteRepo, src config.ProjectSource) config.ProjectRepo {
+	repoURL := r.SSHURL
+	if src.Protocol == "https" || repoURL == "" {
+		repoURL = r.HTTPURL
+	}
+
+	projectRepo := config.ProjectRepo{URL: repoURL}
+	if derived, err := config.RepoNameFromURL(repoURL); err != nil || derived != r.Path {
+		projectRepo.Path = r.Path
+	}
+	return projectRepo
+}
+
+// mirrorSources adds repositories listed by each project's source to the project definition
+// so they are cloned by setup. New repositories are saved through opts.SaveProjects unless
+// running in dry-run mode. It returns the updated copies of projects.
+func mirrorSources(ctx context.Context, opts SetupOptions, projects []config.Project) []config.Project {
+	lister := opts.RemoteLister
+	if lister == nil {
+		lister = NewAPIRemoteLister()
+	}
+
+	updated := append([]config.Project(nil), projects...)
+	all := opts.Projects
+	changed := false
+
+	for i, p := range updated {
+		if p.Source == nil {
+			continue
+		}
+		src := *p.Source
+
+		remote, err := lister.ListRepos(ctx, src)
+		if err != nil {
+			log.Error("Failed to list repositories in %s %s for project %s: %s", src.Provider, src.Group, p.Name, err)
+			continue
+		}
+
+		incoming := config.Project{Name: p.Name}
+		for _, r := range filterRemoteRepos(remote, src) {
+			incoming.Repos = append(incoming.Repos, projectRepoFor(r, src))
+		}
+
+		merged, result := config.MergeProjects(all, []config.Project{incoming})
+		for _, c := range result.Conflicts {
+			log.Warn("Skipping %s from %s %s: %s", c.URL, src.Provider, src.Group, c.Reason)
+		}
+		if result.AddedRepos == 0 {
+			log.Verbose(opts.IsVerbose, "Project %s is up to date with %s %s", p.Name, src.Provider, src.Group)
+			continue
+		}
+
+		log.Info(
+			"Found %d new repositories in %s %s for project %s",
+			result.AddedRepos,
+			src.Provider,
+			src.Group,
+			p.Name,
+		)
+		for _, mp := range merged {
+			if strings.EqualFold(mp.Name, p.Name) {
+				updated[i] = mp
+				break
+			}
+		}
+		all = merged
+		changed = true
+	}
+
+	if changed && !opts.DryRun && opts.SaveProjects != nil {
+		if err := opts.SaveProjects(all); err != nil {
+			log.Error("Failed to save mirrored repositories: %s", err)
+		}
+	}
+	return updated
+}
@@ -0,0 +1,245 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/ui"
+)
+
+func TestAPIRemoteLister_GitHub(t *testing.T) {
+	// Two full pages from the organization endpoint exercise pagination.
+	var repos []map[string]any
+	for i := range remotePageSize + 2 {
+		name := fmt.Sprintf("svc-%03d", i)
+		repos = append(repos, map[string]any{
+			"name":      name,
+			"ssh_url":   "git@github.com:acme/" + name + ".git",
+			"clone_url": "https://github.com/acme/" + name + ".git",
+			"archived":  i == 1,
+			"fork":      i == 2,
+		})
+	}
+
+	var authHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/orgs/acme/repos":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			start := min((page-1)*remotePageSize, len(repos))
+			end := min(start+remotePageSize, len(repos))
+			_ = json.NewEncoder(w).Encode(repos[start:end])
+		case "/orgs/octocat/repos":
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+		case "/users/octocat/repos":
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"name": "hello", "ssh_url": "git@github.com:octocat/hello.git"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	lister := &APIRemoteLister{HTTPClient: server.Client(), GitHubToken: "secret"}
+
+	listed, err := lister.ListRepos(context.Background(), config.ProjectSource{
+		Provider: config.ProviderGitHub,
+		Group:    "acme",
+		APIURL:   server.URL,
+	})
+	require.NoError(t, err)
+	assert.Len(t, listed, remotePageSize+2)
+	assert.Equal(t, "Bearer secret", authHeader)
+	assert.True(t, listed[1].Archived)
+	assert.True(t, listed[2].Fork)
+
+	// Users are listed when the owner is not an organization.
+	listed, err = lister.ListRepos(context.Background(), config.ProjectSource{
+		Provider: config.ProviderGitHub,
+		Group:    "octocat",
+		APIURL:   server.URL,
+	})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "hello", listed[0].Name)
+}
+
+func TestAPIRemoteLister_GitLab(t *testing.T) {
+	var gotToken, gotSubgroups string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The group path must reach the API URL-encoded as a single segment.
+		if r.URL.EscapedPath() != "/api/v4/groups/platform%2Fteam/projects" {
+			http.NotFound(w, r)
+			return
+		}
+		gotToken = r.Header.Get("PRIVATE-TOKEN")
+		gotSubgroups = r.URL.Query().Get("include_subgroups")
+
+		page := r.URL.Query().Get("page")
+		var batch []map[string]any
+		if page == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			batch = []map[string]any{{
+				"path":                "api",
+				"path_with_namespace": "platform/team/api",
+				"ssh_url_to_repo":     "git@gitlab.com:platform/team/api.git",
+				"http_url_to_repo":    "https://gitlab.com/platform/team/api.git",
+			}}
+		} else {
+			w.Header().Set("X-Next-Page", "")
+			batch = []map[string]any{{
+				"path":                "worker",
+				"path_with_namespace": "platform/team/jobs/worker",
+				"ssh_url_to_repo":     "git@gitlab.com:platform/team/jobs/worker.git",
+				"forked_from_project": map[string]any{"id": 1},
+			}}
+		}
+		_ = json.NewEncoder(w).Encode(batch)
+	}))
+	defer server.Close()
+
+	lister := &APIRemoteLister{HTTPClient: server.Client(), GitLabToken: "glpat"}
+	listed, err := lister.ListRepos(context.Background(), config.ProjectSource{
+		Provider:         config.ProviderGitLab,
+		Group:            "platform/team",
+		APIURL:           server.URL + "/api/v4",
+		IncludeSubgroups: true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "glpat", gotToken)
+	assert.Equal(t, "true", gotSubgroups)
+	require.Len(t, listed, 2)
+	assert.Equal(t, RemoteRepo{
+		Name:    "api",
+		Path:    "api",
+		SSHURL:  "git@gitlab.com:platform/team/api.git",
+		HTTPURL: "https://gitlab.com/platform/team/api.git",
+	}, listed[0])
+	assert.Equal(t, "jobs/worker", listed[1].Path)
+	assert.True(t, listed[1].Fork)
+
+	// Without a token or api_url, the configured GitLab host and token lookup are used, once.
+	lookups := 0
+	lister = &APIRemoteLister{
+		HTTPClient: server.Client(),
+		GitLabHost: server.URL,
+		ResolveGitLabToken: func() string {
+			lookups++
+			return "glpat-bitwarden"
+		},
+	}
+	src := config.ProjectSource{Provider: config.ProviderGitLab, Group: "platform/team"}
+	for range 2 {
+		_, err = lister.ListRepos(context.Background(), src)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "glpat-bitwarden", gotToken)
+	assert.Equal(t, 1, lookups)
+}
+
+func TestFilterRemoteRepos(t *testing.T) {
+	repos := []RemoteRepo{
+		{Name: "api", Path: "api"},
+		{Name: "old", Path: "old", Archived: true},
+		{Name: "fork", Path: "fork", Fork: true},
+		{Name: "sandbox-1", Path: "sandbox-1"},
+		{Name: "worker", Path: "jobs/worker"},
+	}
+
+	names := func(rs []RemoteRepo) []string {
+		var out []string
+		for _, r := range rs {
+			out = append(out, r.Name)
+		}
+		return out
+	}
+
+	src := config.ProjectSource{Exclude: []string{"sandbox-*", "jobs/*"}}
+	assert.Equal(t, []string{"api"}, names(filterRemoteRepos(repos, src)))
+
+	src = config.ProjectSource{IncludeArchived: true, IncludeForks: true}
+	assert.Equal(t, []string{"api", "old", "fork", "sandbox-1", "worker"}, names(filterRemoteRepos(repos, src)))
+}
+
+type fakeRemoteLister struct {
+	repos []RemoteRepo
+	err   error
+}
+
+func (f *fakeRemoteLister) ListRepos(ctx context.Context, src config.ProjectSource) ([]RemoteRepo, error) {
+	return f.repos, f.err
+}
+
+func TestSetup_MirrorsSource(t *testing.T) {
+	ui.DisableProgress = true
+
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	tmpDir := t.TempDir()
+	projects := []config.Project{
+		{
+			Name:   "Acme",
+			Source: &config.ProjectSource{Provider: config.ProviderGitHub, Group: "acme"},
+			Repos:  []config.ProjectRepo{{URL: "git@github.com:acme/api.git"}},
+		},
+	}
+	lister := &fakeRemoteLister{repos: []RemoteRepo{
+		{Name: "api", Path: "api", SSHURL: "git@github.com:acme/api.git"},
+		{Name: "web", Path: "web", SSHURL: "git@github.com:acme/web.git"},
+		{Name: "legacy", Path: "legacy", SSHURL: "git@github.com:acme/legacy.git", Archived: true},
+	}}
+
+	var cloned []string
+	var saved []config.Project
+	opts := SetupOptions{
+		DevPath:      tmpDir,
+		Projects:     projects,
+		RemoteLister: lister,
+		RepoClient: &MockRepoClient{
+			CloneFunc: func(ctx context.Context, url, path string) error {
+				cloned = append(cloned, filepath.Base(path))
+				return nil
+			},
+		},
+		SaveProjects: func(p []config.Project) error {
+			saved = p
+			return nil
+		},
+	}
+
+	Setup(context.Background(), opts)
+
+	assert.Equal(t, []string{"api", "web"}, cloned)
+	require.Len(t, saved, 1)
+	assert.Equal(t, []config.ProjectRepo{
+		{URL: "git@github.com:acme/api.git"},
+		{URL: "git@github.com:acme/web.git"},
+	}, saved[0].Repos)
+	assert.NotNil(t, saved[0].Source)
+	assert.Len(t, projects[0].Repos, 1, "the caller's projects are not modified")
+	assert.Contains(t, buf.String(), "Found 1 new repositories in github acme for project Acme")
+
+	// Dry runs list the new repositories without saving them.
+	saved = nil
+	opts.DryRun = true
+	Setup(context.Background(), opts)
+	assert.Nil(t, saved)
+	assert.Contains(t, buf.String(), "[DRY RUN] Would clone git@github.com:acme/web.git")
+}
@@ -11,8 +11,18 @@ type RepoClient interface {
 	Clone(ctx context.Context, url, path string) error
 	IsDirty(ctx context.Context, repoPath string) (bool, error)
 	PullLatestCode(ctx context.Context, repoPath string) error
+	PullWithAutostash(ctx context.Context, repoPath string) error
+	PullWithStrategy(ctx context.Context, repoPath, strategy string, autostash bool) error
+	SwitchToBranch(ctx context.Context, repoPath, branch string) (bool, error)
 	FetchAllPrune(ctx context.Context, repoPath string) error
 	FetchWithOptions(ctx context.Context, repoPath string, force bool) error
+	GetDetailedStatus(ctx context.Context, repoPath string) (repo.StatusInfo, error)
+	RefreshDetailedStatus(ctx context.Context, repoPath string) (repo.StatusInfo, error)
+	GetOriginURL(ctx context.Context, repoPath string) (string, error)
+	ListWorktrees(ctx context.Context, repoPath string) ([]repo.Worktree, error)
+	AddWorktree(ctx context.Context, repoPath, worktreePath, branch string) error
+	RemoveWorktree(ctx context.Context, repoPath, worktreePath string, force bool) error
+	IntegrateUpstream(ctx context.Context, repoPath, strategy string) error
 }
 
 // defaultRepoClient provides the standard implementation using internal/repo.
@@ -30,6 +40,22 @@ func (d *defaultRepoClient) PullLatestCode(ctx context.Context, repoPath string)
 	return repo.PullLatestCode(ctx, repoPath)
 }
 
+func (d *defaultRepoClient) PullWithAutostash(ctx context.Context, repoPath string) error {
+	return repo.PullWithAutostash(ctx, repoPath)
+}
+
+func (d *defaultRepoClient) PullWithStrategy(
+	ctx context.Context,
+	repoPath, strategy string,
+	autostash bool,
+) error {
+	return repo.PullWithStrategy(ctx, repoPath, strategy, autostash)
+}
+
+func (d *defaultRepoClient) SwitchToBranch(ctx context.Context, repoPath, branch string) (bool, error) {
+	return repo.SwitchToBranch(ctx, repoPath, branch)
+}
+
 func (d *defaultRepoClient) FetchAllPrune(ctx context.Context, repoPath string) error {
 	return repo.FetchAllPrune(ctx, repoPath)
 }
@@ -40,3 +66,31 @@ func (d *defaultRepoClient) FetchWithOptions(ctx context.Context, repoPath strin
 	}
 	return repo.FetchAllPruneWithPrompt(ctx, repoPath)
 }
+
+func (d *defaultRepoClient) GetDetailedStatus(ctx context.Context, repoPath string) (repo.StatusInfo, error) {
+	return repo.GetDetailedStatus(ctx, repoPath)
+}
+
+func (d *defaultRepoClient) RefreshDetailedStatus(ctx context.Context, repoPath string) (repo.StatusInfo, error) {
+	return repo.RefreshDetailedStatus(ctx, repoPath)
+}
+
+func (d *defaultRepoClient) GetOriginURL(ctx context.Context, repoPath string) (string, error) {
+	return repo.GetOriginURL(ctx, repoPath)
+}
+
+func (d *defaultRepoClient) ListWorktrees(ctx context.Context, repoPath string) ([]repo.Worktree, error) {
+	return repo.ListWorktrees(ctx, repoPath)
+}
+
+func (d *defaultRepoClient) AddWorktree(ctx context.Context, repoPath, worktreePath, branch string) error {
+	return repo.AddWorktree(ctx, repoPath, worktreePath, branch)
+}
+
+func (d *defaultRepoClient) RemoveWorktree(ctx context.Context, repoPath, worktreePath string, force bool) error {
+	return repo.RemoveWorktree(ctx, repoPath, worktreePath, force)
+}
+
+func (d *defaultRepoClient) IntegrateUpstream(ctx context.Context, repoPath, strategy string) error {
+	return repo.IntegrateUpstream(ctx, repoPath, strategy)
+}
@@ -14,9 +14,15 @@ type SetupOptions struct {
 	DryRun        bool
 	IsVerbose     bool
 	ProjectFilter string
+	Tags          []string // Only set up repositories carrying at least one of these tags
 	DevPath       string
 	Projects      []config.Project
 	RepoClient    RepoClient
+	// RemoteLister enumerates repositories for projects with a source; defaults to the REST API lister.
+	RemoteLister RemoteLister
+	// SaveProjects persists projects after repositories are added from a source. When nil,
+	// mirrored repositories are cloned but not recorded in the configuration.
+	SaveProjects func([]config.Project) error
 }
 
 // SetupStats tracks the results of the setup operation.
@@ -49,6 +55,7 @@ func Setup(ctx context.Context, opts SetupOptions) {
 	if len(projects) == 0 {
 		return
 	}
+	projects = mirrorSources(ctx, opts, projects)
 
 	stats := &SetupStats{}
 	for _, p := range projects {
@@ -87,14 +94,15 @@ func setupProject(ctx context.Context, p config.Project, devPath string, opts Se
 		log.Verbose(opts.IsVerbose, "  Project directory ready: %s", projectPath)
 	}
 
-	for _, projectRepo := range p.Repos {
-		setupRepo(ctx, projectRepo, projectPath, opts, stats)
+	for _, target := range selectRepos(p, opts.Tags) {
+		setupRepo(ctx, target.Repo, target.Policy, projectPath, opts, stats)
 	}
 }
 
 func setupRepo(
 	ctx context.Context,
 	projectRepo config.ProjectRepo,
+	policy config.RepoPolicy,
 	projectPath string,
 	opts SetupOptions,
 	stats *SetupStats,
@@ -118,7 +126,7 @@ func setupRepo(
 	}
 
 	if opts.DryRun {
-		log.Info("  [DRY RUN] Would clone %s to %s", projectRepo.URL, fullRepoPath)
+		log.Info("  [DRY RUN] Would clone %s to %s%s", projectRepo.URL, fullRepoPath, describePolicy(policy))
 		stats.ClonedCount++
 		return
 	}
@@ -134,6 +142,15 @@ func setupRepo(
 
 	log.Success("  Cloned %s", repoPath)
 	stats.ClonedCount++
+
+	// A fresh clone is already on the default branch, so only a pinned branch needs a checkout.
+	if policy.Branch != "" {
+		if _, err := opts.RepoClient.SwitchToBranch(ctx, fullRepoPath, policy.Branch); err != nil {
+			log.Warn("  Cloned %s but could not check out %s: %s", repoPath, policy.Branch, err)
+		} else {
+			log.Verbose(opts.IsVerbose, "  Checked out %s in %s", policy.Branch, repoPath)
+		}
+	}
 }
 
 func printSummary(stats *SetupStats, dryRun bool) {
@@ -111,3 +111,47 @@ func TestSetup(t *testing.T) {
 		})
 	}
 }
+
+func TestSetup_PinnedBranchAndTags(t *testing.T) {
+	ui.DisableProgress = true
+
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	tmpDir := t.TempDir()
+	projects := []config.Project{
+		{
+			Name:   "Platform",
+			Branch: "develop",
+			Repos: []config.ProjectRepo{
+				{URL: "git@github.com:org/api.git", Tags: []string{"backend"}},
+				{URL: "git@github.com:org/web.git", Tags: []string{"frontend"}},
+			},
+		},
+	}
+
+	var cloned []string
+	switched := make(map[string]string)
+	mockRepoClient := &MockRepoClient{
+		CloneFunc: func(ctx context.Context, url, path string) error {
+			cloned = append(cloned, filepath.Base(path))
+			return nil
+		},
+		SwitchToBranchFunc: func(ctx context.Context, path, branch string) (bool, error) {
+			switched[filepath.Base(path)] = branch
+			return true, nil
+		},
+	}
+
+	Setup(context.Background(), SetupOptions{
+		Tags:       []string{"backend"},
+		DevPath:    tmpDir,
+		Projects:   projects,
+		RepoClient: mockRepoClient,
+	})
+
+	assert.Equal(t, []string{"api"}, cloned)
+	assert.Equal(t, map[string]string{"api": "develop"}, switched)
+	assert.Contains(t, buf.String(), "Total repositories: 1")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/go-git/go-git/v5"
@@ -18,12 +19,14 @@ import (
 
 // SyncOptions holds the configuration for syncing projects.
 type SyncOptions struct {
-	DryRun        bool
-	IsVerbose     bool
-	ProjectFilter string
-	DevPath       string
-	Projects      []config.Project
-	RepoClient    RepoClient
+	DryRun         bool
+	IsVerbose      bool
+	AutostashDirty bool // Stash uncommitted work, pull, and re-apply instead of skipping dirty repos
+	ProjectFilter  string
+	Tags           []string // Only sync repositories carrying at least one of these tags
+	DevPath        string
+	Projects       []config.Project
+	RepoClient     RepoClient
 }
 
 // Sync fetches and pulls all repositories in configured projects.
@@ -51,7 +54,11 @@ func Sync(ctx context.Context, opts SyncOptions) {
 
 	var mu sync.Mutex
 	var fetchSuccess, fetchFailed, pullSuccess, pullFailed, skippedCount, dirtyCount int
-	var fetchFailedRepos, pullFailedRepos, skippedRepos, dirtyRepos []string
+	var fetchFailedRepos, pullFailedRepos, skippedRepos, policySkippedRepos, dirtyRepos []string
+	var switchSkippedRepos []string
+	var worktreeUpdated int
+	var worktreeFailedRepos, worktreeDirtyRepos []string
+	stashConflicts := make(map[string]*repo.StashConflictError)
 
 	multi, err := ui.NewMultiSpinner()
 	if err != nil {
@@ -69,11 +76,19 @@ func Sync(ctx context.Context, opts SyncOptions) {
 	for _, project := range projects {
 		projectPath := filepath.Join(opts.DevPath, project.Name)
 
-		for _, r := range project.Repos {
-			repoObj := r // capture loop variable
+		for _, target := range selectRepos(project, opts.Tags) {
+			repoObj, policy := target.Repo, target.Policy
 			eg.Go(func() error {
 				repoPath, err := repoObj.GetEffectivePath()
+				if err == nil {
+					err = policy.Validate()
+				}
 				if err != nil {
+					if repoPath == "" {
+						repoPath = repoObj.URL
+					}
+					spinner := multi.AddSpinner(fmt.Sprintf("Syncing %s...", repoPath))
+					spinner.Fail(fmt.Sprintf("Invalid configuration for %s: %s", repoPath, err))
 					mu.Lock()
 					fetchFailed++
 					pullFailed++
@@ -85,6 +100,18 @@ func Sync(ctx context.Context, opts SyncOptions) {
 
 				fullRepoPath := filepath.Join(projectPath, repoPath)
 
+				if policy.SkipSync {
+					if opts.IsVerbose {
+						spinner := multi.AddSpinner(fmt.Sprintf("Skipping %s (skip_sync policy)", repoPath))
+						spinner.Info()
+					}
+					mu.Lock()
+					skippedCount++
+					policySkippedRepos = append(policySkippedRepos, repoPath)
+					mu.Unlock()
+					return nil
+				}
+
 				// Check if repo exists
 				if !repo.IsCloned(fullRepoPath) {
 					if opts.IsVerbose {
@@ -99,7 +126,9 @@ func Sync(ctx context.Context, opts SyncOptions) {
 				}
 
 				if opts.DryRun {
-					spinner := multi.AddSpinner(fmt.Sprintf("[DRY RUN] Would sync: %s", repoPath))
+					spinner := multi.AddSpinner(
+						fmt.Sprintf("[DRY RUN] Would sync: %s%s", repoPath, describePolicy(policy)),
+					)
 					spinner.Success()
 					mu.Lock()
 					fetchSuccess++
@@ -107,6 +136,10 @@ func Sync(ctx context.Context, opts SyncOptions) {
 					mu.Lock()
 					pullSuccess++
 					mu.Unlock()
+					wt := syncWorktrees(egCtx, opts, multi, fullRepoPath, repoPath, policy)
+					mu.Lock()
+					worktreeUpdated += wt.Updated
+					mu.Unlock()
 					return nil
 				}
 
@@ -131,6 +164,16 @@ func Sync(ctx context.Context, opts SyncOptions) {
 				fetchSuccess++
 				mu.Unlock()
 
+				// Linked worktrees share this fetch; update them once the main clone is done.
+				defer func() {
+					wt := syncWorktrees(egCtx, opts, multi, fullRepoPath, repoPath, policy)
+					mu.Lock()
+					worktreeUpdated += wt.Updated
+					worktreeFailedRepos = append(worktreeFailedRepos, wt.Failed...)
+					worktreeDirtyRepos = append(worktreeDirtyRepos, wt.Dirty...)
+					mu.Unlock()
+				}()
+
 				// Check for uncommitted changes before pull
 				spinner.UpdateText(fmt.Sprintf("Checking %s...", repoPath))
 				isDirty, err := opts.RepoClient.IsDirty(egCtx, fullRepoPath)
@@ -143,7 +186,7 @@ func Sync(ctx context.Context, opts SyncOptions) {
 					return nil
 				}
 
-				if isDirty {
+				if isDirty && !opts.AutostashDirty {
 					spinner.Warning(fmt.Sprintf("Skipped pull for %s (has uncommitted changes)", repoPath))
 					mu.Lock()
 					dirtyCount++
@@ -152,11 +195,48 @@ func Sync(ctx context.Context, opts SyncOptions) {
 					return nil
 				}
 
+				// Move to the branch required by the sync policy before pulling. SwitchToBranch refuses
+				// uncommitted changes, so an autostashed repository is pulled on its current branch.
+				stayed := isDirty && wantsBranchSwitch(policy)
+				if stayed {
+					mu.Lock()
+					switchSkippedRepos = append(switchSkippedRepos, repoPath)
+					mu.Unlock()
+				} else if wantsBranchSwitch(policy) {
+					spinner.UpdateText(fmt.Sprintf("Switching branch for %s...", repoPath))
+					if _, err := switchForPolicy(egCtx, opts.RepoClient, fullRepoPath, policy); err != nil {
+						spinner.Fail(fmt.Sprintf("Branch switch failed for %s: %s", repoPath, err))
+						mu.Lock()
+						pullFailed++
+						pullFailedRepos = append(pullFailedRepos, repoPath)
+						mu.Unlock()
+						return nil
+					}
+				}
+
 				// Pull
-				spinner.UpdateText(fmt.Sprintf("Pulling %s...", repoPath))
-				if err := opts.RepoClient.PullLatestCode(egCtx, fullRepoPath); err != nil {
+				if isDirty {
+					spinner.UpdateText(fmt.Sprintf("Stashing and pulling %s...", repoPath))
+				} else {
+					spinner.UpdateText(fmt.Sprintf("Pulling %s...", repoPath))
+				}
+				if err := pullForPolicy(egCtx, opts.RepoClient, fullRepoPath, policy, isDirty); err != nil {
+					var conflictErr *repo.StashConflictError
+					if errors.As(err, &conflictErr) {
+						spinner.Fail(fmt.Sprintf("Pulled %s but stashed changes conflict", repoPath))
+						mu.Lock()
+						pullFailed++
+						pullFailedRepos = append(pullFailedRepos, repoPath)
+						stashConflicts[repoPath] = conflictErr
+						mu.Unlock()
+						return nil
+					}
 					if errors.Is(err, git.NoErrAlreadyUpToDate) {
-						spinner.Info(fmt.Sprintf("Synced %s (already up to date)", repoPath))
+						if stayed {
+							spinner.Warning(fmt.Sprintf("Synced %s (up to date, branch not switched)", repoPath))
+						} else {
+							spinner.Info(fmt.Sprintf("Synced %s (already up to date)", repoPath))
+						}
 						mu.Lock()
 						pullSuccess++
 						mu.Unlock()
@@ -170,7 +250,11 @@ func Sync(ctx context.Context, opts SyncOptions) {
 					return nil
 				}
 
-				spinner.Success(fmt.Sprintf("Synced %s", repoPath))
+				if stayed {
+					spinner.Warning(fmt.Sprintf("Synced %s on its current branch (has uncommitted changes)", repoPath))
+				} else {
+					spinner.Success(fmt.Sprintf("Synced %s", repoPath))
+				}
 				mu.Lock()
 				pullSuccess++
 				mu.Unlock()
@@ -192,6 +276,14 @@ func Sync(ctx context.Context, opts SyncOptions) {
 		dirtyCount,
 		skippedCount,
 	)
+	if worktreeUpdated+len(worktreeFailedRepos)+len(worktreeDirtyRepos) > 0 {
+		log.Info(
+			"  Worktrees: %d successful, %d failed, %d dirty",
+			worktreeUpdated,
+			len(worktreeFailedRepos),
+			len(worktreeDirtyRepos),
+		)
+	}
 
 	if len(dirtyRepos) > 0 {
 		log.Warn("Dirty repositories (skipped pull, require manual commit/stash):")
@@ -199,13 +291,29 @@ func Sync(ctx context.Context, opts SyncOptions) {
 			log.Warn("  - %s", r)
 		}
 	}
+	if len(worktreeDirtyRepos) > 0 {
+		sort.Strings(worktreeDirtyRepos)
+		log.Warn("Dirty worktrees (skipped update):")
+		for _, r := range worktreeDirtyRepos {
+			log.Warn("  - %s", r)
+		}
+	}
+	if len(switchSkippedRepos) > 0 {
+		sort.Strings(switchSkippedRepos)
+		log.Warn("Branch switch skipped (uncommitted changes, pulled the current branch instead):")
+		for _, r := range switchSkippedRepos {
+			log.Warn("  - %s", r)
+		}
+	}
+	repo.PrintStashConflicts(stashConflicts)
 	if len(skippedRepos) > 0 {
 		log.Warn("Skipped repositories (not cloned):")
 		for _, r := range skippedRepos {
 			log.Warn("  - %s", r)
 		}
 	}
-	if len(fetchFailedRepos) > 0 || len(pullFailedRepos) > 0 {
+	printPolicySkipped(policySkippedRepos)
+	if len(fetchFailedRepos) > 0 || len(pullFailedRepos) > 0 || len(worktreeFailedRepos) > 0 {
 		log.Error("Failed repositories (require manual resolution):")
 
 		// We'll build a unique list to avoid printing duplicates
@@ -216,6 +324,9 @@ func Sync(ctx context.Context, opts SyncOptions) {
 		for _, r := range pullFailedRepos {
 			failedSet[r] = true
 		}
+		for _, r := range worktreeFailedRepos {
+			failedSet[r] = true
+		}
 
 		for r := range failedSet {
 			log.Error("  - %s", r)
@@ -6,12 +6,14 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/eng618/eng/internal/config"
 	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
 	"github.com/eng618/eng/internal/ui"
 )
 
@@ -121,3 +123,218 @@ func TestSync(t *testing.T) {
 		})
 	}
 }
+
+func TestSync_AutostashDirty(t *testing.T) {
+	ui.DisableProgress = true
+
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	tmpDir := t.TempDir()
+	projects := []config.Project{
+		{
+			Name: "Stash",
+			Repos: []config.ProjectRepo{
+				{URL: "git@github.com:org/scratch.git"},  // dirty, re-applies cleanly
+				{URL: "git@github.com:org/conflict.git"}, // dirty, re-apply conflicts
+			},
+		},
+	}
+	os.MkdirAll(filepath.Join(tmpDir, "Stash", "scratch", ".git"), 0o755)
+	os.MkdirAll(filepath.Join(tmpDir, "Stash", "conflict", ".git"), 0o755)
+
+	var autostashed []string
+	var mu sync.Mutex
+	mockRepoClient := &MockRepoClient{
+		IsDirtyFunc: func(ctx context.Context, path string) (bool, error) {
+			return true, nil
+		},
+		PullLatestCodeFunc: func(ctx context.Context, path string) error {
+			t.Errorf("PullLatestCode should not be called for dirty repo %s", path)
+			return nil
+		},
+		PullWithAutostashFunc: func(ctx context.Context, path string) error {
+			mu.Lock()
+			autostashed = append(autostashed, filepath.Base(path))
+			mu.Unlock()
+			if filepath.Base(path) == "conflict" {
+				return &repo.StashConflictError{
+					StashName:       "eng-autostash main 2026-01-01T00:00:00",
+					ConflictedFiles: []string{"go.mod", "main.go"},
+				}
+			}
+			return nil
+		},
+	}
+
+	Sync(context.Background(), SyncOptions{
+		AutostashDirty: true,
+		DevPath:        tmpDir,
+		Projects:       projects,
+		RepoClient:     mockRepoClient,
+	})
+
+	assert.ElementsMatch(t, []string{"scratch", "conflict"}, autostashed)
+
+	out := buf.String()
+	assert.Contains(t, out, "1 successful, 1 failed, 0 dirty")
+	assert.Contains(t, out, "Autostash conflicts")
+	assert.Contains(t, out, `conflict (changes kept in stash "eng-autostash main 2026-01-01T00:00:00")`)
+	assert.Contains(t, out, "conflict: go.mod")
+	assert.Contains(t, out, "conflict: main.go")
+}
+
+func TestSync_AutostashDirtyKeepsBranch(t *testing.T) {
+	ui.DisableProgress = true
+
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	tmpDir := t.TempDir()
+	projects := []config.Project{
+		{
+			Name:   "Platform",
+			Branch: "develop",
+			Repos:  []config.ProjectRepo{{URL: "git@github.com:org/api.git"}},
+		},
+	}
+	os.MkdirAll(filepath.Join(tmpDir, "Platform", "api", ".git"), 0o755)
+
+	autostashed := false
+	mockRepoClient := &MockRepoClient{
+		IsDirtyFunc: func(ctx context.Context, path string) (bool, error) {
+			return true, nil
+		},
+		SwitchToBranchFunc: func(ctx context.Context, path, branch string) (bool, error) {
+			t.Errorf("SwitchToBranch should not be called for dirty repo %s", path)
+			return false, nil
+		},
+		PullWithAutostashFunc: func(ctx context.Context, path string) error {
+			autostashed = true
+			return nil
+		},
+	}
+
+	Sync(context.Background(), SyncOptions{
+		AutostashDirty: true,
+		DevPath:        tmpDir,
+		Projects:       projects,
+		RepoClient:     mockRepoClient,
+	})
+
+	assert.True(t, autostashed)
+	out := buf.String()
+	assert.Contains(t, out, "1 successful, 0 failed, 0 dirty")
+	assert.Contains(t, out, "Branch switch skipped (uncommitted changes, pulled the current branch instead):")
+	assert.Contains(t, out, "  - api")
+}
+
+func TestSync_Policies(t *testing.T) {
+	ui.DisableProgress = true
+
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	tmpDir := t.TempDir()
+	skip := true
+	projects := []config.Project{
+		{
+			Name:         "Platform",
+			Branch:       "develop",
+			PullStrategy: "ff-only",
+			Tags:         []string{"backend"},
+			Repos: []config.ProjectRepo{
+				// api inherits develop and ff-only, web overrides both, and legacy is never synced.
+				{URL: "git@github.com:org/api.git"},
+				{URL: "git@github.com:org/web.git", Branch: "main", PullStrategy: "merge"},
+				{URL: "git@github.com:org/legacy.git", SkipSync: &skip},
+			},
+		},
+		{
+			Name: "Tools",
+			Repos: []config.ProjectRepo{
+				{URL: "git@github.com:org/cli.git"}, // no matching tag
+			},
+		},
+	}
+	for _, dir := range []string{"Platform/api", "Platform/web", "Platform/legacy", "Tools/cli"} {
+		os.MkdirAll(filepath.Join(tmpDir, dir, ".git"), 0o755)
+	}
+
+	var mu sync.Mutex
+	switched := make(map[string]string)
+	strategies := make(map[string]string)
+	var fetched []string
+	mockRepoClient := &MockRepoClient{
+		FetchAllPruneFunc: func(ctx context.Context, path string) error {
+			mu.Lock()
+			fetched = append(fetched, filepath.Base(path))
+			mu.Unlock()
+			return nil
+		},
+		SwitchToBranchFunc: func(ctx context.Context, path, branch string) (bool, error) {
+			mu.Lock()
+			switched[filepath.Base(path)] = branch
+			mu.Unlock()
+			return true, nil
+		},
+		PullWithStrategyFunc: func(ctx context.Context, path, strategy string, autostash bool) error {
+			mu.Lock()
+			strategies[filepath.Base(path)] = strategy
+			mu.Unlock()
+			return nil
+		},
+		PullLatestCodeFunc: func(ctx context.Context, path string) error {
+			t.Errorf("PullLatestCode should not be called when a pull strategy is set (%s)", path)
+			return nil
+		},
+	}
+
+	Sync(context.Background(), SyncOptions{
+		Tags:       []string{"backend"},
+		DevPath:    tmpDir,
+		Projects:   projects,
+		RepoClient: mockRepoClient,
+	})
+
+	assert.ElementsMatch(t, []string{"api", "web"}, fetched)
+	assert.Equal(t, map[string]string{"api": "develop", "web": "main"}, switched)
+	assert.Equal(t, map[string]string{"api": "ff-only", "web": "merge"}, strategies)
+
+	out := buf.String()
+	assert.Contains(t, out, "2 successful, 0 failed, 0 dirty, 1 skipped")
+	assert.Contains(t, out, "Skipped repositories (skip_sync policy):")
+	assert.Contains(t, out, "  - legacy")
+}
+
+func TestSync_InvalidPullStrategy(t *testing.T) {
+	ui.DisableProgress = true
+
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	tmpDir := t.TempDir()
+	os.MkdirAll(filepath.Join(tmpDir, "Platform", "api", ".git"), 0o755)
+
+	Sync(context.Background(), SyncOptions{
+		DevPath: tmpDir,
+		Projects: []config.Project{{
+			Name:  "Platform",
+			Repos: []config.ProjectRepo{{URL: "git@github.com:org/api.git", PullStrategy: "squash"}},
+		}},
+		RepoClient: &MockRepoClient{
+			FetchAllPruneFunc: func(ctx context.Context, path string) error {
+				t.Errorf("FetchAllPrune should not run for an invalid policy (%s)", path)
+				return nil
+			},
+		},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "Fetch: 0 successful, 1 failed")
+	assert.Contains(t, out, "  - api")
+}
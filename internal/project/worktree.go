@@ -0,0 +1,298 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui"
+)
+
+// WorktreeOptions holds the configuration for managing worktrees of project repositories.
+type WorktreeOptions struct {
+	DryRun        bool
+	IsVerbose     bool
+	ProjectFilter string
+	DevPath       string
+	Projects      []config.Project
+	RepoClient    RepoClient
+}
+
+// RepoWorktrees lists the linked worktrees of one cloned project repository.
+type RepoWorktrees struct {
+	Project   string          `json:"project" yaml:"project"`
+	Repo      string          `json:"repo" yaml:"repo"`
+	Path      string          `json:"path" yaml:"path"`
+	Worktrees []repo.Worktree `json:"worktrees" yaml:"worktrees"`
+}
+
+// WorktreeDirName returns the directory name for a worktree of repoName on branch:
+// "<repo>@<branch>", with slashes in the branch replaced by dashes.
+func WorktreeDirName(repoName, branch string) string {
+	return repoName + "@" + strings.ReplaceAll(branch, "/", "-")
+}
+
+// worktreeRepo is a cloned project repository resolved from a command-line name.
+type worktreeRepo struct {
+	Project  string
+	RepoPath string // Effective path relative to the project folder
+	FullPath string
+}
+
+// resolveWorktreeRepo finds the cloned repository called name, matching either its
+// effective path or its final path element, within the filtered projects.
+func resolveWorktreeRepo(opts WorktreeOptions, name string) (worktreeRepo, error) {
+	projects := filterProjects(opts.Projects, opts.ProjectFilter)
+	if len(projects) == 0 {
+		return worktreeRepo{}, fmt.Errorf("no matching projects configured")
+	}
+
+	var matches []worktreeRepo
+	for _, p := range projects {
+		for _, r := range p.Repos {
+			repoPath, err := r.GetEffectivePath()
+			if err != nil {
+				continue
+			}
+			if repoPath != name && filepath.Base(repoPath) != name {
+				continue
+			}
+			matches = append(matches, worktreeRepo{
+				Project:  p.Name,
+				RepoPath: repoPath,
+				FullPath: filepath.Join(opts.DevPath, p.Name, repoPath),
+			})
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return worktreeRepo{}, fmt.Errorf("repository %s not found in configured projects", name)
+	case 1:
+	default:
+		names := make([]string, 0, len(matches))
+		for _, m := range matches {
+			names = append(names, m.Project)
+		}
+		return worktreeRepo{}, fmt.Errorf(
+			"repository %s is configured in several projects (%s); use --project to choose",
+			name,
+			strings.Join(names, ", "),
+		)
+	}
+
+	if !repo.IsCloned(matches[0].FullPath) {
+		return worktreeRepo{}, fmt.Errorf("%s is not cloned; run 'eng project setup' first", matches[0].RepoPath)
+	}
+	return matches[0], nil
+}
+
+// worktreePathFor returns the sibling directory used for a worktree of target on branch.
+func worktreePathFor(target worktreeRepo, branch string) string {
+	return filepath.Join(filepath.Dir(target.FullPath), WorktreeDirName(filepath.Base(target.RepoPath), branch))
+}
+
+// AddWorktree creates a worktree of the named repository for branch next to its clone in
+// the project folder and returns the worktree path.
+func AddWorktree(ctx context.Context, opts WorktreeOptions, repoName, branch string) (string, error) {
+	if opts.RepoClient == nil {
+		opts.RepoClient = &defaultRepoClient{}
+	}
+
+	target, err := resolveWorktreeRepo(opts, repoName)
+	if err != nil {
+		return "", err
+	}
+	path := worktreePathFor(target, branch)
+
+	if opts.DryRun {
+		log.Info("[DRY RUN] Would add worktree for %s on %s at %s", target.RepoPath, branch, path)
+		return path, nil
+	}
+	if err := opts.RepoClient.AddWorktree(ctx, target.FullPath, path, branch); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// RemoveWorktree removes the worktree of the named repository checked out on branch and
+// returns its path. Worktrees with uncommitted changes are only removed when force is set.
+func RemoveWorktree(ctx context.Context, opts WorktreeOptions, repoName, branch string, force bool) (string, error) {
+	if opts.RepoClient == nil {
+		opts.RepoClient = &defaultRepoClient{}
+	}
+
+	target, err := resolveWorktreeRepo(opts, repoName)
+	if err != nil {
+		return "", err
+	}
+
+	worktrees, err := opts.RepoClient.ListWorktrees(ctx, target.FullPath)
+	if err != nil {
+		return "", err
+	}
+
+	path := ""
+	for _, wt := range linkedWorktrees(worktrees) {
+		if wt.Branch == branch {
+			path = wt.Path
+			break
+		}
+	}
+	if path == "" {
+		return "", fmt.Errorf("no worktree of %s is checked out on %s", target.RepoPath, branch)
+	}
+
+	if opts.DryRun {
+		log.Info("[DRY RUN] Would remove worktree %s", path)
+		return path, nil
+	}
+	if err := opts.RepoClient.RemoveWorktree(ctx, target.FullPath, path, force); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ListWorktrees returns the linked worktrees of the named repository, or of every cloned
+// repository in the filtered projects when repoName is empty.
+func ListWorktrees(ctx context.Context, opts WorktreeOptions, repoName string) ([]RepoWorktrees, error) {
+	if opts.RepoClient == nil {
+		opts.RepoClient = &defaultRepoClient{}
+	}
+
+	var targets []worktreeRepo
+	if repoName != "" {
+		target, err := resolveWorktreeRepo(opts, repoName)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	} else {
+		for _, p := range filterProjects(opts.Projects, opts.ProjectFilter) {
+			for _, r := range p.Repos {
+				repoPath, err := r.GetEffectivePath()
+				if err != nil {
+					continue
+				}
+				fullPath := filepath.Join(opts.DevPath, p.Name, repoPath)
+				if repo.IsCloned(fullPath) {
+					targets = append(targets, worktreeRepo{Project: p.Name, RepoPath: repoPath, FullPath: fullPath})
+				}
+			}
+		}
+	}
+
+	var result []RepoWorktrees
+	for _, target := range targets {
+		worktrees, err := opts.RepoClient.ListWorktrees(ctx, target.FullPath)
+		if err != nil {
+			log.Verbose(opts.IsVerbose, "Failed to list worktrees of %s: %s", target.RepoPath, err)
+			continue
+		}
+		linked := linkedWorktrees(worktrees)
+		if len(linked) == 0 && repoName == "" {
+			continue
+		}
+		result = append(result, RepoWorktrees{
+			Project:   target.Project,
+			Repo:      target.RepoPath,
+			Path:      target.FullPath,
+			Worktrees: linked,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Project != result[j].Project {
+			return result[i].Project < result[j].Project
+		}
+		return result[i].Repo < result[j].Repo
+	})
+	return result, nil
+}
+
+// linkedWorktrees drops the main working tree, which git always lists first.
+func linkedWorktrees(worktrees []repo.Worktree) []repo.Worktree {
+	if len(worktrees) <= 1 {
+		return nil
+	}
+	return worktrees[1:]
+}
+
+// worktreeSyncResult collects the outcome of updating the worktrees of one repository.
+type worktreeSyncResult struct {
+	Updated int
+	Failed  []string
+	Dirty   []string
+}
+
+// syncWorktrees brings the linked worktrees of repoPath up to date with their upstream
+// branches. It relies on the fetch just performed in the main clone, so the remote is
+// contacted once per repository no matter how many worktrees it has. Worktrees with
+// uncommitted changes, without an upstream, or on a detached HEAD are left alone.
+func syncWorktrees(
+	ctx context.Context,
+	opts SyncOptions,
+	multi *ui.MultiSpinner,
+	fullRepoPath, repoPath string,
+	policy config.RepoPolicy,
+) worktreeSyncResult {
+	var result worktreeSyncResult
+
+	worktrees, err := opts.RepoClient.ListWorktrees(ctx, fullRepoPath)
+	if err != nil {
+		log.Verbose(opts.IsVerbose, "Failed to list worktrees of %s: %s", repoPath, err)
+		return result
+	}
+
+	for _, wt := range linkedWorktrees(worktrees) {
+		if wt.Bare || wt.Prunable || wt.Detached || wt.Branch == "" {
+			continue
+		}
+		label := filepath.Join(filepath.Dir(repoPath), filepath.Base(wt.Path))
+
+		if opts.DryRun {
+			multi.AddSpinner(fmt.Sprintf("[DRY RUN] Would update worktree: %s (%s)", label, wt.Branch)).Success()
+			result.Updated++
+			continue
+		}
+
+		spinner := multi.AddSpinner(fmt.Sprintf("Updating worktree %s...", label))
+		status, err := opts.RepoClient.RefreshDetailedStatus(ctx, wt.Path)
+		if err != nil {
+			spinner.Fail(fmt.Sprintf("Failed to check status for %s: %s", label, err))
+			result.Failed = append(result.Failed, label)
+			continue
+		}
+		if status.UnstagedCount+status.StagedCount+status.UntrackedCount+status.ConflictCount > 0 {
+			spinner.Warning(fmt.Sprintf("Skipped worktree %s (has uncommitted changes)", label))
+			result.Dirty = append(result.Dirty, label)
+			continue
+		}
+		if !status.HasUpstream {
+			spinner.Info(fmt.Sprintf("Skipped worktree %s (no upstream for %s)", label, wt.Branch))
+			continue
+		}
+
+		err = opts.RepoClient.IntegrateUpstream(ctx, wt.Path, policy.PullStrategy)
+		switch {
+		case errors.Is(err, git.NoErrAlreadyUpToDate):
+			spinner.Info(fmt.Sprintf("Synced worktree %s (already up to date)", label))
+			result.Updated++
+		case err != nil:
+			spinner.Fail(fmt.Sprintf("Update failed for worktree %s: %s", label, err))
+			result.Failed = append(result.Failed, label)
+		default:
+			spinner.Success(fmt.Sprintf("Synced worktree %s", label))
+			result.Updated++
+		}
+	}
+	return result
+}
@@ -0,0 +1,200 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/log"
+	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui"
+)
+
+func TestWorktreeDirName(t *testing.T) {
+	assert.Equal(t, "api@main", WorktreeDirName("api", "main"))
+	assert.Equal(t, "api@feature-login-form", WorktreeDirName("api", "feature/login/form"))
+}
+
+func TestAddAndRemoveWorktree(t *testing.T) {
+	tmpDir := t.TempDir()
+	projects := []config.Project{
+		{Name: "Alpha", Repos: []config.ProjectRepo{{URL: "git@github.com:org/api.git"}}},
+		{Name: "Beta", Repos: []config.ProjectRepo{
+			{URL: "git@github.com:other/api.git"},
+			{URL: "git@github.com:other/web.git", Path: "apps/web"},
+		}},
+	}
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "Alpha", "api", ".git"), 0o755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "Beta", "apps", "web", ".git"), 0o755))
+
+	var added, removed []string
+	var removeForce bool
+	mock := &MockRepoClient{
+		AddWorktreeFunc: func(ctx context.Context, repoPath, worktreePath, branch string) error {
+			added = append(added, repoPath+" -> "+worktreePath+" ("+branch+")")
+			return nil
+		},
+		ListWorktreesFunc: func(ctx context.Context, repoPath string) ([]repo.Worktree, error) {
+			return []repo.Worktree{
+				{Path: repoPath, Branch: "main"},
+				{Path: filepath.Join(filepath.Dir(repoPath), "web@feature-x"), Branch: "feature/x"},
+			}, nil
+		},
+		RemoveWorktreeFunc: func(ctx context.Context, repoPath, worktreePath string, force bool) error {
+			removed = append(removed, worktreePath)
+			removeForce = force
+			return nil
+		},
+	}
+	opts := WorktreeOptions{DevPath: tmpDir, Projects: projects, RepoClient: mock}
+
+	// "api" exists in two projects and must be disambiguated.
+	_, err := AddWorktree(context.Background(), opts, "api", "feature/x")
+	assert.ErrorContains(t, err, "several projects (Alpha, Beta)")
+
+	opts.ProjectFilter = "Alpha"
+	path, err := AddWorktree(context.Background(), opts, "api", "feature/x")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "Alpha", "api@feature-x"), path)
+	assert.Equal(t, []string{
+		filepath.Join(tmpDir, "Alpha", "api") + " -> " + path + " (feature/x)",
+	}, added)
+
+	// Repositories are matched by their full path or its last element, and must be cloned.
+	opts.ProjectFilter = "Beta"
+	path, err = AddWorktree(context.Background(), opts, "web", "spike")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "Beta", "apps", "web@spike"), path)
+	_, err = AddWorktree(context.Background(), opts, "api", "spike")
+	assert.ErrorContains(t, err, "not cloned")
+	_, err = AddWorktree(context.Background(), opts, "missing", "spike")
+	assert.ErrorContains(t, err, "not found")
+
+	path, err = RemoveWorktree(context.Background(), opts, "apps/web", "feature/x", true)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "Beta", "apps", "web@feature-x"), path)
+	assert.Equal(t, []string{path}, removed)
+	assert.True(t, removeForce)
+
+	_, err = RemoveWorktree(context.Background(), opts, "web", "unknown", false)
+	assert.ErrorContains(t, err, "no worktree of apps/web is checked out on unknown")
+
+	opts.DryRun = true
+	_, err = AddWorktree(context.Background(), opts, "web", "dry")
+	assert.NoError(t, err)
+	assert.Len(t, added, 2, "dry run must not create worktrees")
+}
+
+func TestListWorktrees(t *testing.T) {
+	tmpDir := t.TempDir()
+	projects := []config.Project{
+		{Name: "Alpha", Repos: []config.ProjectRepo{
+			{URL: "git@github.com:org/api.git"},
+			{URL: "git@github.com:org/web.git"},
+		}},
+	}
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "Alpha", "api", ".git"), 0o755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "Alpha", "web", ".git"), 0o755))
+
+	mock := &MockRepoClient{
+		ListWorktreesFunc: func(ctx context.Context, repoPath string) ([]repo.Worktree, error) {
+			worktrees := []repo.Worktree{{Path: repoPath, Branch: "main"}}
+			if filepath.Base(repoPath) == "api" {
+				worktrees = append(worktrees, repo.Worktree{Path: repoPath + "@hotfix", Branch: "hotfix"})
+			}
+			return worktrees, nil
+		},
+	}
+	opts := WorktreeOptions{DevPath: tmpDir, Projects: projects, RepoClient: mock}
+
+	all, err := ListWorktrees(context.Background(), opts, "")
+	assert.NoError(t, err)
+	if assert.Len(t, all, 1, "repositories without worktrees are omitted") {
+		assert.Equal(t, "api", all[0].Repo)
+		assert.Equal(t, "hotfix", all[0].Worktrees[0].Branch)
+	}
+
+	single, err := ListWorktrees(context.Background(), opts, "web")
+	assert.NoError(t, err)
+	if assert.Len(t, single, 1) {
+		assert.Empty(t, single[0].Worktrees)
+	}
+}
+
+func TestSync_UpdatesWorktreesAfterSingleFetch(t *testing.T) {
+	ui.DisableProgress = true
+
+	var buf bytes.Buffer
+	log.SetWriters(&buf, &buf)
+	defer log.ResetWriters()
+
+	tmpDir := t.TempDir()
+	projects := []config.Project{
+		{Name: "Alpha", PullStrategy: config.PullStrategyFFOnly, Repos: []config.ProjectRepo{
+			{URL: "git@github.com:org/api.git"},
+		}},
+	}
+	repoPath := filepath.Join(tmpDir, "Alpha", "api")
+	assert.NoError(t, os.MkdirAll(filepath.Join(repoPath, ".git"), 0o755))
+
+	var mu sync.Mutex
+	fetches := 0
+	integrated := map[string]string{}
+	mock := &MockRepoClient{
+		FetchAllPruneFunc: func(ctx context.Context, path string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			fetches++
+			return nil
+		},
+		ListWorktreesFunc: func(ctx context.Context, path string) ([]repo.Worktree, error) {
+			return []repo.Worktree{
+				{Path: path, Branch: "main"},
+				{Path: path + "@feature", Branch: "feature"},
+				{Path: path + "@wip", Branch: "wip"},
+				{Path: path + "@local", Branch: "local"},
+				{Path: path + "@broken", Branch: "broken"},
+				{Path: path + "@review", Detached: true},
+			}, nil
+		},
+		RefreshDetailedStatusFunc: func(ctx context.Context, path string) (repo.StatusInfo, error) {
+			switch filepath.Base(path) {
+			case "api@wip":
+				return repo.StatusInfo{Branch: "wip", HasUpstream: true, UnstagedCount: 1}, nil
+			case "api@local":
+				return repo.StatusInfo{Branch: "local"}, nil
+			}
+			return repo.StatusInfo{Branch: "x", HasUpstream: true}, nil
+		},
+		IntegrateUpstreamFunc: func(ctx context.Context, path, strategy string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			integrated[filepath.Base(path)] = strategy
+			if filepath.Base(path) == "api@broken" {
+				return errors.New("diverged")
+			}
+			return nil
+		},
+	}
+
+	Sync(context.Background(), SyncOptions{DevPath: tmpDir, Projects: projects, RepoClient: mock})
+
+	assert.Equal(t, 1, fetches, "worktrees must reuse the main clone's fetch")
+	assert.Equal(t, map[string]string{
+		"api@feature": config.PullStrategyFFOnly,
+		"api@broken":  config.PullStrategyFFOnly,
+	}, integrated)
+
+	out := buf.String()
+	assert.Contains(t, out, "Worktrees: 1 successful, 1 failed, 1 dirty")
+	assert.Contains(t, out, "Dirty worktrees (skipped update):")
+	assert.Contains(t, out, "  - api@wip")
+	assert.Contains(t, out, "  - api@broken")
+}
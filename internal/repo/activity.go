@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// AuthorActivity counts the commits one author made in a report window.
+type AuthorActivity struct {
+	Name    string `json:"name" yaml:"name"`
+	Email   string `json:"email" yaml:"email"`
+	Commits int    `json:"commits" yaml:"commits"`
+}
+
+// BranchActivity describes a local branch in a report window.
+type BranchActivity struct {
+	Name       string    `json:"name" yaml:"name"`
+	Commits    int       `json:"commits" yaml:"commits"`
+	LastCommit time.Time `json:"last_commit" yaml:"last_commit"`
+}
+
+// RepoActivity summarizes the commits reachable from a repository's local branches.
+type RepoActivity struct {
+	// Commits is the number of distinct commits made since the start of the window.
+	Commits int `json:"commits" yaml:"commits"`
+	// Authors lists who made those commits, most active first.
+	Authors []AuthorActivity `json:"authors,omitempty" yaml:"authors,omitempty"`
+	// Branches lists the branches that gained commits in the window, most active first.
+	// A commit reachable from several branches is counted once, on the default branch when
+	// it is reachable from it and otherwise on the first branch by name.
+	Branches []BranchActivity `json:"branches,omitempty" yaml:"branches,omitempty"`
+	// StaleBranches lists non-default branches whose tip is older than the stale cutoff.
+	StaleBranches []BranchActivity `json:"stale_branches,omitempty" yaml:"stale_branches,omitempty"`
+	// LastCommit is the newest commit on any local branch.
+	LastCommit time.Time `json:"last_commit" yaml:"last_commit"`
+}
+
+// CollectActivity walks every local branch of repoPath with go-git and summarizes the commits
+// made since the given time. Branches other than main/master whose last commit is older than
+// staleBefore are reported as stale; a zero staleBefore disables the check.
+func CollectActivity(ctx context.Context, repoPath string, since, staleBefore time.Time) (RepoActivity, error) {
+	var activity RepoActivity
+
+	r, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return activity, err
+	}
+
+	iter, err := r.Branches()
+	if err != nil {
+		return activity, err
+	}
+	var refs []*plumbing.Reference
+	if err := iter.ForEach(func(ref *plumbing.Reference) error {
+		refs = append(refs, ref)
+		return nil
+	}); err != nil {
+		return activity, err
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		ri, rj := defaultBranchRank(refs[i].Name().Short()), defaultBranchRank(refs[j].Name().Short())
+		if ri != rj {
+			return ri < rj
+		}
+		return refs[i].Name().Short() < refs[j].Name().Short()
+	})
+
+	seen := make(map[plumbing.Hash]bool)
+	authors := make(map[string]*AuthorActivity)
+
+	for _, ref := range refs {
+		if err := ctx.Err(); err != nil {
+			return activity, err
+		}
+
+		branch := BranchActivity{Name: ref.Name().Short()}
+		tip, err := r.CommitObject(ref.Hash())
+		if err != nil {
+			return activity, err
+		}
+		branch.LastCommit = tip.Committer.When
+		if branch.LastCommit.After(activity.LastCommit) {
+			activity.LastCommit = branch.LastCommit
+		}
+
+		commits, err := r.Log(&git.LogOptions{From: ref.Hash(), Order: git.LogOrderCommitterTime})
+		if err != nil {
+			return activity, err
+		}
+		err = commits.ForEach(func(c *object.Commit) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			// Commits arrive newest first, so the first one before the window ends the walk.
+			if c.Committer.When.Before(since) {
+				return storer.ErrStop
+			}
+			if seen[c.Hash] {
+				return nil
+			}
+			seen[c.Hash] = true
+			branch.Commits++
+			activity.Commits++
+
+			key := strings.ToLower(c.Author.Email)
+			if a, ok := authors[key]; ok {
+				a.Commits++
+			} else {
+				authors[key] = &AuthorActivity{Name: c.Author.Name, Email: c.Author.Email, Commits: 1}
+			}
+			return nil
+		})
+		commits.Close()
+		if err != nil && !errors.Is(err, storer.ErrStop) {
+			return activity, err
+		}
+
+		if branch.Commits > 0 {
+			activity.Branches = append(activity.Branches, branch)
+		}
+		if !staleBefore.IsZero() && defaultBranchRank(branch.Name) > 1 && branch.LastCommit.Before(staleBefore) {
+			activity.StaleBranches = append(activity.StaleBranches, branch)
+		}
+	}
+
+	for _, a := range authors {
+		activity.Authors = append(activity.Authors, *a)
+	}
+	sort.Slice(activity.Authors, func(i, j int) bool {
+		if activity.Authors[i].Commits != activity.Authors[j].Commits {
+			return activity.Authors[i].Commits > activity.Authors[j].Commits
+		}
+		return activity.Authors[i].Name < activity.Authors[j].Name
+	})
+	sort.SliceStable(activity.Branches, func(i, j int) bool {
+		return activity.Branches[i].Commits > activity.Branches[j].Commits
+	})
+	return activity, nil
+}
+
+// defaultBranchRank orders main and master ahead of every other branch.
+func defaultBranchRank(name string) int {
+	switch name {
+	case "main":
+		return 0
+	case "master":
+		return 1
+	default:
+		return 2
+	}
+}
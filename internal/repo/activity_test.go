@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCollectActivity(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	runGit := func(env []string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	commitAt := func(when time.Time, author, file string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(when.String()), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+		date := when.Format(time.RFC3339)
+		runGit(nil, "add", file)
+		runGit([]string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date},
+			"-c", "user.name="+author, "-c", "user.email="+author+"@example.com",
+			"commit", "-m", "Update "+file)
+	}
+
+	runGit(nil, "init")
+	runGit(nil, "config", "commit.gpgsign", "false")
+	runGit(nil, "checkout", "-b", "main")
+	commitAt(now.AddDate(0, 0, -60), "ada", "old.txt")
+	runGit(nil, "branch", "abandoned")
+	commitAt(now.AddDate(0, 0, -3), "ada", "a.txt")
+	commitAt(now.AddDate(0, 0, -2), "bob", "b.txt")
+	runGit(nil, "checkout", "-b", "feature")
+	commitAt(now.AddDate(0, 0, -1), "bob", "c.txt")
+	runGit(nil, "checkout", "main")
+
+	activity, err := CollectActivity(context.Background(), dir, now.AddDate(0, 0, -7), now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("CollectActivity failed: %v", err)
+	}
+
+	if activity.Commits != 3 {
+		t.Errorf("Expected 3 commits in the window, got %d", activity.Commits)
+	}
+	if len(activity.Authors) != 2 || activity.Authors[0].Name != "bob" || activity.Authors[0].Commits != 2 {
+		t.Errorf("Expected bob to lead with 2 commits, got %+v", activity.Authors)
+	}
+	if len(activity.Branches) != 2 || activity.Branches[0].Name != "main" || activity.Branches[0].Commits != 2 ||
+		activity.Branches[1].Name != "feature" || activity.Branches[1].Commits != 1 {
+		t.Errorf("Expected main with 2 and feature with 1 commit, got %+v", activity.Branches)
+	}
+	if len(activity.StaleBranches) != 1 || activity.StaleBranches[0].Name != "abandoned" {
+		t.Errorf("Expected abandoned to be stale, got %+v", activity.StaleBranches)
+	}
+	if now.Sub(activity.LastCommit) > 25*time.Hour {
+		t.Errorf("Expected the last commit to be a day old, got %s", activity.LastCommit)
+	}
+}
@@ -0,0 +1,172 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/eng618/eng/internal/log"
+)
+
+// autostashPrefix marks stashes created by PullWithAutostash so they are easy to find in `git stash list`.
+const autostashPrefix = "eng-autostash"
+
+// StashConflictError is returned when autostashed work cannot be re-applied cleanly after a pull.
+// The stash is left in place under StashName so no work is lost.
+type StashConflictError struct {
+	StashName       string
+	ConflictedFiles []string
+	Output          string
+}
+
+func (e *StashConflictError) Error() string {
+	if len(e.ConflictedFiles) == 0 {
+		return fmt.Sprintf("re-applying stash %q failed; changes kept in stash", e.StashName)
+	}
+	return fmt.Sprintf(
+		"re-applying stash %q conflicted in %d file(s): %s; changes kept in stash",
+		e.StashName,
+		len(e.ConflictedFiles),
+		strings.Join(e.ConflictedFiles, ", "),
+	)
+}
+
+// PullWithAutostash stashes uncommitted work (including untracked files) under a named stash,
+// pulls the latest code for the current branch, and re-applies the stash.
+//
+// If the pull itself fails, the stash is restored and the pull error is returned.
+// If re-applying the stash conflicts, the stash is kept and a *StashConflictError listing the
+// conflicted files is returned. Like PullLatestCode, it returns git.NoErrAlreadyUpToDate when
+// there was nothing to pull and the stash was restored cleanly.
+func PullWithAutostash(ctx context.Context, repoPath string) error {
+	return pullWithAutostash(ctx, repoPath, PullLatestCode)
+}
+
+// pullWithAutostash wraps pull with the stash, pull, and re-apply sequence described by PullWithAutostash.
+func pullWithAutostash(
+	ctx context.Context,
+	repoPath string,
+	pull func(ctx context.Context, repoPath string) error,
+) error {
+	branch, err := GetCurrentBranch(ctx, repoPath)
+	if err != nil {
+		return err
+	}
+
+	stashName := fmt.Sprintf("%s %s %s", autostashPrefix, branch, time.Now().Format("2006-01-02T15:04:05"))
+
+	cmdStash, cancelStash := execGitCommand(ctx, repoPath, "stash", "push", "--include-untracked", "-m", stashName)
+	out, err := cmdStash.CombinedOutput()
+	cancelStash()
+	if err != nil {
+		return fmt.Errorf("failed to stash local changes: %w\n%s", err, string(out))
+	}
+	if strings.Contains(string(out), "No local changes to save") {
+		return pull(ctx, repoPath)
+	}
+
+	pullErr := pull(ctx, repoPath)
+	if pullErr != nil && !errors.Is(pullErr, git.NoErrAlreadyUpToDate) {
+		if popErr := popStash(ctx, repoPath, stashName); popErr != nil {
+			return fmt.Errorf("%w (additionally, %s)", pullErr, popErr.Error())
+		}
+		return pullErr
+	}
+
+	if err := popStash(ctx, repoPath, stashName); err != nil {
+		return err
+	}
+	return pullErr
+}
+
+// popStash re-applies the stash named stashName, even if other stashes were pushed on top of it.
+// On failure the stash is kept and a *StashConflictError describing any conflicted files is returned.
+func popStash(ctx context.Context, repoPath, stashName string) error {
+	ref, err := findStash(ctx, repoPath, stashName)
+	if err != nil {
+		return err
+	}
+
+	cmdPop, cancelPop := execGitCommand(ctx, repoPath, "stash", "pop", ref)
+	out, err := cmdPop.CombinedOutput()
+	cancelPop()
+	if err == nil {
+		return nil
+	}
+
+	files, _ := conflictedFiles(ctx, repoPath)
+	return &StashConflictError{
+		StashName:       stashName,
+		ConflictedFiles: files,
+		Output:          strings.TrimSpace(string(out)),
+	}
+}
+
+// findStash returns the ref, such as stash@{1}, of the newest stash whose message is stashName.
+func findStash(ctx context.Context, repoPath, stashName string) (string, error) {
+	cmd, cancel := execGitCommand(ctx, repoPath, "stash", "list", "--format=%gd%x09%gs")
+	defer cancel()
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list stashes: %w", err)
+	}
+
+	// Stashes pushed with -m are described as "On <branch>: <message>".
+	for _, line := range strings.Split(string(out), "\n") {
+		ref, subject, ok := strings.Cut(line, "\t")
+		if ok && strings.HasSuffix(subject, ": "+stashName) {
+			return ref, nil
+		}
+	}
+	return "", fmt.Errorf("stash %q not found", stashName)
+}
+
+// PrintStashConflicts reports, per repository, the stash that was kept and the files
+// that conflicted when re-applying autostashed work.
+func PrintStashConflicts(conflicts map[string]*StashConflictError) {
+	if len(conflicts) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(conflicts))
+	for name := range conflicts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	log.Error("Autostash conflicts (pulled, but local changes could not be re-applied cleanly):")
+	for _, name := range names {
+		c := conflicts[name]
+		log.Error("  - %s (changes kept in stash %q)", name, c.StashName)
+		for _, f := range c.ConflictedFiles {
+			log.Error("      conflict: %s", f)
+		}
+	}
+	log.Info("Resolve the conflicts, then drop the named stash ('git stash list' shows its stash@{n} ref) " +
+		"once your changes are restored.")
+}
+
+// conflictedFiles lists the paths with unresolved merge conflicts in the working tree.
+func conflictedFiles(ctx context.Context, repoPath string) ([]string, error) {
+	cmd, cancel := execGitCommand(ctx, repoPath, "diff", "--name-only", "--diff-filter=U")
+	defer cancel()
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
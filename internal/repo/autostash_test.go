@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// setupRemoteAndClone creates a bare-bones "remote" repository with test.txt and a local clone of it.
+func setupRemoteAndClone(t *testing.T) (remoteDir, localDir string, run func(dir string, args ...string)) {
+	t.Helper()
+
+	run = func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed in %s: %v\nOutput: %s", args, dir, err, string(out))
+		}
+	}
+	configure := func(dir string) {
+		run(dir, "config", "user.name", "Test User")
+		run(dir, "config", "user.email", "test@example.com")
+		run(dir, "config", "commit.gpgsign", "false")
+	}
+
+	remoteDir = t.TempDir()
+	run(remoteDir, "init")
+	configure(remoteDir)
+	run(remoteDir, "branch", "-M", "main")
+	if err := os.WriteFile(filepath.Join(remoteDir, "test.txt"), []byte("original\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write remote file: %v", err)
+	}
+	run(remoteDir, "add", "test.txt")
+	run(remoteDir, "commit", "-m", "Initial commit")
+
+	localDir = t.TempDir()
+	run(localDir, "clone", remoteDir, ".")
+	configure(localDir)
+
+	return remoteDir, localDir, run
+}
+
+func TestPullWithAutostash_RestoresChanges(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	// Remote adds a new file; local has an unrelated scratch file and an edit.
+	if err := os.WriteFile(filepath.Join(remoteDir, "remote.txt"), []byte("remote\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write remote file: %v", err)
+	}
+	run(remoteDir, "add", "remote.txt")
+	run(remoteDir, "commit", "-m", "Remote change")
+
+	if err := os.WriteFile(filepath.Join(localDir, "scratch.txt"), []byte("scratch\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write scratch file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, "test.txt"), []byte("local edit\n"), 0o644); err != nil {
+		t.Fatalf("Failed to edit local file: %v", err)
+	}
+
+	if err := PullWithAutostash(ctx, localDir); err != nil {
+		t.Fatalf("PullWithAutostash failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(localDir, "remote.txt")); err != nil {
+		t.Errorf("Expected remote change to be pulled: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(localDir, "test.txt")); string(content) != "local edit\n" {
+		t.Errorf("Expected local edit to be restored, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(localDir, "scratch.txt")); err != nil {
+		t.Errorf("Expected untracked scratch file to be restored: %v", err)
+	}
+
+	out, _ := exec.Command("git", "-C", localDir, "stash", "list").Output()
+	if strings.TrimSpace(string(out)) != "" {
+		t.Errorf("Expected stash to be dropped after clean re-apply, got %q", out)
+	}
+}
+
+func TestPullWithAutostash_CleanRepo(t *testing.T) {
+	_, localDir, _ := setupRemoteAndClone(t)
+
+	err := PullWithAutostash(context.Background(), localDir)
+	if !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		t.Errorf("Expected NoErrAlreadyUpToDate for clean, current repo, got %v", err)
+	}
+}
+
+func TestPullWithAutostash_ConflictKeepsStash(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	if err := os.WriteFile(filepath.Join(remoteDir, "test.txt"), []byte("remote edit\n"), 0o644); err != nil {
+		t.Fatalf("Failed to edit remote file: %v", err)
+	}
+	run(remoteDir, "commit", "-am", "Remote edit")
+
+	if err := os.WriteFile(filepath.Join(localDir, "test.txt"), []byte("conflicting local edit\n"), 0o644); err != nil {
+		t.Fatalf("Failed to edit local file: %v", err)
+	}
+
+	err := PullWithAutostash(ctx, localDir)
+	var conflictErr *StashConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("Expected StashConflictError, got %v", err)
+	}
+	if len(conflictErr.ConflictedFiles) != 1 || conflictErr.ConflictedFiles[0] != "test.txt" {
+		t.Errorf("Expected test.txt to be reported as conflicted, got %v", conflictErr.ConflictedFiles)
+	}
+	if !strings.HasPrefix(conflictErr.StashName, autostashPrefix+" main ") {
+		t.Errorf("Unexpected stash name %q", conflictErr.StashName)
+	}
+
+	out, _ := exec.Command("git", "-C", localDir, "stash", "list").Output()
+	if !strings.Contains(string(out), conflictErr.StashName) {
+		t.Errorf("Expected named stash to be kept, stash list: %q", out)
+	}
+}
+
+func TestPullWithAutostash_PopsNamedStash(t *testing.T) {
+	ctx := context.Background()
+	_, localDir, run := setupRemoteAndClone(t)
+
+	if err := os.WriteFile(filepath.Join(localDir, "test.txt"), []byte("local edit\n"), 0o644); err != nil {
+		t.Fatalf("Failed to edit local file: %v", err)
+	}
+
+	// Another stash is pushed on top of the autostash while pulling.
+	pull := func(ctx context.Context, repoPath string) error {
+		if err := os.WriteFile(filepath.Join(repoPath, "other.txt"), []byte("other\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write other file: %v", err)
+		}
+		run(repoPath, "stash", "push", "--include-untracked", "-m", "unrelated work")
+		return nil
+	}
+
+	if err := pullWithAutostash(ctx, localDir, pull); err != nil {
+		t.Fatalf("pullWithAutostash failed: %v", err)
+	}
+
+	if content, _ := os.ReadFile(filepath.Join(localDir, "test.txt")); string(content) != "local edit\n" {
+		t.Errorf("Expected the autostashed edit to be restored, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(localDir, "other.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected the unrelated stash not to be applied, stat error: %v", err)
+	}
+
+	out, _ := exec.Command("git", "-C", localDir, "stash", "list").Output()
+	if list := strings.TrimSpace(string(out)); !strings.Contains(list, "unrelated work") ||
+		strings.Contains(list, autostashPrefix) {
+		t.Errorf("Expected only the unrelated stash to remain, stash list: %q", list)
+	}
+}
@@ -0,0 +1,312 @@
+package repo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultStatusCacheTTL bounds how long a cached status is trusted even when the git
+// metadata fingerprint is unchanged. Edits to tracked files that have not been staged
+// do not touch .git, so the TTL is what eventually surfaces them.
+const DefaultStatusCacheTTL = 2 * time.Minute
+
+// StatusCache persists repository status snapshots and development folder scans on disk.
+// Entries are keyed by path and invalidated when the mtimes of .git/index, HEAD, refs,
+// or in-progress operation markers change, or when they are older than TTL.
+type StatusCache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// statusCacheEntry is the on-disk representation of a cached repository status.
+type statusCacheEntry struct {
+	Path        string     `json:"path"`
+	Fingerprint string     `json:"fingerprint"`
+	CachedAt    time.Time  `json:"cached_at"`
+	Status      StatusInfo `json:"status"`
+}
+
+// scanCacheEntry is the on-disk representation of a cached development folder scan.
+type scanCacheEntry struct {
+	Path        string    `json:"path"`
+	Fingerprint string    `json:"fingerprint"`
+	CachedAt    time.Time `json:"cached_at"`
+	Repos       []string  `json:"repos"`
+}
+
+var (
+	statusCacheMu sync.RWMutex
+	statusCache   *StatusCache
+)
+
+// NewStatusCache returns a cache rooted at dir. A non-positive ttl uses DefaultStatusCacheTTL.
+func NewStatusCache(dir string, ttl time.Duration) *StatusCache {
+	if ttl <= 0 {
+		ttl = DefaultStatusCacheTTL
+	}
+	return &StatusCache{Dir: dir, TTL: ttl}
+}
+
+// DefaultStatusCacheDir returns the platform cache location used for status snapshots.
+func DefaultStatusCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve user cache directory: %w", err)
+	}
+	return filepath.Join(base, "eng", "status"), nil
+}
+
+// SetStatusCache installs the cache consulted by GetDetailedStatus and the repository scan helpers.
+// Passing nil disables caching.
+func SetStatusCache(c *StatusCache) {
+	statusCacheMu.Lock()
+	defer statusCacheMu.Unlock()
+	statusCache = c
+}
+
+// ActiveStatusCache returns the currently installed cache, or nil when caching is disabled.
+func ActiveStatusCache() *StatusCache {
+	statusCacheMu.RLock()
+	defer statusCacheMu.RUnlock()
+	return statusCache
+}
+
+// Get returns the cached status for repoPath if its fingerprint still matches and it has not expired.
+func (c *StatusCache) Get(repoPath string) (StatusInfo, bool) {
+	var entry statusCacheEntry
+	if !c.read(c.statusFile(repoPath), &entry) {
+		return StatusInfo{}, false
+	}
+	if entry.Path != repoPath || time.Since(entry.CachedAt) > c.TTL {
+		return StatusInfo{}, false
+	}
+
+	fingerprint, err := gitFingerprint(repoPath)
+	if err != nil || fingerprint != entry.Fingerprint {
+		return StatusInfo{}, false
+	}
+	return entry.Status, true
+}
+
+// Put stores the status for repoPath alongside the current git metadata fingerprint.
+func (c *StatusCache) Put(repoPath string, info StatusInfo) error {
+	fingerprint, err := gitFingerprint(repoPath)
+	if err != nil {
+		return err
+	}
+	return c.write(c.statusFile(repoPath), statusCacheEntry{
+		Path:        repoPath,
+		Fingerprint: fingerprint,
+		CachedAt:    time.Now(),
+		Status:      info,
+	})
+}
+
+// Invalidate removes any cached status for repoPath.
+func (c *StatusCache) Invalidate(repoPath string) error {
+	if err := os.Remove(c.statusFile(repoPath)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// GetRepoScan returns the cached list of repositories found directly under devPath.
+// The entry is discarded when the mtime of devPath changes (a child was added, removed, or renamed),
+// or the mtime of one of its children does (for example, git init created a .git in it).
+func (c *StatusCache) GetRepoScan(devPath string) ([]string, bool) {
+	var entry scanCacheEntry
+	if !c.read(c.scanFile(devPath), &entry) {
+		return nil, false
+	}
+	if entry.Path != devPath || time.Since(entry.CachedAt) > c.TTL {
+		return nil, false
+	}
+
+	fingerprint, err := scanFingerprint(devPath)
+	if err != nil || fingerprint != entry.Fingerprint {
+		return nil, false
+	}
+	return entry.Repos, true
+}
+
+// PutRepoScan stores the list of repositories found directly under devPath.
+func (c *StatusCache) PutRepoScan(devPath string, repos []string) error {
+	fingerprint, err := scanFingerprint(devPath)
+	if err != nil {
+		return err
+	}
+	return c.write(c.scanFile(devPath), scanCacheEntry{
+		Path:        devPath,
+		Fingerprint: fingerprint,
+		CachedAt:    time.Now(),
+		Repos:       repos,
+	})
+}
+
+// Clear removes every cached entry.
+func (c *StatusCache) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
+
+func (c *StatusCache) statusFile(repoPath string) string {
+	return filepath.Join(c.Dir, "repos", cacheKey(repoPath)+".json")
+}
+
+func (c *StatusCache) scanFile(devPath string) string {
+	return filepath.Join(c.Dir, "scans", cacheKey(devPath)+".json")
+}
+
+func (c *StatusCache) read(file string, v any) bool {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// write stores v atomically so concurrent readers never observe a partial file.
+func (c *StatusCache) write(file string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("failed to create status cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create status cache file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write status cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write status cache file: %w", err)
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+func cacheKey(path string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(path)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// resolveGitDirs returns the per-worktree git directory and the common directory
+// (where refs and packed-refs live) for repoPath, without invoking git.
+func resolveGitDirs(repoPath string) (gitDir, commonDir string, err error) {
+	dotGit := filepath.Join(repoPath, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", "", err
+	}
+	if info.IsDir() {
+		return dotGit, dotGit, nil
+	}
+
+	// Linked worktrees and submodules use a .git file pointing at the real git dir.
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", "", err
+	}
+	line := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(line, "gitdir:") {
+		return "", "", fmt.Errorf("unrecognized .git file in %s", repoPath)
+	}
+	gitDir = strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(repoPath, gitDir)
+	}
+
+	commonDir = gitDir
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = strings.TrimSpace(string(data))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	}
+	return gitDir, commonDir, nil
+}
+
+// scanFingerprint summarizes the mtimes of devPath and of the directories directly under it.
+func scanFingerprint(devPath string) (string, error) {
+	info, err := os.Stat(devPath)
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(devPath)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(strconv.FormatInt(info.ModTime().UnixNano(), 10))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			b.WriteByte(';')
+			b.WriteString(entry.Name())
+			b.WriteByte('=')
+			b.WriteString(strconv.FormatInt(info.ModTime().UnixNano(), 10))
+		}
+	}
+	return b.String(), nil
+}
+
+// gitFingerprint summarizes the mtimes of the git metadata that affects StatusInfo.
+func gitFingerprint(repoPath string) (string, error) {
+	gitDir, commonDir, err := resolveGitDirs(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	addMtime := func(name, path string) {
+		var mtime int64
+		if info, err := os.Stat(path); err == nil {
+			mtime = info.ModTime().UnixNano()
+		}
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strconv.FormatInt(mtime, 10))
+		b.WriteByte(';')
+	}
+
+	for _, name := range []string{
+		"HEAD", "index", "FETCH_HEAD", "ORIG_HEAD", "MERGE_HEAD",
+		"CHERRY_PICK_HEAD", "BISECT_LOG", "rebase-merge", "rebase-apply",
+	} {
+		addMtime(name, filepath.Join(gitDir, name))
+	}
+	addMtime("config", filepath.Join(commonDir, "config"))
+	addMtime("packed-refs", filepath.Join(commonDir, "packed-refs"))
+
+	var refsMtime int64
+	_ = filepath.WalkDir(filepath.Join(commonDir, "refs"), func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().UnixNano() > refsMtime {
+			refsMtime = info.ModTime().UnixNano()
+		}
+		return nil
+	})
+	b.WriteString("refs=")
+	b.WriteString(strconv.FormatInt(refsMtime, 10))
+
+	return b.String(), nil
+}
@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStatusCache_PutGet(t *testing.T) {
+	repoPath := setupTestRepo(t, "main")
+	defer os.RemoveAll(repoPath)
+
+	cache := NewStatusCache(t.TempDir(), time.Minute)
+	if _, ok := cache.Get(repoPath); ok {
+		t.Fatal("expected cache miss before Put")
+	}
+
+	want := StatusInfo{Branch: "main", AheadCount: 3, OngoingOp: "rebase"}
+	if err := cache.Put(repoPath, want); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	got, ok := cache.Get(repoPath)
+	if !ok {
+		t.Fatal("expected cache hit after Put")
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if err := cache.Invalidate(repoPath); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+	if _, ok := cache.Get(repoPath); ok {
+		t.Error("expected cache miss after Invalidate")
+	}
+}
+
+func TestStatusCache_InvalidatedByGitMetadata(t *testing.T) {
+	repoPath := setupTestRepo(t, "main")
+	defer os.RemoveAll(repoPath)
+
+	cache := NewStatusCache(t.TempDir(), time.Minute)
+	if err := cache.Put(repoPath, StatusInfo{Branch: "main"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// Switching branches rewrites HEAD, which must invalidate the entry.
+	time.Sleep(10 * time.Millisecond)
+	cmd := exec.Command("git", "checkout", "-b", "feature")
+	cmd.Dir = repoPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git checkout failed: %v\n%s", err, out)
+	}
+
+	if _, ok := cache.Get(repoPath); ok {
+		t.Error("expected cache miss after HEAD changed")
+	}
+}
+
+func TestStatusCache_Expires(t *testing.T) {
+	repoPath := setupTestRepo(t, "main")
+	defer os.RemoveAll(repoPath)
+
+	cache := NewStatusCache(t.TempDir(), time.Millisecond)
+	if err := cache.Put(repoPath, StatusInfo{Branch: "main"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get(repoPath); ok {
+		t.Error("expected cache miss after TTL elapsed")
+	}
+}
+
+func TestStatusCache_RepoScan(t *testing.T) {
+	devPath := t.TempDir()
+	cache := NewStatusCache(t.TempDir(), time.Minute)
+
+	repos := []string{filepath.Join(devPath, "a"), filepath.Join(devPath, "b")}
+	if err := cache.PutRepoScan(devPath, repos); err != nil {
+		t.Fatalf("PutRepoScan failed: %v", err)
+	}
+
+	got, ok := cache.GetRepoScan(devPath)
+	if !ok || len(got) != 2 {
+		t.Fatalf("expected cached scan of 2 repos, got %v (hit=%v)", got, ok)
+	}
+
+	// Adding a child directory changes the dev path mtime.
+	time.Sleep(10 * time.Millisecond)
+	if err := os.Mkdir(filepath.Join(devPath, "c"), 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if _, ok := cache.GetRepoScan(devPath); ok {
+		t.Error("expected scan cache miss after dev path changed")
+	}
+
+	// Running git init in an existing child only changes the child's mtime.
+	if err := cache.PutRepoScan(devPath, repos); err != nil {
+		t.Fatalf("PutRepoScan failed: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := os.Mkdir(filepath.Join(devPath, "c", ".git"), 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if _, ok := cache.GetRepoScan(devPath); ok {
+		t.Error("expected scan cache miss after a child gained a .git directory")
+	}
+}
+
+func TestGetDetailedStatus_UsesActiveCache(t *testing.T) {
+	ctx := context.Background()
+	repoPath := setupTestRepo(t, "main")
+	defer os.RemoveAll(repoPath)
+
+	SetStatusCache(NewStatusCache(t.TempDir(), time.Minute))
+	defer SetStatusCache(nil)
+
+	if _, err := GetDetailedStatus(ctx, repoPath); err != nil {
+		t.Fatalf("failed to get detailed status: %v", err)
+	}
+
+	// A new untracked file does not touch .git, so the cached status is served...
+	if err := os.WriteFile(filepath.Join(repoPath, "untracked.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("failed to write untracked file: %v", err)
+	}
+	info, err := GetDetailedStatus(ctx, repoPath)
+	if err != nil {
+		t.Fatalf("failed to get detailed status: %v", err)
+	}
+	if info.UntrackedCount != 0 {
+		t.Errorf("expected cached status with 0 untracked files, got %d", info.UntrackedCount)
+	}
+
+	// ...until an explicit refresh re-reads git.
+	info, err = RefreshDetailedStatus(ctx, repoPath)
+	if err != nil {
+		t.Fatalf("failed to refresh detailed status: %v", err)
+	}
+	if info.UntrackedCount != 1 {
+		t.Errorf("expected 1 untracked file after refresh, got %d", info.UntrackedCount)
+	}
+}
+
+func TestResolveGitDirs_Worktree(t *testing.T) {
+	repoPath := setupTestRepo(t, "main")
+	defer os.RemoveAll(repoPath)
+
+	worktreePath := filepath.Join(t.TempDir(), "wt")
+	cmd := exec.Command("git", "worktree", "add", "-b", "wt-branch", worktreePath)
+	cmd.Dir = repoPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %v\n%s", err, out)
+	}
+
+	gitDir, commonDir, err := resolveGitDirs(worktreePath)
+	if err != nil {
+		t.Fatalf("resolveGitDirs failed: %v", err)
+	}
+	if filepath.Base(filepath.Dir(gitDir)) != "worktrees" {
+		t.Errorf("expected per-worktree git dir, got %s", gitDir)
+	}
+
+	wantCommon, _ := filepath.EvalSymlinks(filepath.Join(repoPath, ".git"))
+	gotCommon, _ := filepath.EvalSymlinks(commonDir)
+	if gotCommon != wantCommon {
+		t.Errorf("expected common dir %s, got %s", wantCommon, gotCommon)
+	}
+
+	if _, err := gitFingerprint(worktreePath); err != nil {
+		t.Errorf("gitFingerprint failed for worktree: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FileChange is one entry of `git status --porcelain`.
+type FileChange struct {
+	Path     string `json:"path" yaml:"path"`
+	Index    byte   `json:"index" yaml:"index"`       // Status in the index (X), ' ' when unchanged
+	Worktree byte   `json:"worktree" yaml:"worktree"` // Status in the working tree (Y), ' ' when unchanged
+}
+
+// Staged reports whether the file has changes in the index.
+func (f FileChange) Staged() bool {
+	return f.Index != ' ' && f.Index != '?'
+}
+
+// Untracked reports whether the file is not tracked by git.
+func (f FileChange) Untracked() bool {
+	return f.Index == '?'
+}
+
+// Label renders the change as "XY path", matching `git status --short`.
+func (f FileChange) Label() string {
+	return fmt.Sprintf("%c%c %s", f.Index, f.Worktree, f.Path)
+}
+
+// ChangedFiles lists the changed and untracked files of the repository at repoPath.
+// Untracked directories are expanded to the files they contain.
+func ChangedFiles(ctx context.Context, repoPath string) ([]FileChange, error) {
+	cmd, cancel := execGitCommand(ctx, repoPath, "status", "--porcelain", "-z", "--untracked-files=all")
+	defer cancel()
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git status: %w", err)
+	}
+	return parsePorcelainFiles(string(out)), nil
+}
+
+// parsePorcelainFiles parses NUL-separated `git status --porcelain -z` output.
+// Renames and copies carry the original path as an extra entry, which is skipped.
+func parsePorcelainFiles(output string) []FileChange {
+	var files []FileChange
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		f := FileChange{Index: entry[0], Worktree: entry[1], Path: entry[3:]}
+		files = append(files, f)
+		if f.Index == 'R' || f.Index == 'C' {
+			i++
+		}
+	}
+	return files
+}
+
+// StageFiles adds the given paths to the index, including deletions.
+func StageFiles(ctx context.Context, repoPath string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	cmd, cancel := execGitCommand(ctx, repoPath, append([]string{"add", "--all", "--"}, paths...)...)
+	defer cancel()
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git add failed: %w\n%s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// UnstageFiles removes the given paths from the index, keeping the working tree changes.
+func UnstageFiles(ctx context.Context, repoPath string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	cmd, cancel := execGitCommand(ctx, repoPath, append([]string{"reset", "--quiet", "--"}, paths...)...)
+	defer cancel()
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git reset failed: %w\n%s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Commit records the staged changes with the given message. Commit hooks run as usual.
+func Commit(ctx context.Context, repoPath, message string) error {
+	cmd, cancel := execGitCommand(ctx, repoPath, "commit", "--file=-")
+	defer cancel()
+	cmd.Stdin = strings.NewReader(message)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git commit failed: %w\n%s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// ConventionalCommitTypes are the commit types accepted by ValidateConventionalCommit.
+var ConventionalCommitTypes = []string{
+	"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test",
+}
+
+var conventionalSubjectRE = regexp.MustCompile(`^([a-z]+)(\([^()\s]+\))?(!)?: \S`)
+
+// ValidateConventionalCommit checks that message follows the Conventional Commits format:
+// a "type(scope)!: description" subject line, optionally followed by a blank line and a body.
+func ValidateConventionalCommit(message string) error {
+	subject, rest, hasBody := strings.Cut(strings.TrimSpace(message), "\n")
+	match := conventionalSubjectRE.FindStringSubmatch(subject)
+	if match == nil {
+		return errors.New(`subject must look like "type(scope): description", e.g. "feat(ui): add tabs"`)
+	}
+
+	known := false
+	for _, t := range ConventionalCommitTypes {
+		if match[1] == t {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown type %q (expected one of %s)", match[1], strings.Join(ConventionalCommitTypes, ", "))
+	}
+	if hasBody && strings.TrimSpace(strings.SplitN(rest, "\n", 2)[0]) != "" {
+		return errors.New("separate the subject from the body with a blank line")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStageUnstageAndCommit(t *testing.T) {
+	ctx := context.Background()
+	_, localDir, run := setupRemoteAndClone(t)
+
+	if err := os.WriteFile(filepath.Join(localDir, "test.txt"), []byte("changed\n"), 0o644); err != nil {
+		t.Fatalf("Failed to modify file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(localDir, "new dir"), 0o755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, "new dir", "a b.txt"), []byte("new\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	files, err := ChangedFiles(ctx, localDir)
+	if err != nil {
+		t.Fatalf("ChangedFiles failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 changed files, got %+v", files)
+	}
+	byPath := map[string]FileChange{}
+	for _, f := range files {
+		byPath[f.Path] = f
+	}
+	if f := byPath["new dir/a b.txt"]; !f.Untracked() || f.Label() != "?? new dir/a b.txt" {
+		t.Errorf("Expected an untracked file with spaces, got %+v", f)
+	}
+	if f := byPath["test.txt"]; f.Staged() || f.Worktree != 'M' {
+		t.Errorf("Expected an unstaged modification, got %+v", f)
+	}
+
+	if err := StageFiles(ctx, localDir, []string{"test.txt", "new dir/a b.txt"}); err != nil {
+		t.Fatalf("StageFiles failed: %v", err)
+	}
+	if err := UnstageFiles(ctx, localDir, []string{"new dir/a b.txt"}); err != nil {
+		t.Fatalf("UnstageFiles failed: %v", err)
+	}
+	files, _ = ChangedFiles(ctx, localDir)
+	for _, f := range files {
+		if f.Path == "test.txt" && !f.Staged() {
+			t.Errorf("Expected test.txt to be staged, got %+v", f)
+		}
+		if f.Path == "new dir/a b.txt" && !f.Untracked() {
+			t.Errorf("Expected the new file to be untracked again, got %+v", f)
+		}
+	}
+
+	if err := Commit(ctx, localDir, "fix: update test file\n\nWith a body."); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	commits, err := RecentCommits(ctx, localDir, 1)
+	if err != nil || len(commits) != 1 || commits[0].Subject != "fix: update test file" {
+		t.Errorf("Expected the new commit on HEAD, got %+v (%v)", commits, err)
+	}
+	run(localDir, "diff", "--quiet", "HEAD", "--", "test.txt")
+}
+
+func TestValidateConventionalCommit(t *testing.T) {
+	tests := []struct {
+		message string
+		valid   bool
+	}{
+		{message: "feat: add tabs", valid: true},
+		{message: "fix(ui)!: drop legacy layout", valid: true},
+		{message: "docs(readme): explain setup\n\nLonger body.", valid: true},
+		{message: "Add tabs", valid: false},
+		{message: "feature: add tabs", valid: false},
+		{message: "feat:add tabs", valid: false},
+		{message: "feat: add tabs\nbody without blank line", valid: false},
+	}
+	for _, tt := range tests {
+		err := ValidateConventionalCommit(tt.message)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateConventionalCommit(%q) = %v, want valid=%v", tt.message, err, tt.valid)
+		}
+	}
+}
@@ -23,6 +23,8 @@ func execGitCommand(ctx context.Context, dir string, args ...string) (*exec.Cmd,
 	env := os.Environ()
 	env = append(env, "GIT_TERMINAL_PROMPT=0")
 	env = append(env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	// Status reads must not rewrite the index, which would wake up the dashboard's file watcher.
+	env = append(env, "GIT_OPTIONAL_LOCKS=0")
 	cmd.Env = env
 
 	return cmd, cancel
@@ -13,14 +13,15 @@ import (
 // GetGitLabHostAndProjectPath attempts to detect GitLab host and project path from the current repo remote.
 // It returns host (e.g., gitlab.com) and project path (e.g., group/subgroup/repo).
 func GetGitLabHostAndProjectPath(ctx context.Context, repoPath string) (string, string, error) {
-	remoteURL, err := getOriginURL(ctx, repoPath)
+	remoteURL, err := GetOriginURL(ctx, repoPath)
 	if err != nil || remoteURL == "" {
 		return "", "", errors.New("no git remote origin url found")
 	}
 	return parseGitLabRemote(remoteURL)
 }
 
-func getOriginURL(ctx context.Context, repoPath string) (string, error) {
+// GetOriginURL returns the first URL configured for the repository's origin remote.
+func GetOriginURL(ctx context.Context, repoPath string) (string, error) {
 	r, err := git.PlainOpen(repoPath)
 	if err != nil {
 		return "", err
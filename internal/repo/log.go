@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitSummary is the one-line description of a commit shown in log views.
+type CommitSummary struct {
+	Hash    string    `json:"hash" yaml:"hash"`
+	Author  string    `json:"author" yaml:"author"`
+	When    time.Time `json:"when" yaml:"when"`
+	Subject string    `json:"subject" yaml:"subject"`
+}
+
+// commitSummaryFormat separates the CommitSummary fields with the ASCII unit separator.
+const commitSummaryFormat = "--format=%h%x1f%an%x1f%at%x1f%s"
+
+// RecentCommits returns up to limit commits reachable from HEAD, newest first.
+func RecentCommits(ctx context.Context, repoPath string, limit int) ([]CommitSummary, error) {
+	return logCommits(ctx, repoPath, "HEAD", limit)
+}
+
+// IncomingCommits returns up to limit commits on the upstream branch that HEAD does not
+// contain yet, newest first. It reads the remote-tracking ref, so fetch first for fresh results.
+func IncomingCommits(ctx context.Context, repoPath string, limit int) ([]CommitSummary, error) {
+	return logCommits(ctx, repoPath, "HEAD..@{upstream}", limit)
+}
+
+// logCommits runs git log for the revision range and parses the output.
+func logCommits(ctx context.Context, repoPath, revRange string, limit int) ([]CommitSummary, error) {
+	args := []string{"log", commitSummaryFormat}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, revRange, "--")
+
+	cmd, cancel := execGitCommand(ctx, repoPath, args...)
+	defer cancel()
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s failed: %w", revRange, err)
+	}
+	return parseCommitSummaries(string(out)), nil
+}
+
+// parseCommitSummaries parses lines produced with commitSummaryFormat.
+func parseCommitSummaries(output string) []CommitSummary {
+	var commits []CommitSummary
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		c := CommitSummary{Hash: fields[0], Author: fields[1], Subject: fields[3]}
+		if secs, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			c.When = time.Unix(secs, 0)
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// DiffStat returns the `git diff --stat` lines for staged and unstaged changes against HEAD.
+// Untracked files are not included. The last line is git's summary.
+func DiffStat(ctx context.Context, repoPath string) ([]string, error) {
+	cmd, cancel := execGitCommand(ctx, repoPath, "diff", "--stat", "HEAD", "--")
+	defer cancel()
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --stat failed: %w", err)
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecentAndIncomingCommits(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	commitFile(t, run, localDir, "local.txt", "local\n")
+	commitFile(t, run, remoteDir, "remote-1.txt", "one\n")
+	commitFile(t, run, remoteDir, "remote-2.txt", "two\n")
+	run(localDir, "fetch", "origin")
+
+	recent, err := RecentCommits(ctx, localDir, 1)
+	if err != nil {
+		t.Fatalf("RecentCommits failed: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Subject != "Update local.txt" || recent[0].Author != "Test User" {
+		t.Errorf("Expected the local commit, got %+v", recent)
+	}
+	if recent[0].Hash == "" || recent[0].When.IsZero() {
+		t.Errorf("Expected hash and time to be parsed, got %+v", recent[0])
+	}
+
+	incoming, err := IncomingCommits(ctx, localDir, 10)
+	if err != nil {
+		t.Fatalf("IncomingCommits failed: %v", err)
+	}
+	if len(incoming) != 2 || incoming[0].Subject != "Update remote-2.txt" {
+		t.Errorf("Expected the two remote commits, newest first, got %+v", incoming)
+	}
+}
+
+func TestDiffStat(t *testing.T) {
+	ctx := context.Background()
+	_, localDir, run := setupRemoteAndClone(t)
+
+	stat, err := DiffStat(ctx, localDir)
+	if err != nil {
+		t.Fatalf("DiffStat failed: %v", err)
+	}
+	if len(stat) != 0 {
+		t.Errorf("Expected no changes in a clean clone, got %v", stat)
+	}
+
+	if err := os.WriteFile(filepath.Join(localDir, "test.txt"), []byte("changed\n"), 0o644); err != nil {
+		t.Fatalf("Failed to modify file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, "staged.txt"), []byte("new\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	run(localDir, "add", "staged.txt")
+
+	stat, err = DiffStat(ctx, localDir)
+	if err != nil {
+		t.Fatalf("DiffStat failed: %v", err)
+	}
+	joined := strings.Join(stat, "\n")
+	if len(stat) != 3 || !strings.Contains(joined, "test.txt") || !strings.Contains(joined, "staged.txt") {
+		t.Errorf("Expected two files and a summary line, got %v", stat)
+	}
+}
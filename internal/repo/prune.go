@@ -0,0 +1,147 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Reasons reported by FindStaleBranches.
+const (
+	StaleReasonGone   = "gone"   // The upstream branch was deleted on the remote
+	StaleReasonMerged = "merged" // Fully merged into one of the base branches
+)
+
+// StaleBranch is a local branch that is a candidate for deletion.
+type StaleBranch struct {
+	Name     string
+	Reason   string // StaleReasonGone or StaleReasonMerged
+	Upstream string // Upstream ref for gone branches, e.g. "origin/feature"
+	MergedTo string // Base branch the branch is merged into, for merged branches
+	// Unmerged marks a gone branch whose tip is not reachable from a base branch or a remote
+	// ref, so deleting it loses commits.
+	Unmerged bool
+}
+
+// FindStaleBranches lists local branches whose upstream is gone or that are fully merged
+// into one of bases. Run FetchAllPrune first so deleted remote branches are detected.
+// The current branch, the bases themselves, and branches checked out in any worktree are
+// never returned. A branch that is both gone and merged is reported as gone, and a gone branch
+// with commits that no base branch or remote ref contains is marked Unmerged.
+func FindStaleBranches(ctx context.Context, repoPath string, bases []string) ([]StaleBranch, error) {
+	cmd, cancel := execGitCommand(
+		ctx,
+		repoPath,
+		"for-each-ref",
+		"--format=%(refname:short)%00%(upstream:short)%00%(upstream:track)",
+		"refs/heads",
+	)
+	out, err := cmd.Output()
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	excluded := make(map[string]bool)
+	for _, b := range bases {
+		excluded[b] = true
+	}
+	if current, err := GetCurrentBranch(ctx, repoPath); err == nil {
+		excluded[current] = true
+	}
+	if worktrees, err := ListWorktrees(ctx, repoPath); err == nil {
+		for _, wt := range worktrees {
+			if wt.Branch != "" {
+				excluded[wt.Branch] = true
+			}
+		}
+	}
+
+	found := make(map[string]StaleBranch)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 || excluded[fields[0]] {
+			continue
+		}
+		if fields[2] == "[gone]" {
+			found[fields[0]] = StaleBranch{Name: fields[0], Reason: StaleReasonGone, Upstream: fields[1]}
+		}
+	}
+
+	for _, base := range bases {
+		if base == "" || !branchExists(ctx, repoPath, base) {
+			continue
+		}
+		mergedCmd, mergedCancel := execGitCommand(
+			ctx,
+			repoPath,
+			"for-each-ref",
+			"--merged="+base,
+			"--format=%(refname:short)",
+			"refs/heads",
+		)
+		mergedOut, err := mergedCmd.Output()
+		mergedCancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches merged into %s: %w", base, err)
+		}
+		for _, name := range strings.Fields(string(mergedOut)) {
+			if excluded[name] {
+				continue
+			}
+			if _, ok := found[name]; !ok {
+				found[name] = StaleBranch{Name: name, Reason: StaleReasonMerged, MergedTo: base}
+			}
+		}
+	}
+
+	stale := make([]StaleBranch, 0, len(found))
+	for _, b := range found {
+		if b.Reason == StaleReasonGone {
+			reachable, err := reachableElsewhere(ctx, repoPath, b.Name, bases)
+			b.Unmerged = err != nil || !reachable
+		}
+		stale = append(stale, b)
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].Name < stale[j].Name })
+	return stale, nil
+}
+
+// reachableElsewhere reports whether the tip of branch is reachable from one of bases or from a
+// remote-tracking ref, so that deleting the branch loses no commits.
+func reachableElsewhere(ctx context.Context, repoPath, branch string, bases []string) (bool, error) {
+	args := []string{"rev-list", "--max-count=1", "refs/heads/" + branch, "--not", "--remotes"}
+	for _, base := range bases {
+		if base != "" && branchExists(ctx, repoPath, base) {
+			args = append(args, "refs/heads/"+base)
+		}
+	}
+
+	cmd, cancel := execGitCommand(ctx, repoPath, args...)
+	defer cancel()
+
+	out, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether %s is merged: %w", branch, err)
+	}
+	return strings.TrimSpace(string(out)) == "", nil
+}
+
+// DeleteBranch deletes a local branch. Without force, git refuses to delete a branch that
+// is not merged into its upstream or HEAD; branches whose upstream is gone usually need force,
+// which callers should only use when the branch is not Unmerged or the user asked for it.
+func DeleteBranch(ctx context.Context, repoPath, branch string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+
+	cmd, cancel := execGitCommand(ctx, repoPath, "branch", flag, branch)
+	defer cancel()
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w\n%s", branch, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindStaleBranches(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	// gone, but its commit is still on another remote branch.
+	run(localDir, "checkout", "-b", "gone-merged")
+	commitFile(t, run, localDir, "gone-merged.txt", "gone merged\n")
+	run(localDir, "push", "-u", "origin", "gone-merged")
+	run(localDir, "push", "origin", "gone-merged:archive")
+	run(localDir, "checkout", "main")
+
+	// gone: pushed, then deleted on the remote.
+	run(localDir, "checkout", "-b", "gone-feature")
+	commitFile(t, run, localDir, "gone.txt", "gone\n")
+	run(localDir, "push", "-u", "origin", "gone-feature")
+
+	// merged: branch whose commit is already on main.
+	run(localDir, "checkout", "main")
+	run(localDir, "checkout", "-b", "merged-feature")
+	commitFile(t, run, localDir, "merged.txt", "merged\n")
+	run(localDir, "checkout", "main")
+	run(localDir, "merge", "--ff-only", "merged-feature")
+
+	// active: unmerged local work that must be kept.
+	run(localDir, "checkout", "-b", "active")
+	commitFile(t, run, localDir, "active.txt", "active\n")
+
+	// checked out in a worktree: merged, but not deletable.
+	run(localDir, "branch", "in-worktree", "main")
+	run(localDir, "worktree", "add", filepath.Join(t.TempDir(), "wt"), "in-worktree")
+
+	run(localDir, "checkout", "main")
+	run(remoteDir, "branch", "-D", "gone-feature")
+	run(remoteDir, "branch", "-D", "gone-merged")
+	if err := FetchAllPrune(ctx, localDir); err != nil {
+		t.Fatalf("FetchAllPrune failed: %v", err)
+	}
+
+	stale, err := FindStaleBranches(ctx, localDir, []string{"main", "develop"})
+	if err != nil {
+		t.Fatalf("FindStaleBranches failed: %v", err)
+	}
+	if len(stale) != 3 {
+		t.Fatalf("Expected 3 stale branches, got %+v", stale)
+	}
+	if stale[0].Name != "gone-feature" || stale[0].Reason != StaleReasonGone ||
+		stale[0].Upstream != "origin/gone-feature" || !stale[0].Unmerged {
+		t.Errorf("Unexpected unmerged gone branch: %+v", stale[0])
+	}
+	if stale[1].Name != "gone-merged" || stale[1].Reason != StaleReasonGone || stale[1].Unmerged {
+		t.Errorf("Unexpected gone branch merged on the remote: %+v", stale[1])
+	}
+	if stale[2].Name != "merged-feature" || stale[2].Reason != StaleReasonMerged || stale[2].MergedTo != "main" {
+		t.Errorf("Unexpected merged branch: %+v", stale[2])
+	}
+
+	if err := DeleteBranch(ctx, localDir, "gone-feature", false); err == nil {
+		t.Error("Expected an unmerged gone branch to need force")
+	}
+	if err := DeleteBranch(ctx, localDir, "gone-feature", true); err != nil {
+		t.Errorf("DeleteBranch with force failed: %v", err)
+	}
+	if err := DeleteBranch(ctx, localDir, "merged-feature", false); err != nil {
+		t.Errorf("DeleteBranch failed: %v", err)
+	}
+	if branchExists(ctx, localDir, "gone-feature") || branchExists(ctx, localDir, "merged-feature") {
+		t.Error("Expected branches to be deleted")
+	}
+}
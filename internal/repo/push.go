@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PushOptions controls how Push updates the remote branch.
+type PushOptions struct {
+	// ForceWithLease overwrites the remote branch only if it still points where the local
+	// remote-tracking ref says it does, so commits pushed by others are never discarded.
+	ForceWithLease bool
+	// SetUpstream pushes the current branch to origin under the same name and records it as
+	// the branch's upstream. Use it for branches that have never been pushed.
+	SetUpstream bool
+}
+
+// Push pushes the current branch of repoPath to its upstream, or to origin when
+// opts.SetUpstream is set.
+func Push(ctx context.Context, repoPath string, opts PushOptions) error {
+	args := []string{"push"}
+	if opts.ForceWithLease {
+		args = append(args, "--force-with-lease")
+	}
+	if opts.SetUpstream {
+		branch, err := GetCurrentBranch(ctx, repoPath)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--set-upstream", "origin", branch)
+	}
+
+	cmd, cancel := execGitCommand(ctx, repoPath, args...)
+	defer cancel()
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		output := strings.TrimSpace(string(out))
+		if strings.Contains(output, "stale info") {
+			return fmt.Errorf("remote branch changed since the last fetch; fetch and review before forcing: %w", err)
+		}
+		return fmt.Errorf("git push failed: %w\n%s", err, output)
+	}
+	return nil
+}
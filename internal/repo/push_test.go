@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPush_SetUpstreamAndForceWithLease(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	run(localDir, "checkout", "-b", "feature")
+	commitFile(t, run, localDir, "feature.txt", "one\n")
+
+	if err := Push(ctx, localDir, PushOptions{SetUpstream: true}); err != nil {
+		t.Fatalf("Push with SetUpstream failed: %v", err)
+	}
+	status, err := RefreshDetailedStatus(ctx, localDir)
+	if err != nil {
+		t.Fatalf("RefreshDetailedStatus failed: %v", err)
+	}
+	if !status.HasUpstream || status.AheadCount != 0 {
+		t.Fatalf("Expected feature to track origin with nothing ahead, got %+v", status)
+	}
+
+	// Rewritten history needs a lease to be pushed.
+	run(localDir, "commit", "--amend", "-m", "Rewritten")
+	if err := Push(ctx, localDir, PushOptions{}); err == nil {
+		t.Fatal("Expected a plain push of rewritten history to be rejected")
+	}
+	if err := Push(ctx, localDir, PushOptions{ForceWithLease: true}); err != nil {
+		t.Fatalf("Push with ForceWithLease failed: %v", err)
+	}
+
+	// Someone else pushes to the branch; without a fetch the lease is stale.
+	run(remoteDir, "checkout", "feature")
+	commitFile(t, run, remoteDir, "other.txt", "theirs\n")
+	run(remoteDir, "checkout", "main")
+	run(localDir, "commit", "--amend", "-m", "Rewritten once more")
+	err = Push(ctx, localDir, PushOptions{ForceWithLease: true})
+	if err == nil || !strings.Contains(err.Error(), "remote branch changed since the last fetch") {
+		t.Fatalf("Expected a stale lease error, got %v", err)
+	}
+}
@@ -11,20 +11,45 @@ import (
 
 // StatusInfo holds detailed git repository status information.
 type StatusInfo struct {
-	Branch         string
-	IsDetached     bool
-	AheadCount     int
-	BehindCount    int
-	HasUpstream    bool
-	UnstagedCount  int
-	StagedCount    int
-	UntrackedCount int
-	ConflictCount  int
-	OngoingOp      string // "rebase", "merge", "cherry-pick", "bisect", or ""
+	Branch         string `json:"branch" yaml:"branch"`
+	IsDetached     bool   `json:"detached" yaml:"detached"`
+	AheadCount     int    `json:"ahead" yaml:"ahead"`
+	BehindCount    int    `json:"behind" yaml:"behind"`
+	HasUpstream    bool   `json:"has_upstream" yaml:"has_upstream"`
+	UnstagedCount  int    `json:"unstaged" yaml:"unstaged"`
+	StagedCount    int    `json:"staged" yaml:"staged"`
+	UntrackedCount int    `json:"untracked" yaml:"untracked"`
+	ConflictCount  int    `json:"conflicts" yaml:"conflicts"`
+	OngoingOp      string `json:"ongoing_op" yaml:"ongoing_op"` // "rebase", "merge", "cherry-pick", "bisect", or ""
 }
 
-// GetDetailedStatus retrieves rich git status details by running the standard Git CLI.
+// GetDetailedStatus retrieves rich git status details, consulting the active status cache first.
+// When no cache is installed or the cached entry is stale, it runs the standard Git CLI.
 func GetDetailedStatus(ctx context.Context, repoPath string) (StatusInfo, error) {
+	if cache := ActiveStatusCache(); cache != nil {
+		if info, ok := cache.Get(repoPath); ok {
+			return info, nil
+		}
+	}
+	return RefreshDetailedStatus(ctx, repoPath)
+}
+
+// RefreshDetailedStatus retrieves rich git status details by running the standard Git CLI,
+// bypassing any cached entry. The result is written back to the active status cache.
+func RefreshDetailedStatus(ctx context.Context, repoPath string) (StatusInfo, error) {
+	info, err := readDetailedStatus(ctx, repoPath)
+	if err != nil {
+		return info, err
+	}
+	if cache := ActiveStatusCache(); cache != nil {
+		// A failed cache write only costs a future cache miss.
+		_ = cache.Put(repoPath, info)
+	}
+	return info, nil
+}
+
+// readDetailedStatus collects status details from the Git CLI.
+func readDetailedStatus(ctx context.Context, repoPath string) (StatusInfo, error) {
 	var info StatusInfo
 
 	// 1. Get current branch and detached HEAD state
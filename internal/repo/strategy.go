@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/eng618/eng/internal/config"
+)
+
+// PullWithStrategy pulls the current branch using the given strategy ("rebase", "ff-only", or "merge").
+// An empty strategy behaves like "rebase", matching PullLatestCode. When autostash is true,
+// uncommitted work is stashed around the pull as described by PullWithAutostash.
+// It returns git.NoErrAlreadyUpToDate when there was nothing to pull.
+func PullWithStrategy(ctx context.Context, repoPath, strategy string, autostash bool) error {
+	var pull func(ctx context.Context, repoPath string) error
+	switch strategy {
+	case "", config.PullStrategyRebase:
+		pull = PullLatestCode
+	case config.PullStrategyFFOnly:
+		pull = pullFastForwardOnly
+	case config.PullStrategyMerge:
+		pull = pullMerge
+	default:
+		return fmt.Errorf("unknown pull strategy %q (expected rebase, ff-only, or merge)", strategy)
+	}
+
+	if autostash {
+		return pullWithAutostash(ctx, repoPath, pull)
+	}
+	return pull(ctx, repoPath)
+}
+
+// pullFastForwardOnly pulls the current branch, refusing to create a merge or rebase.
+func pullFastForwardOnly(ctx context.Context, repoPath string) error {
+	currentBranch, err := GetCurrentBranch(ctx, repoPath)
+	if err != nil {
+		return err
+	}
+
+	cmd, cancel := execGitCommand(ctx, repoPath, "pull", "--ff-only", "origin", currentBranch)
+	defer cancel()
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(out), "Not possible to fast-forward") ||
+			strings.Contains(string(out), "diverging branches") {
+			return fmt.Errorf("cannot fast-forward %s: local and remote have diverged", currentBranch)
+		}
+		return fmt.Errorf("git pull failed: %w\n%s", err, string(out))
+	}
+
+	if strings.Contains(string(out), "Already up to date.") {
+		return git.NoErrAlreadyUpToDate
+	}
+	return nil
+}
+
+// pullMerge pulls the current branch with a merge commit when needed, aborting the merge on conflict.
+func pullMerge(ctx context.Context, repoPath string) error {
+	currentBranch, err := GetCurrentBranch(ctx, repoPath)
+	if err != nil {
+		return err
+	}
+
+	cmd, cancel := execGitCommand(ctx, repoPath, "pull", "--no-rebase", "--no-edit", "origin", currentBranch)
+	defer cancel()
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		gitDir, _, dirErr := resolveGitDirs(repoPath)
+		if dirErr != nil {
+			gitDir = filepath.Join(repoPath, ".git")
+		}
+		if _, statErr := os.Stat(filepath.Join(gitDir, "MERGE_HEAD")); statErr == nil {
+			cmdAbort, cancelAbort := execGitCommand(ctx, repoPath, "merge", "--abort")
+			_, _ = cmdAbort.CombinedOutput()
+			cancelAbort()
+			return fmt.Errorf("conflict detected: merge aborted. Please resolve conflicts manually in your terminal")
+		}
+		return fmt.Errorf("git pull failed: %w\n%s", err, string(out))
+	}
+
+	if strings.Contains(string(out), "Already up to date.") {
+		return git.NoErrAlreadyUpToDate
+	}
+	return nil
+}
+
+// SwitchToBranch checks out branch, creating a local tracking branch from origin when needed.
+// An empty branch resolves to the repository's default branch (see GetMainBranch).
+// It refuses to switch when the working tree has uncommitted changes and reports whether
+// a checkout actually happened.
+func SwitchToBranch(ctx context.Context, repoPath, branch string) (bool, error) {
+	if branch == "" {
+		mainBranch, err := GetMainBranch(ctx, repoPath)
+		if err != nil {
+			return false, err
+		}
+		branch = mainBranch
+	}
+
+	currentBranch, err := GetCurrentBranch(ctx, repoPath)
+	if err != nil {
+		return false, err
+	}
+	if currentBranch == branch {
+		return false, nil
+	}
+
+	dirty, err := IsDirty(ctx, repoPath)
+	if err != nil {
+		return false, err
+	}
+	if dirty {
+		return false, fmt.Errorf("cannot switch from %s to %s: uncommitted changes", currentBranch, branch)
+	}
+
+	args := []string{"checkout", branch}
+	if !branchExists(ctx, repoPath, branch) {
+		args = []string{"checkout", "-b", branch, "--track", "origin/" + branch}
+	}
+
+	cmd, cancel := execGitCommand(ctx, repoPath, args...)
+	defer cancel()
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return false, fmt.Errorf("failed to switch to %s: %w\n%s", branch, err, strings.TrimSpace(string(out)))
+	}
+	return true, nil
+}
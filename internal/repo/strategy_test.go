@@ -0,0 +1,147 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/eng618/eng/internal/config"
+)
+
+// commitFile writes content to name in dir and commits it.
+func commitFile(t *testing.T, run func(dir string, args ...string), dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	run(dir, "add", name)
+	run(dir, "commit", "-m", "Update "+name)
+}
+
+func TestPullWithStrategy_FFOnly(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	if err := PullWithStrategy(ctx, localDir, config.PullStrategyFFOnly, false); !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		t.Fatalf("Expected NoErrAlreadyUpToDate, got %v", err)
+	}
+
+	commitFile(t, run, remoteDir, "remote.txt", "remote\n")
+	if err := PullWithStrategy(ctx, localDir, config.PullStrategyFFOnly, false); err != nil {
+		t.Fatalf("Fast-forward pull failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(localDir, "remote.txt")); err != nil {
+		t.Errorf("Expected remote change to be pulled: %v", err)
+	}
+
+	// Diverge: both sides commit different files.
+	commitFile(t, run, remoteDir, "remote2.txt", "remote\n")
+	commitFile(t, run, localDir, "local.txt", "local\n")
+
+	err := PullWithStrategy(ctx, localDir, config.PullStrategyFFOnly, false)
+	if err == nil || !strings.Contains(err.Error(), "diverged") {
+		t.Fatalf("Expected diverged error, got %v", err)
+	}
+}
+
+func TestPullWithStrategy_Merge(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	commitFile(t, run, remoteDir, "remote.txt", "remote\n")
+	commitFile(t, run, localDir, "local.txt", "local\n")
+
+	if err := PullWithStrategy(ctx, localDir, config.PullStrategyMerge, false); err != nil {
+		t.Fatalf("Merge pull failed: %v", err)
+	}
+	for _, name := range []string{"remote.txt", "local.txt"} {
+		if _, err := os.Stat(filepath.Join(localDir, name)); err != nil {
+			t.Errorf("Expected %s after merge: %v", name, err)
+		}
+	}
+
+	cmd, cancel := execGitCommand(ctx, localDir, "rev-list", "--parents", "-n", "1", "HEAD")
+	defer cancel()
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("rev-list failed: %v", err)
+	}
+	if parents := strings.Fields(string(out)); len(parents) != 3 {
+		t.Errorf("Expected HEAD to be a merge commit, got %q", strings.TrimSpace(string(out)))
+	}
+}
+
+func TestPullWithStrategy_MergeConflictAborts(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	commitFile(t, run, remoteDir, "test.txt", "remote\n")
+	commitFile(t, run, localDir, "test.txt", "local\n")
+
+	err := PullWithStrategy(ctx, localDir, config.PullStrategyMerge, false)
+	if err == nil || !strings.Contains(err.Error(), "merge aborted") {
+		t.Fatalf("Expected merge aborted error, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(localDir, ".git", "MERGE_HEAD")); !os.IsNotExist(statErr) {
+		t.Errorf("Expected merge to be aborted, MERGE_HEAD still present")
+	}
+}
+
+func TestPullWithStrategy_Unknown(t *testing.T) {
+	err := PullWithStrategy(context.Background(), t.TempDir(), "squash", false)
+	if err == nil || !strings.Contains(err.Error(), "unknown pull strategy") {
+		t.Fatalf("Expected unknown strategy error, got %v", err)
+	}
+}
+
+func TestSwitchToBranch(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	run(remoteDir, "checkout", "-b", "develop")
+	commitFile(t, run, remoteDir, "develop.txt", "develop\n")
+	run(remoteDir, "checkout", "main")
+	run(localDir, "fetch", "origin")
+
+	switched, err := SwitchToBranch(ctx, localDir, "develop")
+	if err != nil {
+		t.Fatalf("SwitchToBranch failed: %v", err)
+	}
+	if !switched {
+		t.Error("Expected a checkout to happen")
+	}
+	if branch, _ := GetCurrentBranch(ctx, localDir); branch != "develop" {
+		t.Errorf("Expected to be on develop, got %s", branch)
+	}
+	if _, err := os.Stat(filepath.Join(localDir, "develop.txt")); err != nil {
+		t.Errorf("Expected develop.txt from origin/develop: %v", err)
+	}
+
+	switched, err = SwitchToBranch(ctx, localDir, "develop")
+	if err != nil || switched {
+		t.Errorf("Expected no-op when already on develop, got switched=%v err=%v", switched, err)
+	}
+
+	// Dirty trees are never switched.
+	if err := os.WriteFile(filepath.Join(localDir, "develop.txt"), []byte("edit\n"), 0o644); err != nil {
+		t.Fatalf("Failed to edit file: %v", err)
+	}
+	if _, err := SwitchToBranch(ctx, localDir, "main"); err == nil {
+		t.Error("Expected an error when switching with uncommitted changes")
+	}
+	run(localDir, "checkout", "--", "develop.txt")
+
+	// An empty branch resolves to the default branch.
+	switched, err = SwitchToBranch(ctx, localDir, "")
+	if err != nil || !switched {
+		t.Fatalf("Expected switch to default branch, got switched=%v err=%v", switched, err)
+	}
+	if branch, _ := GetCurrentBranch(ctx, localDir); branch != "main" {
+		t.Errorf("Expected to be on main, got %s", branch)
+	}
+}
@@ -0,0 +1,194 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/eng618/eng/internal/config"
+)
+
+// Worktree describes one working tree attached to a repository, as reported by
+// `git worktree list --porcelain`.
+type Worktree struct {
+	Path     string `json:"path" yaml:"path"`
+	Branch   string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Head     string `json:"head,omitempty" yaml:"head,omitempty"`
+	Detached bool   `json:"detached,omitempty" yaml:"detached,omitempty"`
+	Bare     bool   `json:"bare,omitempty" yaml:"bare,omitempty"`
+	Prunable bool   `json:"prunable,omitempty" yaml:"prunable,omitempty"`
+}
+
+// ListWorktrees returns every working tree of the repository at repoPath.
+// The first entry is always the main working tree.
+func ListWorktrees(ctx context.Context, repoPath string) ([]Worktree, error) {
+	cmd, cancel := execGitCommand(ctx, repoPath, "worktree", "list", "--porcelain")
+	defer cancel()
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	return parseWorktreeList(string(out)), nil
+}
+
+// parseWorktreeList parses the porcelain output of `git worktree list`.
+func parseWorktreeList(output string) []Worktree {
+	var worktrees []Worktree
+	var current *Worktree
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "worktree":
+			worktrees = append(worktrees, Worktree{Path: value})
+			current = &worktrees[len(worktrees)-1]
+		case "HEAD":
+			if current != nil {
+				current.Head = value
+			}
+		case "branch":
+			if current != nil {
+				current.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "detached":
+			if current != nil {
+				current.Detached = true
+			}
+		case "bare":
+			if current != nil {
+				current.Bare = true
+			}
+		case "prunable":
+			if current != nil {
+				current.Prunable = true
+			}
+		}
+	}
+	return worktrees
+}
+
+// AddWorktree creates a linked working tree for branch at worktreePath. An existing local
+// branch is checked out as is; otherwise a branch tracking origin/<branch> is created when
+// the remote has one, or a new branch is started from the current HEAD.
+func AddWorktree(ctx context.Context, repoPath, worktreePath, branch string) error {
+	if _, err := os.Stat(worktreePath); err == nil {
+		return fmt.Errorf("%s already exists", worktreePath)
+	}
+
+	var args []string
+	switch {
+	case branchExists(ctx, repoPath, branch):
+		args = []string{"worktree", "add", worktreePath, branch}
+	case remoteBranchExists(ctx, repoPath, branch):
+		args = []string{"worktree", "add", "--track", "-b", branch, worktreePath, "origin/" + branch}
+	default:
+		args = []string{"worktree", "add", "-b", branch, worktreePath}
+	}
+
+	cmd, cancel := execGitCommand(ctx, repoPath, args...)
+	defer cancel()
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add worktree for %s: %w\n%s", branch, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// RemoveWorktree removes the linked working tree at worktreePath. Without force, git refuses
+// to remove a worktree with uncommitted changes. The branch itself is kept.
+func RemoveWorktree(ctx context.Context, repoPath, worktreePath string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	args = append(args, worktreePath)
+
+	cmd, cancel := execGitCommand(ctx, repoPath, args...)
+	defer cancel()
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w\n%s", worktreePath, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// IsLinkedWorktree reports whether path is a linked worktree, whose .git is a file
+// pointing into another repository's git directory.
+func IsLinkedWorktree(path string) bool {
+	info, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil && !info.IsDir()
+}
+
+// IntegrateUpstream brings the current branch of repoPath up to date with its already fetched
+// upstream, without contacting the remote. It is used for worktrees that share the object store
+// of a repository fetched moments before. The strategy matches PullWithStrategy; conflicting
+// rebases and merges are aborted. It returns git.NoErrAlreadyUpToDate when nothing changed.
+func IntegrateUpstream(ctx context.Context, repoPath, strategy string) error {
+	var args []string
+	switch strategy {
+	case "", config.PullStrategyRebase:
+		args = []string{"rebase", "@{upstream}"}
+	case config.PullStrategyFFOnly:
+		args = []string{"merge", "--ff-only", "@{upstream}"}
+	case config.PullStrategyMerge:
+		args = []string{"merge", "--no-edit", "@{upstream}"}
+	default:
+		return fmt.Errorf("unknown pull strategy %q (expected rebase, ff-only, or merge)", strategy)
+	}
+
+	behindCmd, behindCancel := execGitCommand(ctx, repoPath, "rev-list", "--count", "HEAD..@{upstream}")
+	behindOut, err := behindCmd.Output()
+	behindCancel()
+	if err != nil {
+		return fmt.Errorf("no upstream configured for the current branch")
+	}
+	if strings.TrimSpace(string(behindOut)) == "0" {
+		return git.NoErrAlreadyUpToDate
+	}
+
+	cmd, cancel := execGitCommand(ctx, repoPath, args...)
+	defer cancel()
+
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	if strategy == config.PullStrategyFFOnly {
+		return fmt.Errorf("cannot fast-forward: local and upstream have diverged")
+	}
+
+	gitDir, _, dirErr := resolveGitDirs(repoPath)
+	if dirErr != nil {
+		gitDir = filepath.Join(repoPath, ".git")
+	}
+	for _, op := range []struct{ marker, command string }{
+		{"rebase-merge", "rebase"},
+		{"rebase-apply", "rebase"},
+		{"MERGE_HEAD", "merge"},
+	} {
+		if _, statErr := os.Stat(filepath.Join(gitDir, op.marker)); statErr == nil {
+			abortCmd, abortCancel := execGitCommand(ctx, repoPath, op.command, "--abort")
+			_, _ = abortCmd.CombinedOutput()
+			abortCancel()
+			return fmt.Errorf(
+				"conflict detected: %s aborted. Please resolve conflicts manually in your terminal",
+				op.command,
+			)
+		}
+	}
+	return fmt.Errorf("failed to update from upstream: %w\n%s", err, strings.TrimSpace(string(out)))
+}
+
+// remoteBranchExists reports whether origin/<branch> is known locally.
+func remoteBranchExists(ctx context.Context, repoPath, branch string) bool {
+	cmd, cancel := execGitCommand(ctx, repoPath, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch)
+	defer cancel()
+	return cmd.Run() == nil
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/eng618/eng/internal/config"
+)
+
+func TestParseWorktreeList(t *testing.T) {
+	output := `worktree /dev/app
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /dev/app@feature-x
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/feature/x
+
+worktree /dev/app@review
+HEAD 3333333333333333333333333333333333333333
+detached
+prunable gitdir file points to non-existent location
+`
+	worktrees := parseWorktreeList(output)
+	if len(worktrees) != 3 {
+		t.Fatalf("Expected 3 worktrees, got %d: %+v", len(worktrees), worktrees)
+	}
+	if worktrees[0].Path != "/dev/app" || worktrees[0].Branch != "main" {
+		t.Errorf("Unexpected main worktree: %+v", worktrees[0])
+	}
+	if worktrees[1].Branch != "feature/x" || worktrees[1].Head == "" {
+		t.Errorf("Unexpected linked worktree: %+v", worktrees[1])
+	}
+	if !worktrees[2].Detached || !worktrees[2].Prunable || worktrees[2].Branch != "" {
+		t.Errorf("Expected detached, prunable worktree, got %+v", worktrees[2])
+	}
+}
+
+func TestWorktreeLifecycle(t *testing.T) {
+	ctx := context.Background()
+	remoteDir, localDir, run := setupRemoteAndClone(t)
+
+	run(remoteDir, "branch", "feature")
+	run(localDir, "fetch", "origin")
+
+	parent := t.TempDir()
+	trackingPath := filepath.Join(parent, "app@feature")
+	newPath := filepath.Join(parent, "app@spike")
+
+	if err := AddWorktree(ctx, localDir, trackingPath, "feature"); err != nil {
+		t.Fatalf("AddWorktree (remote branch) failed: %v", err)
+	}
+	if err := AddWorktree(ctx, localDir, newPath, "spike"); err != nil {
+		t.Fatalf("AddWorktree (new branch) failed: %v", err)
+	}
+	if err := AddWorktree(ctx, localDir, newPath, "other"); err == nil {
+		t.Error("Expected AddWorktree to refuse an existing path")
+	}
+	if !IsLinkedWorktree(trackingPath) || IsLinkedWorktree(localDir) {
+		t.Error("Expected only the added directories to be linked worktrees")
+	}
+
+	worktrees, err := ListWorktrees(ctx, localDir)
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	if len(worktrees) != 3 {
+		t.Fatalf("Expected 3 worktrees, got %+v", worktrees)
+	}
+	if worktrees[1].Branch != "feature" || worktrees[2].Branch != "spike" {
+		t.Errorf("Unexpected worktree branches: %+v", worktrees)
+	}
+
+	// A commit on the remote, fetched once through the main clone, reaches the worktree
+	// without another fetch.
+	run(remoteDir, "checkout", "feature")
+	commitFile(t, run, remoteDir, "feature.txt", "feature\n")
+	run(localDir, "fetch", "origin")
+
+	if err := IntegrateUpstream(ctx, trackingPath, config.PullStrategyFFOnly); err != nil {
+		t.Fatalf("IntegrateUpstream failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(trackingPath, "feature.txt")); err != nil {
+		t.Errorf("Expected upstream commit in worktree: %v", err)
+	}
+	if err := IntegrateUpstream(ctx, trackingPath, config.PullStrategyRebase); !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		t.Errorf("Expected NoErrAlreadyUpToDate, got %v", err)
+	}
+	if err := IntegrateUpstream(ctx, newPath, config.PullStrategyRebase); err == nil {
+		t.Error("Expected an error for a branch without upstream")
+	}
+
+	if err := os.WriteFile(filepath.Join(newPath, "wip.txt"), []byte("wip\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := RemoveWorktree(ctx, localDir, newPath, false); err == nil {
+		t.Error("Expected RemoveWorktree to refuse a dirty worktree without force")
+	}
+	if err := RemoveWorktree(ctx, localDir, newPath, true); err != nil {
+		t.Fatalf("RemoveWorktree with force failed: %v", err)
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Errorf("Expected worktree directory to be removed, stat err: %v", err)
+	}
+}
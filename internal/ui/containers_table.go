@@ -253,3 +253,190 @@ func clamp(val, min, max int) int {
 	}
 	return val
 }
+
+// RenderHealthTable renders a Lip Gloss table of the readiness of each service of the given stacks
+// while waiting for them to become healthy.
+func RenderHealthTable(
+	stackNames []string,
+	details map[string][]containers.ContainerDetail,
+	termWidth int,
+) string {
+	if termWidth <= 0 {
+		termWidth = GetTerminalWidth()
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Background).
+		Background(theme.Primary).
+		Padding(0, 1)
+
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+	borderStyle := lipgloss.NewStyle().Foreground(theme.Primary)
+
+	// 5 columns = 6 border chars + 10 padding spaces
+	availWidth := termWidth - 16
+	if availWidth < 50 {
+		availWidth = 50
+	}
+
+	colReady := 7
+	colStatus := 22
+	colStack := clamp(availWidth*20/100, 8, 20)
+	colService := clamp(availWidth*25/100, 10, 24)
+	colName := availWidth - (colReady + colStatus + colStack + colService)
+	if colName < 14 {
+		colName = 14
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		Headers("STACK", "SERVICE", "CONTAINER", "STATUS", "READY").
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == 0 {
+				return headerStyle
+			}
+			s := cellStyle
+			switch col {
+			case 0:
+				return s.MaxWidth(colStack)
+			case 1:
+				return s.MaxWidth(colService)
+			case 2:
+				return s.MaxWidth(colName)
+			case 3:
+				return s.MaxWidth(colStatus)
+			case 4:
+				return s.Width(colReady).Align(lipgloss.Center)
+			}
+			return s
+		})
+
+	ready, total := 0, 0
+	for _, stackName := range stackNames {
+		containerList := details[stackName]
+		if len(containerList) == 0 {
+			total++
+			t.Row(
+				theme.BoldText.Render(truncateString(stackName, colStack)),
+				"-",
+				theme.MutedText.Render("waiting for containers"),
+				"",
+				theme.MutedText.Render("…"),
+			)
+			continue
+		}
+		for _, c := range containerList {
+			total++
+			mark := theme.MutedText.Render("…")
+			if containers.ContainerReady(c) {
+				ready++
+				mark = theme.SuccessText.Render("✓")
+			}
+			t.Row(
+				theme.BoldText.Render(truncateString(stackName, colStack)),
+				truncateString(c.Service, colService),
+				theme.MutedText.Render(truncateString(c.Name, colName)),
+				formatContainerStatusBadge(c.State, c.Health),
+				mark,
+			)
+		}
+	}
+
+	title := theme.PrimaryText.Bold(true).Render(fmt.Sprintf("Waiting for services: %d/%d ready", ready, total))
+	return fmt.Sprintf("%s\n%s", title, t.Render())
+}
+
+// RenderEnvDiffTable renders a Lip Gloss table of the keys whose values differ between the env files
+// of a stack for envA and envB. Values are masked unless showValues is set.
+func RenderEnvDiffTable(diff containers.EnvDiff, envA, envB string, showValues bool, termWidth int) string {
+	if termWidth <= 0 {
+		termWidth = GetTerminalWidth()
+	}
+
+	var differ []containers.EnvDiffEntry
+	for _, e := range diff.Entries {
+		if e.Differ {
+			differ = append(differ, e)
+		}
+	}
+	title := theme.PrimaryText.Bold(true).Render(fmt.Sprintf("Stack %s: %s ↔ %s", diff.Stack, envA, envB))
+	summary := fmt.Sprintf("%d key(s) differ, %d identical", len(differ), len(diff.Entries)-len(differ))
+	if len(differ) == 0 {
+		return fmt.Sprintf("%s\n%s", title, theme.MutedText.Render(summary))
+	}
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Background).
+		Background(theme.Primary).
+		Padding(0, 1)
+
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+	borderStyle := lipgloss.NewStyle().Foreground(theme.Primary)
+
+	// 4 columns = 5 border chars + 8 padding spaces
+	availWidth := termWidth - 13
+	if availWidth < 50 {
+		availWidth = 50
+	}
+
+	colStatus := 14
+	colKey := clamp(availWidth*30/100, 12, 32)
+	colValue := (availWidth - colKey - colStatus) / 2
+	if colValue < 10 {
+		colValue = 10
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(borderStyle).
+		Headers("KEY", strings.ToUpper(envA), strings.ToUpper(envB), "STATUS").
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == 0 {
+				return headerStyle
+			}
+			s := cellStyle
+			switch col {
+			case 0:
+				return s.MaxWidth(colKey)
+			case 1, 2:
+				return s.MaxWidth(colValue)
+			case 3:
+				return s.MaxWidth(colStatus)
+			}
+			return s
+		})
+
+	for _, e := range differ {
+		status := theme.PrimaryText.Render("changed")
+		switch {
+		case !e.InA:
+			status = theme.ErrorText.Render("only in " + envB)
+		case !e.InB:
+			status = theme.ErrorText.Render("only in " + envA)
+		}
+		t.Row(
+			theme.BoldText.Render(truncateString(e.Key, colKey)),
+			formatEnvValue(e.A, e.InA, showValues, colValue),
+			formatEnvValue(e.B, e.InB, showValues, colValue),
+			status,
+		)
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s", title, t.Render(), theme.MutedText.Render(summary))
+}
+
+// formatEnvValue renders an env file value for the diff table, masked unless showValues is set.
+func formatEnvValue(value string, set, showValues bool, maxLen int) string {
+	switch {
+	case !set:
+		return theme.MutedText.Render("(not set)")
+	case value == "":
+		return theme.MutedText.Render("(empty)")
+	case !showValues:
+		return theme.MutedText.Render("••••••")
+	}
+	return truncateString(value, maxLen)
+}
@@ -44,3 +44,46 @@ func TestRenderContainerTable_Widths(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderHealthTable(t *testing.T) {
+	details := map[string][]containers.ContainerDetail{
+		"web": {
+			{Name: "web-app-1", Service: "app", State: "running"},
+			{Name: "web-db-1", Service: "db", State: "running", Health: "starting"},
+		},
+	}
+
+	for _, w := range []int{60, 100} {
+		out := RenderHealthTable([]string{"web", "api"}, details, w)
+		for _, want := range []string{"1/3 ready", "web-app-1", "db", "waiting for containers"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected health table at width %d to contain %q, got:\n%s", w, want, out)
+			}
+		}
+	}
+}
+
+func TestRenderEnvDiffTable(t *testing.T) {
+	diff := containers.EnvDiff{
+		Stack: "web",
+		Entries: []containers.EnvDiffEntry{
+			{Key: "IMAGE", A: "web", B: "web", InA: true, InB: true},
+			{Key: "PASSWORD", A: "dev-secret", B: "prod-secret", InA: true, InB: true, Differ: true},
+			{Key: "TAG", B: "1.2", InB: true, Differ: true},
+		},
+	}
+
+	out := RenderEnvDiffTable(diff, "dev", "prod", false, 100)
+	if strings.Contains(out, "secret") || strings.Contains(out, "IMAGE") {
+		t.Errorf("expected masked values and only differing keys, got:\n%s", out)
+	}
+	for _, want := range []string{"PASSWORD", "changed", "(not set)", "only in prod", "2 key(s) differ, 1 identical"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in diff table, got:\n%s", want, out)
+		}
+	}
+
+	if out := RenderEnvDiffTable(diff, "dev", "prod", true, 100); !strings.Contains(out, "prod-secret") {
+		t.Errorf("expected values with showValues, got:\n%s", out)
+	}
+}
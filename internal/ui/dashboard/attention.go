@@ -0,0 +1,141 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/eng618/eng/internal/config"
+)
+
+// attentionTitle is the list title of the synthetic entry aggregating repositories across projects.
+const attentionTitle = "⚠ Needs attention"
+
+// attentionSeverity ranks how urgently a repository needs attention; 0 means it does not.
+// Conflicts block all work, a failed status check or an interrupted operation leaves the repository
+// in an unknown or unusual state, as does a detached HEAD, and being behind or dirty only needs a
+// pull or commit.
+func attentionSeverity(s RepoStatus) int {
+	switch {
+	case s.Loading:
+		return 0
+	case s.Error != nil:
+		return 4
+	case !s.IsCloned:
+		return 0
+	case s.ConflictCount > 0:
+		return 5
+	case s.OngoingOp != "":
+		return 4
+	case s.IsDetached:
+		return 3
+	case s.BehindCount > 0:
+		return 2
+	case s.IsDirty:
+		return 1
+	}
+	return 0
+}
+
+// attentionReasons lists why a repository needs attention, most severe first.
+func attentionReasons(s RepoStatus) []string {
+	var reasons []string
+	if s.Error != nil {
+		reasons = append(reasons, "error: "+s.Error.Error())
+	}
+	if s.ConflictCount > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d conflicted", s.ConflictCount))
+	}
+	if s.OngoingOp != "" {
+		reasons = append(reasons, s.OngoingOp+" in progress")
+	}
+	if s.IsDetached {
+		reasons = append(reasons, "detached HEAD")
+	}
+	if s.BehindCount > 0 {
+		reasons = append(reasons, fmt.Sprintf("behind %d", s.BehindCount))
+	}
+	if s.IsDirty {
+		reasons = append(reasons, "uncommitted changes")
+	}
+	return reasons
+}
+
+// attentionSelected reports whether the "Needs attention" entry is selected.
+func (m Model) attentionSelected() bool {
+	item, ok := m.list.SelectedItem().(ProjectItem)
+	return ok && item.Attention
+}
+
+// trackedRepos returns the repositories whose statuses the right pane depends on: the repositories of
+// the selected project, or of every project for the "Needs attention" entry.
+func (m Model) trackedRepos() []repoRef {
+	item, ok := m.list.SelectedItem().(ProjectItem)
+	if !ok {
+		return nil
+	}
+	projects := []config.Project{item.Project}
+	if item.Attention {
+		projects = m.projects
+	}
+
+	var refs []repoRef
+	for _, p := range projects {
+		for _, r := range p.Repos {
+			refs = append(refs, repoRef{Project: p.Name, Repo: r})
+		}
+	}
+	return refs
+}
+
+// visibleRepos returns the repositories listed in the right pane. For the "Needs attention" entry
+// these are the tracked repositories that need attention, most severe first.
+func (m Model) visibleRepos() []repoRef {
+	refs := m.trackedRepos()
+	if !m.attentionSelected() {
+		return refs
+	}
+
+	var needy []repoRef
+	for _, ref := range refs {
+		if attentionSeverity(m.repoStatuses[ref.Key()]) > 0 {
+			needy = append(needy, ref)
+		}
+	}
+	sort.SliceStable(needy, func(i, j int) bool {
+		si, sj := attentionSeverity(m.repoStatuses[needy[i].Key()]), attentionSeverity(m.repoStatuses[needy[j].Key()])
+		if si != sj {
+			return si > sj
+		}
+		return m.repoLabel(needy[i]) < m.repoLabel(needy[j])
+	})
+	return needy
+}
+
+// pendingAttentionChecks counts the tracked repositories whose status is still loading.
+func (m Model) pendingAttentionChecks() int {
+	pending := 0
+	for _, ref := range m.trackedRepos() {
+		if s, ok := m.repoStatuses[ref.Key()]; !ok || s.Loading {
+			pending++
+		}
+	}
+	return pending
+}
+
+// repoLabel returns the name a repository is listed under, prefixed with its project in the
+// "Needs attention" view.
+func (m Model) repoLabel(ref repoRef) string {
+	if m.attentionSelected() {
+		return ref.Project + "/" + ref.Name()
+	}
+	return ref.Name()
+}
+
+// attentionHeader returns the right pane title of the "Needs attention" entry.
+func (m Model) attentionHeader() string {
+	title := fmt.Sprintf("Needs attention (%d)", len(m.visibleRepos()))
+	if pending := m.pendingAttentionChecks(); pending > 0 {
+		title += fmt.Sprintf(" · checking %d more...", pending)
+	}
+	return title
+}
@@ -0,0 +1,155 @@
+package dashboard
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/eng618/eng/internal/log"
+)
+
+// bulkActionConcurrency bounds how many marked repositories are worked on at once.
+const bulkActionConcurrency = 4
+
+// markerPrefix is shown before the names of marked repositories.
+const markerPrefix = "● "
+
+// toggleMark marks the selected repository for bulk actions, or unmarks it.
+func (m *Model) toggleMark() {
+	ref, ok := m.selectedRepo()
+	if !ok {
+		return
+	}
+	if m.marked[ref.Key()] {
+		delete(m.marked, ref.Key())
+	} else {
+		m.marked[ref.Key()] = true
+	}
+}
+
+// markedRepos returns the marked repositories of all projects, in configuration order.
+func (m Model) markedRepos() []repoRef {
+	var refs []repoRef
+	for _, p := range m.projects {
+		for _, r := range p.Repos {
+			ref := repoRef{Project: p.Name, Repo: r}
+			if m.marked[ref.Key()] {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// markedLabel returns label prefixed with the marker when ref is marked.
+func (m Model) markedLabel(ref repoRef, label string) string {
+	if m.marked[ref.Key()] {
+		return markerPrefix + label
+	}
+	return label
+}
+
+// runBulkActions runs the repository actions of items concurrently. Every worker logs through the
+// same pipe, prefixing its lines with the repository name, and counts its item in m.bulkCompleted
+// before logging the result so the progress bar advances with the log.
+func (m Model) runBulkActions(items []ActionItem) (Model, tea.Cmd) {
+	m.totalActions = len(items)
+	m.completedActions = 0
+	m.actionState = fmt.Sprintf("%s %d marked repositories...", actionNames[items[0].Action], len(items))
+	completed := &atomic.Int32{}
+	m.bulkCompleted = completed
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		// Intercept internal log output
+		log.SetWriters(pw, pw)
+		defer log.ResetWriters()
+
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			failed []error
+		)
+		sem := make(chan struct{}, bulkActionConcurrency)
+		for _, item := range items {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				name := actionTargetName(item)
+				if reason := skipReason(item, name); reason != "" {
+					completed.Add(1)
+					log.Warn("%s", reason)
+					return
+				}
+
+				log.Info("%s %s...", actionNames[item.Action], name)
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				out := &prefixWriter{prefix: name + ": ", w: pw}
+				err := runRepoAction(ctx, item, out)
+				out.Flush()
+
+				completed.Add(1)
+				if err != nil {
+					log.Error("%s: %v", name, err)
+					mu.Lock()
+					failed = append(failed, fmt.Errorf("%s: %w", name, err))
+					mu.Unlock()
+					return
+				}
+				log.Success("%s: %s", name, repoActionDone[item.Action])
+			}()
+		}
+		wg.Wait()
+
+		if len(failed) > 0 {
+			pw.CloseWithError(fmt.Errorf("%d of %d failed, %w", len(failed), len(items), failed[0]))
+		} else {
+			pw.Close()
+		}
+	}()
+
+	scanner := bufio.NewScanner(pr)
+	return m, readLogCmd(scanner)
+}
+
+// prefixWriter prefixes each line written to it, so output of concurrent actions can be told apart.
+type prefixWriter struct {
+	prefix string
+	w      io.Writer
+	buf    []byte
+}
+
+// Write writes the complete lines of p, each in a single write, and buffers the rest.
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		if _, err := io.WriteString(p.w, p.prefix+string(p.buf[:i+1])); err != nil {
+			return len(b), err
+		}
+		p.buf = p.buf[i+1:]
+	}
+}
+
+// Flush writes a buffered incomplete line.
+func (p *prefixWriter) Flush() {
+	if len(p.buf) > 0 {
+		_, _ = io.WriteString(p.w, p.prefix+string(p.buf)+"\n")
+		p.buf = nil
+	}
+}
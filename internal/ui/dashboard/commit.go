@@ -0,0 +1,247 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/repo"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+// commitFormWidth is the maximum width of the commit form modal.
+const commitFormWidth = 72
+
+// commitPlan collects the answers of the commit form. The model holds it by pointer so the
+// form's value bindings stay valid when Bubble Tea copies the model.
+type commitPlan struct {
+	RepoURL     string
+	FullPath    string
+	Branch      string
+	HasUpstream bool
+	Files       []repo.FileChange
+	Selected    []string
+	Message     string
+	Push        bool
+}
+
+// stagePaths returns the selected files, which are staged before committing.
+func (p *commitPlan) stagePaths() []string {
+	return p.Selected
+}
+
+// unstagePaths returns the files that are staged but were deselected in the form.
+func (p *commitPlan) unstagePaths() []string {
+	selected := make(map[string]bool, len(p.Selected))
+	for _, path := range p.Selected {
+		selected[path] = true
+	}
+	var paths []string
+	for _, f := range p.Files {
+		if f.Staged() && !selected[f.Path] {
+			paths = append(paths, f.Path)
+		}
+	}
+	return paths
+}
+
+// startCommit opens the commit form for the selected repository.
+func (m *Model) startCommit() tea.Cmd {
+	ref, ok := m.selectedRepo()
+	if !ok || !m.repoFocused() {
+		return nil
+	}
+	fullPath := ref.FullPath(m.devPath)
+
+	if !repo.IsCloned(fullPath) {
+		m.notificationID++
+		m.notification = fmt.Sprintf("Not cloned: %s", ref.Name())
+		m.notificationStyle = notificationErrorStyle
+		m.notificationType = NotifyError
+		return m.delayClearNotificationCmd(m.notificationID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	files, err := repo.ChangedFiles(ctx, fullPath)
+	if err != nil {
+		m.notificationID++
+		m.notification = fmt.Sprintf("Commit error: %v", err)
+		m.notificationStyle = notificationErrorStyle
+		m.notificationType = NotifyError
+		return m.delayClearNotificationCmd(m.notificationID)
+	}
+	if len(files) == 0 {
+		m.notificationID++
+		m.notification = "Nothing to commit: working tree clean"
+		m.notificationStyle = notificationWarnStyle
+		m.notificationType = NotifyWarn
+		return m.delayClearNotificationCmd(m.notificationID)
+	}
+
+	info, err := repo.RefreshDetailedStatus(ctx, fullPath)
+	if err != nil {
+		m.notificationID++
+		m.notification = fmt.Sprintf("Commit error: %v", err)
+		m.notificationStyle = notificationErrorStyle
+		m.notificationType = NotifyError
+		return m.delayClearNotificationCmd(m.notificationID)
+	}
+
+	// Pushing is opt-in for protected branches and detached HEADs.
+	protected := !info.IsDetached && config.GetGitConfig().IsProtectedBranch(info.Branch)
+	plan := &commitPlan{
+		RepoURL:     ref.Repo.URL,
+		FullPath:    fullPath,
+		Branch:      info.Branch,
+		HasUpstream: info.HasUpstream,
+		Files:       files,
+		Push:        !protected && !info.IsDetached,
+	}
+	m.commitPlan = plan
+	m.commitForm = newCommitForm(plan, m.conventionalCommits, protected)
+	m.commitForm.WithWidth(min(commitFormWidth, m.windowWidth-8))
+	return m.commitForm.Init()
+}
+
+// newCommitForm builds the file selection, message, and push steps of the commit flow.
+func newCommitForm(plan *commitPlan, conventional, protected bool) *huh.Form {
+	options := make([]huh.Option[string], len(plan.Files))
+	for i, f := range plan.Files {
+		options[i] = huh.NewOption(f.Label(), f.Path).Selected(f.Staged())
+	}
+
+	messageDescription := "Enter moves on, Alt+Enter adds a line."
+	if conventional {
+		messageDescription = "Conventional Commits, e.g. \"feat(ui): add tabs\". " + messageDescription
+	}
+
+	pushTitle := "Push after committing?"
+	if protected {
+		pushTitle = fmt.Sprintf("Push to protected branch %q?", plan.Branch)
+	}
+
+	keymap := huh.NewDefaultKeyMap()
+	keymap.Quit = key.NewBinding(key.WithKeys("esc", "ctrl+c"), key.WithHelp("esc", "cancel"))
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Files to commit").
+				Description("Selected files are staged, deselected files are unstaged.").
+				Options(options...).
+				Height(12).
+				Validate(func(selected []string) error {
+					if len(selected) == 0 {
+						return errors.New("select at least one file")
+					}
+					return nil
+				}).
+				Value(&plan.Selected),
+		),
+		huh.NewGroup(
+			huh.NewText().
+				Title("Commit message").
+				Description(messageDescription).
+				Lines(6).
+				Validate(func(message string) error {
+					if strings.TrimSpace(message) == "" {
+						return errors.New("commit message cannot be empty")
+					}
+					if conventional {
+						return repo.ValidateConventionalCommit(message)
+					}
+					return nil
+				}).
+				Value(&plan.Message),
+		),
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(pushTitle).
+				Value(&plan.Push),
+		),
+	).WithTheme(theme.EngTheme()).WithKeyMap(keymap).WithShowHelp(true)
+}
+
+// updateCommitForm forwards a message to the active commit form and queues the commit once it completes.
+func (m Model) updateCommitForm(msg tea.Msg) (Model, tea.Cmd) {
+	form, cmd := m.commitForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.commitForm = f
+	}
+
+	switch m.commitForm.State {
+	case huh.StateAborted:
+		m.commitForm = nil
+		m.commitPlan = nil
+		m.notificationID++
+		m.notification = "Commit canceled."
+		m.notificationStyle = notificationWarnStyle
+		m.notificationType = NotifyWarn
+		return m, m.delayClearNotificationCmd(m.notificationID)
+	case huh.StateCompleted:
+		plan := m.commitPlan
+		m.commitForm = nil
+		m.commitPlan = nil
+		return m.queueCommit(plan)
+	}
+	return m, cmd
+}
+
+// queueCommit queues the stage, commit, and optional push steps of a completed commit form.
+// Each step after the first is skipped when an earlier step fails.
+func (m Model) queueCommit(plan *commitPlan) (Model, tea.Cmd) {
+	m.actionLogs = []string{}
+	m.hasError = false
+	m.lastError = nil
+	m.actionQueue = []ActionItem{
+		{
+			Action:   "stage",
+			RepoName: plan.RepoURL,
+			FullPath: plan.FullPath,
+			Stage:    plan.stagePaths(),
+			Unstage:  plan.unstagePaths(),
+		},
+		{
+			Action:   "commit",
+			RepoName: plan.RepoURL,
+			FullPath: plan.FullPath,
+			Message:  strings.TrimSpace(plan.Message) + "\n",
+			Chained:  true,
+		},
+	}
+	if plan.Push {
+		m.actionQueue = append(m.actionQueue, ActionItem{
+			Action:      "push",
+			RepoName:    plan.RepoURL,
+			FullPath:    plan.FullPath,
+			SetUpstream: !plan.HasUpstream,
+			Chained:     true,
+		})
+	}
+
+	m.totalActions = len(m.actionQueue)
+	m.completedActions = 0
+
+	var cmd tea.Cmd
+	m, cmd = m.popAndRunNextAction()
+	return m, tea.Batch(m.spinner.Tick, cmd)
+}
+
+// skipChainedActions drops the queued actions that depend on the action that just failed.
+// It returns the number of actions skipped.
+func (m *Model) skipChainedActions() int {
+	skipped := 0
+	for len(m.actionQueue) > 0 && m.actionQueue[0].Chained {
+		m.actionQueue = m.actionQueue[1:]
+		skipped++
+	}
+	return skipped
+}
@@ -3,6 +3,7 @@ package dashboard
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -20,14 +21,28 @@ func Run() error {
 		)
 	}
 
-	gitDevPath := config.GetGitConfig().DevPath
-	if gitDevPath == "" {
-		home, _ := os.UserHomeDir()
-		gitDevPath = home + "/Development"
+	gitDevPath := devPathFromConfig()
+
+	dashboardConfig := config.GetDashboardConfig()
+	keys, err := newKeyMap(dashboardConfig.Keymap)
+	if err != nil {
+		return theme.NewActionableError(err, "Valid actions are: "+strings.Join(keyMapActions(), ", ")+".")
+	}
+	layout, err := ParseLayout(dashboardConfig.Layout)
+	if err != nil {
+		return theme.NewActionableError(err, "Set dashboard.layout to horizontal, vertical, or single.")
 	}
-	gitDevPath = os.ExpandEnv(gitDevPath)
 
 	m := NewModel(projects, gitDevPath, config.GetGitConfig().Editor)
+	// Open on what needs fixing across all projects.
+	m.list.Select(0)
+	m.keys = keys
+	m.layout = layout
+	m.conventionalCommits = dashboardConfig.ConventionalCommits
+	if dashboardConfig.AutoRefresh {
+		m.watcher = newRepoWatcher()
+		defer m.watcher.Close()
+	}
 
 	// Use alternate screen buffer
 	p := tea.NewProgram(m, tea.WithAltScreen())
@@ -38,3 +53,13 @@ func Run() error {
 
 	return nil
 }
+
+// devPathFromConfig returns the configured git development folder, defaulting to ~/Development.
+func devPathFromConfig() string {
+	gitDevPath := config.GetGitConfig().DevPath
+	if gitDevPath == "" {
+		home, _ := os.UserHomeDir()
+		gitDevPath = home + "/Development"
+	}
+	return os.ExpandEnv(gitDevPath)
+}
@@ -2,8 +2,11 @@ package dashboard
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -12,7 +15,11 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/eng618/eng/internal/cmdutil"
 	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/containers"
+	"github.com/eng618/eng/internal/immich"
+	"github.com/eng618/eng/internal/repo"
 )
 
 func TestDashboardResponsiveLayout(t *testing.T) {
@@ -558,6 +565,17 @@ func TestDashboardStatusRendering(t *testing.T) {
 	if !strings.Contains(view, "status: Merge conflicts! (2 files)") {
 		t.Errorf("expected Merge conflicts! (2 files), got view:\n%s", view)
 	}
+
+	// 8. Linked worktrees
+	m.repoStatuses[key] = RepoStatus{
+		IsCloned:  true,
+		Branch:    "main",
+		Worktrees: []string{"feature/login", "hotfix"},
+	}
+	view = render()
+	if !strings.Contains(view, "worktrees: feature/login, hotfix") {
+		t.Errorf("expected worktree line, got view:\n%s", view)
+	}
 }
 
 func TestTableScrollVisibility(t *testing.T) {
@@ -609,3 +627,720 @@ func TestTableScrollVisibility(t *testing.T) {
 		}
 	}
 }
+
+func TestDashboardDetailPane(t *testing.T) {
+	tempDev := t.TempDir()
+	repoPath := filepath.Join(tempDev, "TestProject", "repo1")
+	if err := os.MkdirAll(repoPath, 0o755); err != nil {
+		t.Fatalf("failed to create repo dir: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{
+			"-c", "user.name=Test User", "-c", "user.email=test@example.com",
+			"commit", "--allow-empty", "-m", "Initial commit",
+		},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	projects := []config.Project{
+		{
+			Name:  "TestProject",
+			Repos: []config.ProjectRepo{{URL: "https://github.com/test/repo1"}},
+		},
+	}
+	m := NewModel(projects, tempDev, "")
+	m, _ = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 14})
+	m.focusedPane = FocusRight
+
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.focusedPane != FocusDetail {
+		t.Fatalf("Expected 'd' to open the detail pane, focus is %v", m.focusedPane)
+	}
+	if cmd == nil {
+		t.Fatal("Expected a command loading the repository detail")
+	}
+	if view := m.renderRightPane(); !strings.Contains(view, "Loading changes and history") {
+		t.Errorf("Expected a loading placeholder, got:\n%s", view)
+	}
+
+	m, _ = updateModel(m, cmd())
+	content := strings.Join(m.detailLines(200), "\n")
+	wants := []string{"Changes", "No uncommitted changes", "No upstream branch", "Recent commits", "Initial commit"}
+	for _, want := range wants {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected detail pane to contain %q, got:\n%s", want, content)
+		}
+	}
+	if lines := strings.Split(m.renderRightPane(), "\n"); len(lines) > m.windowHeight-6 {
+		t.Errorf("Expected detail pane to fit in %d lines, got %d", m.windowHeight-6, len(lines))
+	}
+
+	// The content is taller than the pane, so j scrolls and k scrolls back.
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.detailScrollOffset != 1 {
+		t.Errorf("Expected j to scroll the detail pane, offset %d", m.detailScrollOffset)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	if m.detailScrollOffset != 0 {
+		t.Errorf("Expected k to stop at the top, offset %d", m.detailScrollOffset)
+	}
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.focusedPane != FocusRight {
+		t.Errorf("Expected Esc to return to the repository list, focus is %v", m.focusedPane)
+	}
+}
+
+// runQueuedActions feeds the log and completion messages of queued actions back into the model until the queue drains.
+func runQueuedActions(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	pending := []tea.Cmd{cmd}
+	for len(pending) > 0 {
+		next := pending[0]
+		pending = pending[1:]
+		if next == nil {
+			continue
+		}
+		switch msg := next().(type) {
+		case tea.BatchMsg:
+			pending = append(pending, msg...)
+		case logLineMsg, actionDoneMsg:
+			updated, cmd := m.Update(msg)
+			m = updated.(Model)
+			if m.actionState != "" {
+				pending = append(pending, cmd)
+			}
+		}
+	}
+	if m.actionState != "" {
+		t.Fatalf("Expected the action queue to finish, still %q", m.actionState)
+	}
+	return m
+}
+
+func TestDashboardCommitFlow(t *testing.T) {
+	tempDev := t.TempDir()
+	repoPath := filepath.Join(tempDev, "TestProject", "repo1")
+	if err := os.MkdirAll(repoPath, 0o755); err != nil {
+		t.Fatalf("failed to create repo dir: %v", err)
+	}
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-b", "feature")
+	git("config", "user.name", "Test User")
+	git("config", "user.email", "test@example.com")
+	git("commit", "--allow-empty", "-m", "Initial commit")
+	for _, name := range []string{"keep.txt", "skip.txt"} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(name+"\n"), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	git("add", "skip.txt")
+
+	projects := []config.Project{
+		{
+			Name:  "TestProject",
+			Repos: []config.ProjectRepo{{URL: "https://github.com/test/repo1"}},
+		},
+	}
+	m := NewModel(projects, tempDev, "")
+	m, _ = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.focusedPane = FocusRight
+
+	// C opens the commit form with the changed files; Esc cancels it.
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	m = updated.(Model)
+	if m.commitForm == nil {
+		t.Fatalf("Expected C to open the commit form, notification %q", m.notification)
+	}
+	if view := m.View(); !strings.Contains(view, "Files to commit") || !strings.Contains(view, "keep.txt") {
+		t.Errorf("Expected the commit form listing changed files, got:\n%s", view)
+	}
+	if !m.commitPlan.Push {
+		t.Error("Expected push to default to on for an unprotected branch")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.commitForm != nil || m.notification != "Commit canceled." {
+		t.Fatalf("Expected Esc to cancel the commit, notification %q", m.notification)
+	}
+
+	// Selecting only keep.txt stages it, unstages skip.txt, and commits.
+	plan := &commitPlan{
+		RepoURL:  "https://github.com/test/repo1",
+		FullPath: repoPath,
+		Files: []repo.FileChange{
+			{Path: "keep.txt", Index: '?', Worktree: '?'},
+			{Path: "skip.txt", Index: 'A', Worktree: ' '},
+		},
+		Selected: []string{"keep.txt"},
+		Message:  "feat: add keep file\n\n",
+	}
+	m, cmd := m.queueCommit(plan)
+	if m.totalActions != 2 {
+		t.Fatalf("Expected stage and commit actions without push, got %d", m.totalActions)
+	}
+	m = runQueuedActions(t, m, cmd)
+	if m.notificationType != NotifySuccess {
+		t.Fatalf("Expected the commit to succeed, got %q\nlogs:\n%s", m.notification, strings.Join(m.actionLogs, "\n"))
+	}
+	if subject := git("log", "-1", "--format=%s"); subject != "feat: add keep file" {
+		t.Errorf("Expected the new commit on HEAD, got %q", subject)
+	}
+	if files := git("show", "--name-only", "--format=", "HEAD"); files != "keep.txt" {
+		t.Errorf("Expected only keep.txt in the commit, got %q", files)
+	}
+	if status := git("status", "--porcelain"); status != "?? skip.txt" {
+		t.Errorf("Expected skip.txt to be unstaged, got %q", status)
+	}
+
+	// A failed stage skips the commit and push that depend on it.
+	plan.Selected = []string{"missing.txt"}
+	plan.Push = true
+	m, cmd = m.queueCommit(plan)
+	m = runQueuedActions(t, m, cmd)
+	if m.notificationType != NotifyError {
+		t.Fatalf("Expected the failed stage to be reported, got %q", m.notification)
+	}
+	if m.completedActions != 3 {
+		t.Errorf("Expected skipped actions to count as completed, got %d of %d", m.completedActions, m.totalActions)
+	}
+	if subject := git("log", "-1", "--format=%s"); subject != "feat: add keep file" {
+		t.Errorf("Expected no new commit after a failed stage, got %q", subject)
+	}
+}
+
+func TestRepoWatcher(t *testing.T) {
+	repoPath := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-b", "main")
+	git("-c", "user.name=Test User", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "Initial")
+
+	waitForChange := func(w *repoWatcher) []string {
+		t.Helper()
+		result := make(chan tea.Msg, 1)
+		go func() { result <- w.waitCmd()() }()
+		select {
+		case msg := <-result:
+			changed, ok := msg.(repoChangedMsg)
+			if !ok {
+				t.Fatalf("Expected repoChangedMsg, got %T", msg)
+			}
+			return changed.Keys
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for a repository change")
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name  string
+		start func() *repoWatcher
+	}{
+		{name: "events", start: newRepoWatcher},
+		{name: "polling", start: func() *repoWatcher { return startRepoWatcher(nil, 50*time.Millisecond) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tt.start()
+			defer w.Close()
+			w.Set(map[string]string{"repo": repoPath})
+
+			// A burst of changes is delivered once, after it settles.
+			for i := range 5 {
+				name := filepath.Join(repoPath, fmt.Sprintf("%s-%d.txt", tt.name, i))
+				if err := os.WriteFile(name, []byte("x"), 0o644); err != nil {
+					t.Fatalf("failed to write file: %v", err)
+				}
+			}
+			if keys := waitForChange(w); len(keys) != 1 || keys[0] != "repo" {
+				t.Errorf("Expected one change for repo, got %v", keys)
+			}
+
+			// Switching branches in another terminal is picked up too.
+			git("checkout", "-q", "-b", "topic-"+tt.name)
+			if keys := waitForChange(w); len(keys) != 1 || keys[0] != "repo" {
+				t.Errorf("Expected a change after switching branches, got %v", keys)
+			}
+			git("checkout", "-q", "main")
+			waitForChange(w)
+
+			// Repositories that are no longer visible are not reported.
+			w.Set(map[string]string{})
+			if err := os.WriteFile(filepath.Join(repoPath, tt.name+"-hidden.txt"), []byte("x"), 0o644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			result := make(chan tea.Msg, 1)
+			go func() { result <- w.waitCmd()() }()
+			select {
+			case msg := <-result:
+				t.Errorf("Expected no change for an unwatched repo, got %v", msg)
+			case <-time.After(time.Second):
+			}
+		})
+	}
+}
+
+func TestDashboardNeedsAttention(t *testing.T) {
+	projects := []config.Project{
+		{
+			Name: "Alpha",
+			Repos: []config.ProjectRepo{
+				{URL: "https://github.com/test/clean"},
+				{URL: "https://github.com/test/dirty"},
+				{URL: "https://github.com/test/conflicted"},
+			},
+		},
+		{
+			Name: "Beta",
+			Repos: []config.ProjectRepo{
+				{URL: "https://github.com/test/behind"},
+				{URL: "https://github.com/test/detached"},
+				{URL: "https://github.com/test/missing"},
+				{URL: "https://github.com/test/timedout"},
+			},
+		},
+	}
+	m := NewModel(projects, "/tmp/dev", "")
+	m, _ = updateModel(m, tea.WindowSizeMsg{Width: 140, Height: 30})
+
+	if item, ok := m.list.SelectedItem().(ProjectItem); !ok || item.Project.Name != "Alpha" {
+		t.Fatalf("Expected the first project to be selected initially, got %v", m.list.SelectedItem())
+	}
+	m.list.Select(0)
+	if !m.attentionSelected() {
+		t.Fatal("Expected the first list entry to be the attention view")
+	}
+	if got := len(m.trackedRepos()); got != 7 {
+		t.Fatalf("Expected the attention view to track all 7 repositories, got %d", got)
+	}
+	if view := m.renderRightPane(); !strings.Contains(view, "checking 7 more") {
+		t.Errorf("Expected pending checks in the header, got:\n%s", view)
+	}
+
+	statuses := map[string]RepoStatus{
+		"Alpha/clean":      {IsCloned: true, Branch: "main", HasUpstream: true},
+		"Alpha/dirty":      {IsCloned: true, Branch: "main", IsDirty: true, UnstagedCount: 2},
+		"Alpha/conflicted": {IsCloned: true, Branch: "main", ConflictCount: 1, IsDirty: true, OngoingOp: "merge"},
+		"Beta/behind":      {IsCloned: true, Branch: "main", HasUpstream: true, BehindCount: 3},
+		"Beta/detached":    {IsCloned: true, Branch: "(detached HEAD at abc1234)", IsDetached: true},
+		"Beta/missing":     {IsCloned: false},
+		"Beta/timedout":    {IsCloned: true, Error: context.DeadlineExceeded},
+	}
+	for name, status := range statuses {
+		project, repoName, _ := strings.Cut(name, "/")
+		m, _ = updateModel(m, statusMsg{
+			ProjectName: project,
+			RepoURL:     "https://github.com/test/" + repoName,
+			Status:      status,
+		})
+	}
+
+	var labels []string
+	for _, ref := range m.visibleRepos() {
+		labels = append(labels, m.repoLabel(ref))
+	}
+	want := []string{"Alpha/conflicted", "Beta/timedout", "Beta/detached", "Beta/behind", "Alpha/dirty"}
+	if strings.Join(labels, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected repositories sorted by severity %v, got %v", want, labels)
+	}
+
+	view := m.renderRightPane()
+	for _, want := range []string{"Needs attention (5)", "NEEDS", "1 conflicted, merge", "behind 3", "error: context"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected attention view to contain %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Alpha/clean") || strings.Contains(view, "Beta/missing") {
+		t.Errorf("Expected clean and missing repositories to be hidden, got:\n%s", view)
+	}
+
+	// Repository actions target the selected entry across projects.
+	m.focusedPane = FocusRight
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	ref, ok := m.selectedRepo()
+	if !ok || ref.Project != "Beta" || ref.FullPath("/tmp/dev") != "/tmp/dev/Beta/timedout" {
+		t.Errorf("Expected the timed out Beta repository to be selected, got %+v", ref)
+	}
+
+	// Once everything is resolved the view says so.
+	for name := range statuses {
+		project, repoName, _ := strings.Cut(name, "/")
+		m, _ = updateModel(m, statusMsg{
+			ProjectName: project,
+			RepoURL:     "https://github.com/test/" + repoName,
+			Status:      RepoStatus{IsCloned: true, Branch: "main"},
+		})
+	}
+	if view := m.renderRightPane(); !strings.Contains(view, "Nothing needs attention") {
+		t.Errorf("Expected an all-clear message, got:\n%s", view)
+	}
+}
+
+func TestDashboardServicesViews(t *testing.T) {
+	tempDir := t.TempDir()
+	stackDir := filepath.Join(tempDir, "stacks", "web")
+	if err := os.MkdirAll(stackDir, 0o755); err != nil {
+		t.Fatalf("failed to create stack dir: %v", err)
+	}
+	compose := []byte("services:\n  app:\n    image: nginx\n")
+	if err := os.WriteFile(filepath.Join(stackDir, "docker-compose.yml"), compose, 0o644); err != nil {
+		t.Fatalf("failed to write compose file: %v", err)
+	}
+
+	// A fake docker records its arguments and answers ps and logs.
+	binDir := t.TempDir()
+	dockerLog := filepath.Join(tempDir, "docker.log")
+	script := `#!/bin/sh
+echo "$@" >> "` + dockerLog + `"
+case "$*" in
+  *"ps --format json"*)
+    echo '{"Name":"web-app-1","Service":"app","State":"running","Health":"unhealthy"}'
+    echo '{"Name":"web-db-1","Service":"db","State":"exited","Health":""}';;
+  *" logs "*) echo "app-1  | listening on :80";;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "docker"), []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to write fake docker: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	projects := []config.Project{
+		{
+			Name:  "TestProject",
+			Repos: []config.ProjectRepo{{URL: "https://github.com/test/repo1"}},
+		},
+	}
+	m := NewModel(projects, "/tmp/dev", "")
+	m.stackManager = containers.NewManager(tempDir)
+	m.immichManager = immich.NewManager(filepath.Join(tempDir, "immich-app"))
+	m, _ = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	feed := func(m Model, msg tea.Msg) Model {
+		updated, _ := m.Update(msg)
+		return updated.(Model)
+	}
+
+	// ] opens the Stacks view and loads stacks with their container health.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	m = updated.(Model)
+	if m.view != ViewStacks || !m.stacksLoading {
+		t.Fatalf("Expected ] to open and load the Stacks view, view %v", m.view)
+	}
+	m = feed(m, cmd())
+	view := m.View()
+	for _, want := range []string{"Compose stacks (1)", "web", "Partial", "1/2 running", "1 unhealthy", "web-db-1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the Stacks view to contain %q, got:\n%s", want, view)
+		}
+	}
+	if lines := strings.Split(view, "\n"); len(lines) != 30 {
+		t.Errorf("Expected the Stacks view to be 30 lines high, got %d", len(lines))
+	}
+
+	// R restarts the selected stack through docker compose.
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = runQueuedActions(t, updated.(Model), cmd)
+	if m.notificationType != NotifySuccess {
+		t.Fatalf("Expected the restart to succeed, got %q\nlogs:\n%s", m.notification, strings.Join(m.actionLogs, "\n"))
+	}
+	data, _ := os.ReadFile(dockerLog)
+	if !strings.Contains(string(data), "compose -f "+filepath.Join(stackDir, "docker-compose.yml")+" restart") {
+		t.Errorf("Expected docker compose restart, got:\n%s", data)
+	}
+
+	// D asks before bringing the stack down: any key but y cancels, and y runs docker compose down.
+	composeDown := "compose -f " + filepath.Join(stackDir, "docker-compose.yml") + " down"
+	m = feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if view := m.View(); !strings.Contains(view, "Bring down stack web?") {
+		t.Errorf("Expected a confirmation prompt, got:\n%s", view)
+	}
+	m = feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if data, _ := os.ReadFile(dockerLog); m.actionState != "" || strings.Contains(string(data), composeDown) {
+		t.Errorf("Expected the stack to be left up, notification %q", m.notification)
+	}
+	m = feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = runQueuedActions(t, updated.(Model), cmd)
+	if data, _ := os.ReadFile(dockerLog); !strings.Contains(string(data), composeDown) {
+		t.Errorf("Expected docker compose down after confirming, got:\n%s", data)
+	}
+
+	// L shows the stack's recent logs in place of the view; Esc goes back.
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	m = feed(updated.(Model), cmd())
+	if view := m.View(); !strings.Contains(view, "Logs: web") || !strings.Contains(view, "listening on :80") {
+		t.Errorf("Expected the stack logs, got:\n%s", view)
+	}
+	m = feed(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.serviceLogs != nil {
+		t.Error("Expected Esc to close the logs")
+	}
+
+	// The Immich view refuses actions on a host without the Immich stack.
+	m = feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	if m.view != ViewImmich {
+		t.Fatalf("Expected ] to open the Immich view, view %v", m.view)
+	}
+	m = feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if m.actionState != "" || m.notification != "Immich: not configured on this host" {
+		t.Errorf("Expected Immich actions to be refused, notification %q", m.notification)
+	}
+
+	// [ goes back through the views.
+	m = feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	m = feed(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	if m.view != ViewProjects || !strings.Contains(m.View(), "Project: TestProject") {
+		t.Errorf("Expected [ to return to the Projects view, view %v", m.view)
+	}
+}
+
+func TestDashboardKeymapAndLayouts(t *testing.T) {
+	projects := []config.Project{
+		{
+			Name:  "TestProject",
+			Repos: []config.ProjectRepo{{URL: "https://github.com/test/repo1"}},
+		},
+	}
+
+	keys, err := newKeyMap(map[string][]string{"Fetch": {"F"}, "open": {}, "down": {"n", "down"}})
+	if err != nil {
+		t.Fatalf("newKeyMap failed: %v", err)
+	}
+	_, err = newKeyMap(map[string][]string{"launch": {"x"}})
+	if err == nil || !strings.Contains(err.Error(), "launch") {
+		t.Errorf("Expected an error naming the unknown action, got %v", err)
+	}
+
+	m := NewModel(projects, "/tmp/dev", "")
+	m.keys = keys
+	m, _ = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// Remapped keys drive navigation, and the footer and help follow the bindings.
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyTab})
+	view := m.View()
+	if !strings.Contains(view, "[n/k] Navigate") || !strings.Contains(view, "[F] Fetch") ||
+		strings.Contains(view, "[o] Open") {
+		t.Errorf("Expected the footer to reflect the remapped keys, got:\n%s", view)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if m.actionState != "" {
+		t.Errorf("Expected f to do nothing once fetch is remapped, got %q", m.actionState)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if help := m.View(); !strings.Contains(help, "n/down") || strings.Contains(help, "Open in Finder") {
+		t.Errorf("Expected the help to be generated from the bindings, got:\n%s", help)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	for _, name := range []string{"horizontal", "vertical", "single"} {
+		layout, err := ParseLayout(name)
+		if err != nil {
+			t.Fatalf("ParseLayout(%q) failed: %v", name, err)
+		}
+		m.layout = layout
+		m, _ = updateModel(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+
+		lines := strings.Split(m.View(), "\n")
+		if len(lines) != 30 {
+			t.Errorf("%s layout: expected 30 lines, got %d", name, len(lines))
+		}
+		for idx, line := range lines[1 : len(lines)-1] {
+			if lipgloss.Width(line) != 100 {
+				t.Errorf("%s layout: line %d has width %d, expected 100", name, idx+1, lipgloss.Width(line))
+				break
+			}
+		}
+		if compact := strings.Contains(m.View(), "1: Projects"); compact != (layout == LayoutSingle) {
+			t.Errorf("%s layout: expected tabs only in the single-pane layout", name)
+		}
+	}
+	if _, err := ParseLayout("diagonal"); err == nil {
+		t.Error("Expected an error for an unknown layout")
+	}
+}
+
+func TestDashboardSnapshot(t *testing.T) {
+	tempDev := t.TempDir()
+	repoPath := filepath.Join(tempDev, "Alpha", "dirty")
+	if err := os.MkdirAll(repoPath, 0o755); err != nil {
+		t.Fatalf("failed to create repo dir: %v", err)
+	}
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-b", "main")
+	git("config", "user.name", "Test User")
+	git("config", "user.email", "test@example.com")
+	if err := os.WriteFile(filepath.Join(repoPath, "file.txt"), []byte("one\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	git("add", "file.txt")
+	git("commit", "-m", "Initial commit")
+	if err := os.WriteFile(filepath.Join(repoPath, "file.txt"), []byte("two\n"), 0o644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+
+	projects := []config.Project{
+		{Name: "Alpha", Repos: []config.ProjectRepo{{URL: "https://github.com/test/dirty"}}},
+		{Name: "Beta", Repos: []config.ProjectRepo{{URL: "https://github.com/test/missing"}}},
+	}
+	m := NewModel(projects, tempDev, "")
+	m.collectStatuses(true)
+
+	view := m.renderSnapshot(100)
+	for _, want := range []string{"Needs attention (1)", "Alpha/dirty", "uncommitted changes", "Beta", "Missing"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected snapshot to contain %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "▸") {
+		t.Errorf("Expected no selected repository in the snapshot, got:\n%s", view)
+	}
+
+	doc := m.snapshot()
+	if doc.NeedsAttention != 1 || len(doc.Projects) != 2 {
+		t.Fatalf("Expected 1 repository needing attention across 2 projects, got %+v", doc)
+	}
+	dirty := doc.Projects[0].Repos[0]
+	if !dirty.Cloned || dirty.Branch != "main" || dirty.Unstaged != 1 ||
+		len(dirty.NeedsAttention) != 1 || dirty.NeedsAttention[0] != "uncommitted changes" {
+		t.Errorf("Unexpected snapshot of the dirty repository: %+v", dirty)
+	}
+	if missing := doc.Projects[1].Repos[0]; missing.Cloned || missing.Path != filepath.Join(tempDev, "Beta", "missing") {
+		t.Errorf("Unexpected snapshot of the missing repository: %+v", missing)
+	}
+
+	var buf bytes.Buffer
+	if err := cmdutil.WriteStructured(&buf, cmdutil.OutputYAML, doc); err != nil {
+		t.Fatalf("WriteStructured failed: %v", err)
+	}
+	for _, want := range []string{"needs_attention: 1", "has_upstream: false", "- uncommitted changes"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected YAML snapshot to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestDashboardBulkActions(t *testing.T) {
+	tempDir := t.TempDir()
+	seed := filepath.Join(tempDir, "seed")
+	git := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	git(tempDir, "init", "-b", "main", seed)
+	git(seed, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "Initial")
+	origin := func(name string) config.ProjectRepo {
+		git(tempDir, "clone", "--bare", "-q", seed, name+".git")
+		return config.ProjectRepo{URL: filepath.Join(tempDir, name+".git"), Path: name}
+	}
+
+	tempDev := filepath.Join(tempDir, "dev")
+	projects := []config.Project{
+		{Name: "Alpha", Repos: []config.ProjectRepo{origin("one"), origin("two")}},
+		{Name: "Beta", Repos: []config.ProjectRepo{origin("three")}},
+	}
+	m := NewModel(projects, tempDev, "")
+	m, _ = updateModel(m, tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// Mark Alpha/one and Beta/three; space only marks in the repositories pane.
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeySpace})
+	if len(m.marked) != 0 {
+		t.Fatalf("Expected space in the projects pane to mark nothing, got %v", m.marked)
+	}
+	m.focusedPane = FocusRight
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeySpace})
+	m.list.Select(2)
+	m.selectedRepoIndex = 0
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeySpace})
+	if got := m.markedRepos(); len(got) != 2 || got[0].Name() != "one" || got[1].Name() != "three" {
+		t.Fatalf("Expected Alpha/one and Beta/three to be marked, got %v", got)
+	}
+
+	view := m.View()
+	for _, want := range []string{markerPrefix + "three", "2 marked", "Marked:", "[c] Clone", "[x] Clear"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q, got:\n%s", want, view)
+		}
+	}
+
+	// Toggling again unmarks; marking again restores the set.
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeySpace})
+	if len(m.marked) != 1 {
+		t.Fatalf("Expected space to unmark Beta/three, got %v", m.marked)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeySpace})
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	m = updated.(Model)
+	if m.totalActions != 2 || !strings.Contains(m.actionState, "2 marked repositories") {
+		t.Fatalf("Expected a bulk clone of 2 repositories, got %d actions, state %q", m.totalActions, m.actionState)
+	}
+	m = runQueuedActions(t, m, cmd)
+
+	if m.notificationType != NotifySuccess {
+		t.Fatalf("Expected the bulk clone to succeed, got %q\nlogs:\n%s", m.notification, strings.Join(m.actionLogs, "\n"))
+	}
+	if m.completedActions < m.totalActions {
+		t.Errorf("Expected progress to reach %d, got %d", m.totalActions, m.completedActions)
+	}
+	for _, path := range []string{"Alpha/one", "Beta/three"} {
+		if !repo.IsCloned(filepath.Join(tempDev, path)) {
+			t.Errorf("Expected %s to be cloned", path)
+		}
+	}
+	if repo.IsCloned(filepath.Join(tempDev, "Alpha", "two")) {
+		t.Error("Expected the unmarked Alpha/two to be left alone")
+	}
+	logs := strings.Join(m.actionLogs, "\n")
+	for _, want := range []string{"Alpha/one: Clone completed successfully!", "Beta/three: Clone completed successfully!"} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("Expected logs to contain %q, got:\n%s", want, logs)
+		}
+	}
+
+	// Marks survive the action and are cleared with x.
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if len(m.marked) != 0 {
+		t.Errorf("Expected x to clear the marks, got %v", m.marked)
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out strings.Builder
+	w := &prefixWriter{prefix: "repo: ", w: &out}
+	fmt.Fprint(w, "one\ntw")
+	fmt.Fprint(w, "o\nthree")
+	w.Flush()
+	if got, want := out.String(), "repo: one\nrepo: two\nrepo: three\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
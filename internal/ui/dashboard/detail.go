@@ -0,0 +1,219 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/eng618/eng/internal/repo"
+)
+
+const (
+	// detailCommitLimit is the number of recent commits shown in the detail pane.
+	detailCommitLimit = 10
+	// detailIncomingLimit caps the incoming commits shown in the detail pane.
+	detailIncomingLimit = 20
+)
+
+// RepoDetail holds the diff and commit log shown in the detail pane for one repository.
+type RepoDetail struct {
+	DiffStat    []string
+	Commits     []repo.CommitSummary
+	Incoming    []repo.CommitSummary
+	HasUpstream bool
+	Error       error
+	Loading     bool
+}
+
+// detailMsg is returned when a repository's detail is loaded.
+type detailMsg struct {
+	Key    string
+	Detail RepoDetail
+}
+
+// selectedRepo returns the repository selected in the right pane.
+func (m Model) selectedRepo() (repoRef, bool) {
+	refs := m.visibleRepos()
+	if m.selectedRepoIndex < 0 || m.selectedRepoIndex >= len(refs) {
+		return repoRef{}, false
+	}
+	return refs[m.selectedRepoIndex], true
+}
+
+// loadSelectedRepoDetailCmd marks the selected repository's detail as loading and reads it in the background.
+func (m *Model) loadSelectedRepoDetailCmd() tea.Cmd {
+	ref, ok := m.selectedRepo()
+	if !ok {
+		return nil
+	}
+
+	key := ref.Key()
+	d := m.repoDetails[key]
+	d.Loading = true
+	m.repoDetails[key] = d
+
+	fullPath := ref.FullPath(m.devPath)
+	return func() tea.Msg {
+		return detailMsg{Key: key, Detail: loadRepoDetail(fullPath)}
+	}
+}
+
+// loadRepoDetail reads the diff stat, recent commits, and incoming commits of a repository.
+func loadRepoDetail(fullPath string) RepoDetail {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var detail RepoDetail
+	var err error
+	if detail.DiffStat, err = repo.DiffStat(ctx, fullPath); err != nil {
+		detail.Error = err
+		return detail
+	}
+	if detail.Commits, err = repo.RecentCommits(ctx, fullPath, detailCommitLimit); err != nil {
+		detail.Error = err
+		return detail
+	}
+	// A branch without an upstream has nothing incoming; git reports that as an error.
+	if incoming, err := repo.IncomingCommits(ctx, fullPath, detailIncomingLimit); err == nil {
+		detail.Incoming = incoming
+		detail.HasUpstream = true
+	}
+	return detail
+}
+
+// detailLines renders the detail of the selected repository as lines for the scrollable pane.
+func (m Model) detailLines(width int) []string {
+	ref, ok := m.selectedRepo()
+	if !ok {
+		return []string{statusMutedStyle.Render("No repository selected.")}
+	}
+
+	key := ref.Key()
+	title := "repo: " + m.repoLabel(ref)
+	if status := m.repoStatuses[key]; status.Branch != "" {
+		title += fmt.Sprintf(" (%s)", status.Branch)
+	}
+	lines := []string{repoNameStyle.Render(truncate(title, width)), ""}
+
+	detail, exists := m.repoDetails[key]
+	switch {
+	case !exists || detail.Loading:
+		return append(lines, statusMutedStyle.Render("  [ Loading changes and history... ]"))
+	case detail.Error != nil:
+		return append(lines, statusErrorStyle.Render(truncate("  ✗ Error: "+detail.Error.Error(), width)))
+	}
+
+	lines = append(lines, projectNameStyle.Render("Changes"))
+	if len(detail.DiffStat) == 0 {
+		lines = append(lines, statusSuccessStyle.Render("  No uncommitted changes to tracked files"))
+	}
+	for _, l := range detail.DiffStat {
+		lines = append(lines, truncate("  "+l, width))
+	}
+	lines = append(lines, "")
+
+	switch {
+	case !detail.HasUpstream:
+		lines = append(lines, projectNameStyle.Render("Incoming"))
+		lines = append(lines, statusMutedStyle.Render("  No upstream branch"))
+	case len(detail.Incoming) == 0:
+		lines = append(lines, projectNameStyle.Render("Incoming"))
+		lines = append(lines, statusSuccessStyle.Render("  Up to date with upstream (as of last fetch)"))
+	default:
+		lines = append(lines, projectNameStyle.Render(fmt.Sprintf("Incoming (↓%d)", len(detail.Incoming))))
+		for _, c := range detail.Incoming {
+			lines = append(lines, statusErrorStyle.Render(commitLine(c, width)))
+		}
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, projectNameStyle.Render("Recent commits"))
+	if len(detail.Commits) == 0 {
+		lines = append(lines, statusMutedStyle.Render("  No commits yet"))
+	}
+	for _, c := range detail.Commits {
+		lines = append(lines, commitLine(c, width))
+	}
+	return lines
+}
+
+// commitLine formats a commit as "hash subject (author, age)".
+func commitLine(c repo.CommitSummary, width int) string {
+	age := "unknown"
+	if !c.When.IsZero() {
+		age = formatAge(time.Since(c.When))
+	}
+	return truncate(fmt.Sprintf("  %s %s (%s, %s)", c.Hash, c.Subject, c.Author, age), width)
+}
+
+// formatAge renders a duration as a short relative age such as "5m ago" or "3d ago".
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// renderDetailPane renders the scrollable detail view in place of the repository list.
+func (m Model) renderDetailPane(width int) string {
+	bodyHeight := m.detailBodyHeight()
+	all := m.detailLines(width)
+	start := m.detailScrollOffset
+	if maxScroll := len(all) - bodyHeight; start > maxScroll {
+		start = maxScroll
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + bodyHeight
+	if end > len(all) {
+		end = len(all)
+	}
+
+	lines := append([]string{}, all[start:end]...)
+	for len(lines) < bodyHeight {
+		lines = append(lines, "")
+	}
+
+	k := m.keys
+	footer := hints(
+		hint("Scroll", k.Down, k.Up), hint("Back", k.Details, k.Back), hint("Refresh", k.Refresh),
+		hint("Fetch", k.Fetch), hint("Pull", k.Pull), hint("Commit", k.Commit), hint("Help", k.Help),
+	)
+	if m.notification != "" {
+		return strings.Join(lines, "\n") + "\n" + m.notificationStyle.Render(truncate(m.notification, width))
+	}
+	return strings.Join(lines, "\n") + "\n" + statusMutedStyle.Render(truncate(footer, width))
+}
+
+// detailBodyHeight is the number of detail lines visible between the project title and the footer.
+func (m Model) detailBodyHeight() int {
+	_, _, _, rightHeight := m.paneSizes()
+	h := rightHeight - 4
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// scrollDetail moves the detail pane by delta lines, keeping it within the rendered content.
+func (m *Model) scrollDetail(delta int) {
+	m.detailScrollOffset += delta
+	_, _, rightWidth, _ := m.paneSizes()
+	maxScroll := len(m.detailLines(rightWidth)) - m.detailBodyHeight()
+	if m.detailScrollOffset > maxScroll {
+		m.detailScrollOffset = maxScroll
+	}
+	if m.detailScrollOffset < 0 {
+		m.detailScrollOffset = 0
+	}
+}
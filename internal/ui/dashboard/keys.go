@@ -0,0 +1,221 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds the dashboard's key bindings. Bindings of different contexts may share keys, such
+// as the Projects view and the Stacks and Immich views, but destructive actions keep keys of their own.
+type keyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Focus    key.Binding
+	Back     key.Binding
+	NextPane key.Binding
+	NextView key.Binding
+	PrevView key.Binding
+	Details  key.Binding
+
+	Mark       key.Binding
+	ClearMarks key.Binding
+
+	Fetch      key.Binding
+	Pull       key.Binding
+	Sync       key.Binding
+	Clone      key.Binding
+	Commit     key.Binding
+	Open       key.Binding
+	Edit       key.Binding
+	EditPicker key.Binding
+	Terminal   key.Binding
+	Refresh    key.Binding
+	Add        key.Binding
+
+	ServiceUp      key.Binding
+	ServiceDown    key.Binding
+	ServiceRestart key.Binding
+	Logs           key.Binding
+
+	Help key.Binding
+	Quit key.Binding
+}
+
+// newBinding returns a binding whose help lists all of its keys. The space bar may be given as "space".
+func newBinding(desc string, keys ...string) key.Binding {
+	matched := make([]string, len(keys))
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		if k == "space" {
+			k = " "
+		}
+		matched[i] = k
+		labels[i] = keyLabel(k)
+	}
+	return key.NewBinding(key.WithKeys(matched...), key.WithHelp(strings.Join(labels, "/"), desc))
+}
+
+// keyLabel returns the name a key is shown under in help and hints.
+func keyLabel(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+// defaultKeyMap returns the built-in bindings, which follow vim conventions alongside the arrow keys.
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Up:       newBinding("Move up / scroll up", "k", "up"),
+		Down:     newBinding("Move down / scroll down", "j", "down"),
+		Focus:    newBinding("Focus repositories pane", "enter", "l", "right"),
+		Back:     newBinding("Focus projects pane / go back", "esc", "h", "left"),
+		NextPane: newBinding("Switch pane", "tab"),
+		NextView: newBinding("Next view (Projects, Stacks, Immich)", "]"),
+		PrevView: newBinding("Previous view", "["),
+		Details:  newBinding("Toggle diff and commit log of repository", "d"),
+
+		Mark:       newBinding("Mark/unmark repository for fetch, pull, sync, clone, and open", "space"),
+		ClearMarks: newBinding("Clear all marks", "x"),
+
+		Fetch:      newBinding("Fetch repository (or all)", "f"),
+		Pull:       newBinding("Pull repository (or all)", "p"),
+		Sync:       newBinding("Sync repository (or all)", "s"),
+		Clone:      newBinding("Clone/Setup repository (or all)", "c"),
+		Commit:     newBinding("Stage, commit, and push repository", "C"),
+		Open:       newBinding("Open in Finder / File Explorer", "o"),
+		Edit:       newBinding("Open in configured editor", "e"),
+		EditPicker: newBinding("Choose editor to open in...", "E"),
+		Terminal:   newBinding("Open in terminal window", "t"),
+		Refresh:    newBinding("Refresh statuses", "r"),
+		Add:        newBinding("Add project or repository", "a"),
+
+		ServiceUp:      newBinding("Bring up stack / start Immich", "u"),
+		ServiceDown:    newBinding("Bring down stack / stop Immich (asks first)", "D"),
+		ServiceRestart: newBinding("Restart stack or Immich", "R"),
+		Logs:           newBinding("Show recent logs", "L"),
+
+		Help: newBinding("Toggle help menu", "?"),
+		Quit: newBinding("Quit application", "q", "ctrl+c"),
+	}
+}
+
+// bindings maps the action names used by the dashboard.keymap configuration to their bindings.
+func (k *keyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":              &k.Up,
+		"down":            &k.Down,
+		"focus":           &k.Focus,
+		"back":            &k.Back,
+		"next_pane":       &k.NextPane,
+		"next_view":       &k.NextView,
+		"prev_view":       &k.PrevView,
+		"details":         &k.Details,
+		"mark":            &k.Mark,
+		"clear_marks":     &k.ClearMarks,
+		"fetch":           &k.Fetch,
+		"pull":            &k.Pull,
+		"sync":            &k.Sync,
+		"clone":           &k.Clone,
+		"commit":          &k.Commit,
+		"open":            &k.Open,
+		"edit":            &k.Edit,
+		"edit_picker":     &k.EditPicker,
+		"terminal":        &k.Terminal,
+		"refresh":         &k.Refresh,
+		"add":             &k.Add,
+		"service_up":      &k.ServiceUp,
+		"service_down":    &k.ServiceDown,
+		"service_restart": &k.ServiceRestart,
+		"logs":            &k.Logs,
+		"help":            &k.Help,
+		"quit":            &k.Quit,
+	}
+}
+
+// newKeyMap returns the default bindings with the keys of the actions in overrides replaced.
+// An empty key list disables an action.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	k := defaultKeyMap()
+	bindings := k.bindings()
+
+	var unknown []string
+	for name, keys := range overrides {
+		b, ok := bindings[strings.ToLower(name)]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if len(keys) == 0 {
+			b.SetEnabled(false)
+			continue
+		}
+		*b = newBinding(b.Help().Desc, keys...)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return k, fmt.Errorf("unknown dashboard.keymap actions: %s", strings.Join(unknown, ", "))
+	}
+	return k, nil
+}
+
+// keyMapActions lists the action names accepted by dashboard.keymap.
+func keyMapActions() []string {
+	k := defaultKeyMap()
+	names := make([]string, 0, len(k.bindings()))
+	for name := range k.bindings() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// helpGroup is a titled section of the help menu.
+type helpGroup struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// helpGroups returns the sections of the help menu.
+func (k keyMap) helpGroups() []helpGroup {
+	return []helpGroup{
+		{Title: "General:", Bindings: []key.Binding{k.Help, k.Quit, k.NextView, k.PrevView}},
+		{Title: "Navigation:", Bindings: []key.Binding{k.Focus, k.Back, k.NextPane, k.Up, k.Down, k.Details}},
+		{Title: "Bulk actions:", Bindings: []key.Binding{k.Mark, k.ClearMarks}},
+		{Title: "Actions (Context-aware):", Bindings: []key.Binding{
+			k.Fetch, k.Pull, k.Sync, k.Clone, k.Commit, k.Open, k.Edit, k.EditPicker, k.Terminal, k.Refresh, k.Add,
+		}},
+		{Title: "Stacks & Immich views:", Bindings: []key.Binding{
+			k.ServiceUp, k.ServiceDown, k.ServiceRestart, k.Logs,
+		}},
+	}
+}
+
+// hint renders a footer hint such as "[f] Fetch" from the primary key of each enabled binding.
+// It returns "" when all bindings are disabled.
+func hint(label string, bindings ...key.Binding) string {
+	var keys []string
+	for _, b := range bindings {
+		if b.Enabled() && len(b.Keys()) > 0 {
+			keys = append(keys, keyLabel(b.Keys()[0]))
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(keys, "/") + "] " + label
+}
+
+// hints joins footer hints, skipping empty ones.
+func hints(parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, "  ")
+}
@@ -0,0 +1,52 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Layout arranges the projects and repositories panes of the Projects view.
+type Layout string
+
+const (
+	LayoutHorizontal Layout = "horizontal" // Projects to the left of repositories
+	LayoutVertical   Layout = "vertical"   // Projects above repositories, for narrow terminals
+	LayoutSingle     Layout = "single"     // One pane at a time with tabs, as in compact mode
+)
+
+// ParseLayout parses the dashboard.layout setting. An empty setting selects the horizontal layout.
+func ParseLayout(s string) (Layout, error) {
+	switch l := Layout(strings.ToLower(strings.TrimSpace(s))); l {
+	case "":
+		return LayoutHorizontal, nil
+	case LayoutHorizontal, LayoutVertical, LayoutSingle:
+		return l, nil
+	}
+	return "", fmt.Errorf("unknown dashboard layout %q: use horizontal, vertical, or single", s)
+}
+
+// leftPaneOuterWidth is the width of the projects pane, including its border, in the horizontal layout.
+func (m Model) leftPaneOuterWidth() int {
+	return min(max((m.windowWidth-4)/4, 20), 30)
+}
+
+// topPaneHeight is the height of the projects pane, excluding its border, in the vertical layout.
+func (m Model) topPaneHeight() int {
+	return max((m.windowHeight-6)/3, 4)
+}
+
+// paneSizes returns the content sizes, inside borders and padding, of the projects list and the
+// right pane for the current layout.
+func (m Model) paneSizes() (listWidth, listHeight, rightWidth, rightHeight int) {
+	switch {
+	case m.isCompactMode():
+		return m.windowWidth - 8, m.windowHeight - 6, m.windowWidth - 8, m.windowHeight - 6
+	case m.layout == LayoutVertical:
+		top := m.topPaneHeight()
+		return m.windowWidth - 8, top - 2, m.windowWidth - 8, m.windowHeight - 8 - top
+	default:
+		left := m.leftPaneOuterWidth()
+		right := m.windowWidth - 4 - left
+		return left - 4, m.windowHeight - 6, right - 4, m.windowHeight - 6
+	}
+}
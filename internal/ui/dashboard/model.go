@@ -1,13 +1,18 @@
 package dashboard
 
 import (
+	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/containers"
+	"github.com/eng618/eng/internal/immich"
 )
 
 type PaneFocus int
@@ -15,6 +20,7 @@ type PaneFocus int
 const (
 	FocusLeft PaneFocus = iota
 	FocusRight
+	FocusDetail // Diff and commit log of the selected repository, shown in place of the repository list
 )
 
 type NotificationType int
@@ -39,6 +45,7 @@ type RepoStatus struct {
 	UntrackedCount int
 	ConflictCount  int
 	OngoingOp      string
+	Worktrees      []string // Branches checked out in linked worktrees
 	Error          error
 	Loading        bool
 	LastUpdated    time.Time
@@ -46,17 +53,70 @@ type RepoStatus struct {
 
 // ProjectItem adapts config.Project to the list.Item interface.
 type ProjectItem struct {
-	Project config.Project
+	Project   config.Project
+	Attention bool // The synthetic entry listing repositories that need attention across all projects
 }
 
-func (i ProjectItem) Title() string       { return i.Project.Name }
+func (i ProjectItem) Title() string {
+	if i.Attention {
+		return attentionTitle
+	}
+	return i.Project.Name
+}
 func (i ProjectItem) Description() string { return "" }
-func (i ProjectItem) FilterValue() string { return i.Project.Name }
+func (i ProjectItem) FilterValue() string { return i.Title() }
+
+// projectItems returns the list items for projects, led by the "Needs attention" entry.
+func projectItems(projects []config.Project) []list.Item {
+	items := make([]list.Item, 0, len(projects)+1)
+	items = append(items, ProjectItem{Attention: true})
+	for _, p := range projects {
+		items = append(items, ProjectItem{Project: p})
+	}
+	return items
+}
+
+// repoRef identifies a configured repository within its project.
+type repoRef struct {
+	Project string
+	Repo    config.ProjectRepo
+}
+
+// Key returns the key of the repository in repoStatuses and repoDetails.
+func (r repoRef) Key() string {
+	return r.Project + r.Repo.URL
+}
+
+// Name returns the repository's directory name, or its URL if that cannot be derived.
+func (r repoRef) Name() string {
+	name, err := r.Repo.GetEffectivePath()
+	if err != nil {
+		return r.Repo.URL
+	}
+	return name
+}
+
+// FullPath returns the repository's location under devPath.
+func (r repoRef) FullPath(devPath string) string {
+	relPath, _ := r.Repo.GetEffectivePath()
+	return filepath.Join(devPath, r.Project, relPath)
+}
 
 type ActionItem struct {
 	Action   string
 	RepoName string
 	FullPath string
+	Label    string // Shown in progress and logs instead of the name derived from RepoName
+
+	// Commit flow fields
+	Stage       []string // Paths to stage
+	Unstage     []string // Paths to remove from the index
+	Message     string   // Commit message
+	SetUpstream bool     // Push with --set-upstream
+	Chained     bool     // Skipped when the preceding action fails
+
+	// Service actions fields
+	Stack string // Compose stack the stack-* actions apply to
 }
 
 type configUpdateFinishedMsg struct {
@@ -71,18 +131,47 @@ type Model struct {
 	list         list.Model
 	projects     []config.Project
 	repoStatuses map[string]RepoStatus // Keyed by project.Name + repo.URL
+	repoDetails  map[string]RepoDetail // Keyed like repoStatuses
 	devPath      string
 	editor       string
-
-	focusedPane       PaneFocus
-	selectedRepoIndex int
-	repoScrollOffset  int
+	watcher      *repoWatcher // Nil when auto-refresh is disabled
+	keys         keyMap
+	layout       Layout
+	marked       map[string]bool // Repositories marked for bulk actions, keyed like repoStatuses
+
+	// Compose stacks and Immich views
+	view               DashboardView
+	stackManager       *containers.Manager
+	immichManager      *immich.Manager
+	stacks             []containers.Stack
+	stackDetails       map[string][]containers.ContainerDetail // Keyed by stack name
+	stacksLoading      bool
+	stacksErr          error
+	selectedStackIndex int
+	immichStatus       *immich.StatusResult
+	immichLoading      bool
+	immichErr          error
+	immichScrollOffset int
+	serviceLogs        *serviceLogs // Logs pane shown in place of the view while non-nil
+	confirmService     string       // Service action awaiting y/N confirmation, empty when none
+
+	focusedPane        PaneFocus
+	selectedRepoIndex  int
+	repoScrollOffset   int
+	detailScrollOffset int
 
 	actionState string // empty if idle, otherwise the loading message
 	actionQueue []ActionItem
 	actionLogs  []string
 	spinner     spinner.Model
 
+	bulkCompleted *atomic.Int32 // Completed actions of the running bulk actions, nil otherwise
+
+	// Commit form shown as a modal while non-nil
+	commitForm          *huh.Form
+	commitPlan          *commitPlan
+	conventionalCommits bool
+
 	// Toast notification fields
 	notification      string
 	notificationStyle lipgloss.Style
@@ -104,10 +193,7 @@ type Model struct {
 
 // NewModel initializes the dashboard model with configured projects.
 func NewModel(projects []config.Project, devPath, editor string) Model {
-	items := make([]list.Item, len(projects))
-	for i, p := range projects {
-		items[i] = ProjectItem{Project: p}
-	}
+	items := projectItems(projects)
 
 	d := list.NewDefaultDelegate()
 	d.ShowDescription = false
@@ -122,23 +208,40 @@ func NewModel(projects []config.Project, devPath, editor string) Model {
 	s.Spinner = spinner.Dot
 	s.Style = spinnerStyle
 
+	// Start on the first project; Run opens on the "Needs attention" entry instead.
+	if len(projects) > 0 {
+		l.Select(1)
+	}
+
 	m := Model{
 		list:              l,
 		projects:          projects,
 		repoStatuses:      make(map[string]RepoStatus),
+		repoDetails:       make(map[string]RepoDetail),
+		marked:            make(map[string]bool),
 		devPath:           devPath,
 		editor:            editor,
 		focusedPane:       FocusLeft,
 		selectedRepoIndex: 0,
 		spinner:           s,
+		keys:              defaultKeyMap(),
+		layout:            LayoutHorizontal,
+		stackManager:      containers.NewManager(config.GetContainersConfig().Path),
+		immichManager:     immich.NewManager(""),
+		stackDetails:      make(map[string][]containers.ContainerDetail),
 	}
 	return m
 }
 
+// repoFocused reports whether actions apply to the selected repository rather than the whole project.
+func (m Model) repoFocused() bool {
+	return m.focusedPane != FocusLeft
+}
+
 func (m Model) isFallbackMode() bool {
 	return m.windowWidth < 50 || m.windowHeight < 10
 }
 
 func (m Model) isCompactMode() bool {
-	return !m.isFallbackMode() && (m.windowWidth < 60 || m.windowHeight < 14)
+	return !m.isFallbackMode() && (m.layout == LayoutSingle || m.windowWidth < 60 || m.windowHeight < 14)
 }
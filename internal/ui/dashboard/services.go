@@ -0,0 +1,597 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/eng618/eng/internal/containers"
+	"github.com/eng618/eng/internal/immich"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+// DashboardView is a top-level view of the dashboard, switched with [ and ].
+type DashboardView int
+
+const (
+	ViewProjects DashboardView = iota
+	ViewStacks                 // Compose stacks and their container health
+	ViewImmich                 // Immich service, API, and backup state
+	viewCount
+)
+
+func (v DashboardView) String() string {
+	switch v {
+	case ViewStacks:
+		return "Stacks"
+	case ViewImmich:
+		return "Immich"
+	default:
+		return "Projects"
+	}
+}
+
+const (
+	// serviceLogsTail is how many log lines the logs pane loads.
+	serviceLogsTail = 200
+	// serviceActionTimeout bounds compose and Immich actions, which pull images and wait for containers to stop.
+	serviceActionTimeout = 5 * time.Minute
+	// stacksUpEnv is the environment stacks are brought up with, matching the default of 'eng compose up'.
+	stacksUpEnv = "prod"
+)
+
+// stacksMsg is returned when the compose stacks and their containers are loaded.
+type stacksMsg struct {
+	Stacks  []containers.Stack
+	Details map[string][]containers.ContainerDetail
+	Err     error
+}
+
+// immichStatusMsg is returned when the Immich status is loaded.
+type immichStatusMsg struct {
+	Status *immich.StatusResult
+	Err    error
+}
+
+// serviceLogs holds the logs pane of a stack or Immich.
+type serviceLogs struct {
+	Title        string
+	Lines        []string
+	Loading      bool
+	Err          error
+	ScrollOffset int
+	reload       func() tea.Msg
+}
+
+// serviceLogsMsg is returned when the logs of the logs pane are loaded.
+type serviceLogsMsg struct {
+	Title string
+	Lines []string
+	Err   error
+}
+
+// isServiceAction reports whether an action targets a compose stack or Immich rather than a repository.
+func isServiceAction(action string) bool {
+	return strings.HasPrefix(action, "stack-") || strings.HasPrefix(action, "immich-")
+}
+
+// switchView moves to the next or previous view and loads its state.
+func (m Model) switchView(delta int) (Model, tea.Cmd) {
+	m.view = DashboardView((int(m.view) + delta + int(viewCount)) % int(viewCount))
+	m.serviceLogs = nil
+	return m, m.loadViewCmd()
+}
+
+// loadViewCmd reloads the state shown by the current view.
+func (m *Model) loadViewCmd() tea.Cmd {
+	switch m.view {
+	case ViewStacks:
+		m.stacksLoading = true
+		return loadStacksCmd(*m.stackManager)
+	case ViewImmich:
+		m.immichLoading = true
+		return loadImmichCmd(*m.immichManager)
+	}
+	return nil
+}
+
+func loadStacksCmd(mgr containers.Manager) tea.Cmd {
+	return func() tea.Msg {
+		stacks, err := mgr.DiscoverStacks()
+		if err != nil || len(stacks) == 0 {
+			return stacksMsg{Stacks: stacks, Err: err}
+		}
+		details, err := mgr.ContainerDetails(nil)
+		return stacksMsg{Stacks: stacks, Details: details, Err: err}
+	}
+}
+
+func loadImmichCmd(mgr immich.Manager) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		status, err := mgr.GetStatus(ctx)
+		return immichStatusMsg{Status: status, Err: err}
+	}
+}
+
+// updateServices handles the keys of the Stacks and Immich views.
+func (m Model) updateServices(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.serviceLogs != nil {
+		switch {
+		case key.Matches(msg, m.keys.Back, m.keys.Logs):
+			m.serviceLogs = nil
+		case key.Matches(msg, m.keys.Down):
+			m.scrollServiceLogs(1)
+		case key.Matches(msg, m.keys.Up):
+			m.scrollServiceLogs(-1)
+		case key.Matches(msg, m.keys.Refresh):
+			m.serviceLogs.Loading = true
+			return m, m.serviceLogs.reload
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		if m.view == ViewStacks && m.selectedStackIndex < len(m.stacks)-1 {
+			m.selectedStackIndex++
+		} else if m.view == ViewImmich {
+			m.scrollImmich(1)
+		}
+	case key.Matches(msg, m.keys.Up):
+		if m.view == ViewStacks && m.selectedStackIndex > 0 {
+			m.selectedStackIndex--
+		} else if m.view == ViewImmich {
+			m.scrollImmich(-1)
+		}
+	case key.Matches(msg, m.keys.Refresh):
+		return m, m.loadViewCmd()
+	case key.Matches(msg, m.keys.ServiceUp):
+		return m.queueServiceAction("up")
+	case key.Matches(msg, m.keys.ServiceDown):
+		return m.confirmServiceAction("down")
+	case key.Matches(msg, m.keys.ServiceRestart):
+		return m.queueServiceAction("restart")
+	case key.Matches(msg, m.keys.Logs):
+		return m.openServiceLogs()
+	}
+	return m, nil
+}
+
+// serviceTarget returns the stack the Stacks view acts on, or reports why there is nothing to act on.
+func (m Model) serviceTarget() (string, error) {
+	if m.view == ViewImmich {
+		if !m.immichManager.IsConfigured() {
+			return "", errors.New("not configured on this host")
+		}
+		return "", nil
+	}
+	if m.selectedStackIndex >= len(m.stacks) {
+		return "", errors.New("no stack selected")
+	}
+	return m.stacks[m.selectedStackIndex].Name, nil
+}
+
+// confirmServiceAction asks for confirmation before running action on the selected stack or Immich.
+func (m Model) confirmServiceAction(action string) (Model, tea.Cmd) {
+	if _, err := m.serviceTarget(); err != nil {
+		m.notificationID++
+		m.notification = fmt.Sprintf("%s: %v", m.view, err)
+		m.notificationStyle = notificationErrorStyle
+		m.notificationType = NotifyError
+		return m, m.delayClearNotificationCmd(m.notificationID)
+	}
+	m.confirmService = action
+	return m, nil
+}
+
+// updateServiceConfirm runs the action awaiting confirmation on y, and cancels it on any other key.
+func (m Model) updateServiceConfirm(msg tea.KeyMsg) (Model, tea.Cmd) {
+	action := m.confirmService
+	m.confirmService = ""
+	if msg.String() == "y" || msg.String() == "Y" {
+		return m.queueServiceAction(action)
+	}
+
+	m.notificationID++
+	m.notification = fmt.Sprintf("%s %s canceled.", m.view, action)
+	m.notificationStyle = notificationWarnStyle
+	m.notificationType = NotifyWarn
+	return m, m.delayClearNotificationCmd(m.notificationID)
+}
+
+// serviceConfirmPrompt returns the question asked before the action awaiting confirmation.
+func (m Model) serviceConfirmPrompt() string {
+	if m.view == ViewImmich {
+		return "Stop Immich? [y/N]"
+	}
+	stack, _ := m.serviceTarget()
+	return fmt.Sprintf("Bring down stack %s? Its containers are removed; volumes are kept. [y/N]", stack)
+}
+
+// queueServiceAction brings up, brings down, or restarts the selected stack or Immich.
+func (m Model) queueServiceAction(action string) (Model, tea.Cmd) {
+	stack, err := m.serviceTarget()
+	if err != nil {
+		m.notificationID++
+		m.notification = fmt.Sprintf("%s: %v", m.view, err)
+		m.notificationStyle = notificationErrorStyle
+		m.notificationType = NotifyError
+		return m, m.delayClearNotificationCmd(m.notificationID)
+	}
+
+	prefix := "stack-"
+	if m.view == ViewImmich {
+		prefix = "immich-"
+	}
+
+	m.actionLogs = []string{}
+	m.hasError = false
+	m.lastError = nil
+	m.actionQueue = []ActionItem{{Action: prefix + action, Stack: stack}}
+	m.totalActions = 1
+	m.completedActions = 0
+
+	var cmd tea.Cmd
+	m, cmd = m.popAndRunNextAction()
+	return m, tea.Batch(m.spinner.Tick, cmd)
+}
+
+// openServiceLogs opens the logs pane with the recent logs of the selected stack or Immich.
+func (m Model) openServiceLogs() (Model, tea.Cmd) {
+	stack, err := m.serviceTarget()
+	if err != nil {
+		m.notificationID++
+		m.notification = fmt.Sprintf("%s: %v", m.view, err)
+		m.notificationStyle = notificationErrorStyle
+		m.notificationType = NotifyError
+		return m, m.delayClearNotificationCmd(m.notificationID)
+	}
+
+	var title string
+	var load func() tea.Msg
+	if m.view == ViewImmich {
+		title = "Immich"
+		mgr := *m.immichManager
+		load = func() tea.Msg {
+			var buf bytes.Buffer
+			mgr.Output = &buf
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := mgr.Logs(ctx, "", false, serviceLogsTail)
+			return serviceLogsMsg{Title: title, Lines: splitLogLines(buf.String()), Err: err}
+		}
+	} else {
+		title = stack
+		mgr := *m.stackManager
+		load = func() tea.Msg {
+			var buf bytes.Buffer
+			mgr.Output = &buf
+			err := mgr.Logs(stack, false, fmt.Sprint(serviceLogsTail))
+			return serviceLogsMsg{Title: title, Lines: splitLogLines(buf.String()), Err: err}
+		}
+	}
+
+	m.serviceLogs = &serviceLogs{Title: title, Loading: true, reload: load}
+	return m, load
+}
+
+func splitLogLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// servicesPaneSize returns the width and height of the body of the Stacks and Immich views,
+// which excludes the footer line.
+func (m Model) servicesPaneSize() (width, height int) {
+	if m.isCompactMode() {
+		return m.windowWidth - 8, m.windowHeight - 5
+	}
+	return m.windowWidth - 8, m.windowHeight - 7
+}
+
+func (m *Model) scrollServiceLogs(delta int) {
+	_, height := m.servicesPaneSize()
+	maxScroll := max(0, len(m.serviceLogs.Lines)-(height-2))
+	m.serviceLogs.ScrollOffset = min(max(0, m.serviceLogs.ScrollOffset+delta), maxScroll)
+}
+
+func (m *Model) scrollImmich(delta int) {
+	width, height := m.servicesPaneSize()
+	maxScroll := max(0, len(m.immichLines(width))-height)
+	m.immichScrollOffset = min(max(0, m.immichScrollOffset+delta), maxScroll)
+}
+
+// renderServicesDashboard renders the Stacks and Immich views as a single full-width pane.
+func (m Model) renderServicesDashboard() string {
+	width, height := m.servicesPaneSize()
+	style := activePaneStyle.Width(m.windowWidth - 6).Height(m.windowHeight - 4)
+	if m.isCompactMode() {
+		style = compactPaneStyle.Width(m.windowWidth - 6).Height(m.windowHeight - 4)
+	}
+
+	k := m.keys
+	var lines []string
+	var footer string
+	switch {
+	case m.serviceLogs != nil:
+		lines = m.renderServiceLogs(width, height)
+		footer = hints(
+			hint("Scroll", k.Down, k.Up), hint("Reload", k.Refresh), hint("Back", k.Back), hint("Help", k.Help),
+		)
+	case m.view == ViewStacks:
+		lines = m.renderStacksPane(width, height)
+		footer = hints(
+			hint("Navigate", k.Down, k.Up), hint("Up", k.ServiceUp), hint("Down", k.ServiceDown),
+			hint("Restart", k.ServiceRestart), hint("Logs", k.Logs), hint("Refresh", k.Refresh), hint("Help", k.Help),
+		)
+	default:
+		lines = m.immichLines(width)
+		start := min(m.immichScrollOffset, max(0, len(lines)-height))
+		lines = lines[start:]
+		footer = hints(
+			hint("Scroll", k.Down, k.Up), hint("Start", k.ServiceUp), hint("Stop", k.ServiceDown),
+			hint("Restart", k.ServiceRestart), hint("Logs", k.Logs), hint("Refresh", k.Refresh), hint("Help", k.Help),
+		)
+	}
+
+	body := padLines(lines, height)
+	footer = m.renderFooter(footer, width)
+	if m.confirmService != "" {
+		footer = notificationWarnStyle.Render(truncate(m.serviceConfirmPrompt(), width))
+	}
+	return m.renderFrame(style.Render(body + "\n" + footer))
+}
+
+// renderStacksPane renders the stacks table and the containers of the selected stack.
+func (m Model) renderStacksPane(width, height int) []string {
+	title := fmt.Sprintf("Compose stacks (%d)", len(m.stacks))
+	if m.stacksLoading {
+		title += " · refreshing..."
+	}
+	lines := []string{projectNameStyle.Render(truncate(title, width)), ""}
+
+	switch {
+	case m.stacksErr != nil:
+		return append(lines, statusErrorStyle.Render(truncate("✗ Error: "+m.stacksErr.Error(), width)))
+	case len(m.stacks) == 0 && m.stacksLoading:
+		return append(lines, statusMutedStyle.Render(truncate("Checking stacks...", width)))
+	case len(m.stacks) == 0:
+		msg := fmt.Sprintf("No compose stacks found in %s.", m.stackManager.BasePath)
+		return append(lines, statusMutedStyle.Render(truncate(msg, width)))
+	}
+
+	// The stacks table takes up to half of the pane; the containers of the selected stack fill the rest.
+	rows := max(1, min(len(m.stacks), (height-2)/2-2))
+	start := max(0, m.selectedStackIndex-rows+1)
+	end := min(len(m.stacks), start+rows)
+
+	wName := max(10, width*30/100)
+	wState := 10
+	wCount := 14
+	wHealth := max(10, width-wName-wState-wCount-6)
+	tableHeaderStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+
+	header := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s",
+		wName-2, "STACK", wState, "STATE", wCount, "CONTAINERS", wHealth, "HEALTH")
+	lines = append(lines, tableHeaderStyle.Render(truncate(header, width)))
+	for idx := start; idx < end; idx++ {
+		s := m.stacks[idx]
+		details := m.stackDetails[s.Name]
+		running := runningContainers(details)
+		state, stateStyle := stackState(running, len(details))
+		health, healthCellStyle := stackHealth(details)
+		count := fmt.Sprintf("%d/%d running", running, len(details))
+
+		isSelected := idx == m.selectedStackIndex
+		nameCell := renderRepoCell(s.Name, isSelected, wName)
+		cells := []string{
+			fmt.Sprintf("%-*s", wState, truncate(state, wState)),
+			fmt.Sprintf("%-*s", wCount, truncate(count, wCount)),
+			fmt.Sprintf("%-*s", wHealth, truncate(health, wHealth)),
+		}
+		styles := []lipgloss.Style{stateStyle, statusMutedStyle, healthCellStyle}
+		row := nameCell
+		for i, cell := range cells {
+			if isSelected {
+				row += "  " + selectedTableCellStyle.Render(cell)
+			} else {
+				row += "  " + styles[i].Render(cell)
+			}
+		}
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(row))
+	}
+
+	selected := m.stacks[min(m.selectedStackIndex, len(m.stacks)-1)]
+	lines = append(lines, "", projectNameStyle.Render(truncate("Containers: "+selected.Name, width)))
+	details := m.stackDetails[selected.Name]
+	if len(details) == 0 {
+		hint := "No containers. Press [u] to bring the stack up."
+		return append(lines, statusMutedStyle.Render(truncate(hint, width)))
+	}
+
+	wCName := max(10, width*30/100)
+	wService := max(8, width*18/100)
+	wCState := 10
+	wCHealth := 10
+	wPorts := max(5, width-wCName-wService-wCState-wCHealth-8)
+	header = fmt.Sprintf("%-*s  %-*s  %-*s  %-*s  %-*s",
+		wCName, "NAME", wService, "SERVICE", wCState, "STATE", wCHealth, "HEALTH", wPorts, "PORTS")
+	lines = append(lines, tableHeaderStyle.Render(truncate(header, width)))
+	for _, c := range details {
+		stateStyle := statusSuccessStyle
+		if c.State != "running" {
+			stateStyle = statusErrorStyle
+		}
+		health := c.Health
+		if health == "" {
+			health = "—"
+		}
+		row := repoNameStyle.Render(fmt.Sprintf("%-*s", wCName, truncate(c.Name, wCName))) + "  " +
+			statusMutedStyle.Render(fmt.Sprintf("%-*s", wService, truncate(c.Service, wService))) + "  " +
+			stateStyle.Render(fmt.Sprintf("%-*s", wCState, truncate(c.State, wCState))) + "  " +
+			containerHealthStyle(c.Health).Render(fmt.Sprintf("%-*s", wCHealth, truncate(health, wCHealth))) + "  " +
+			statusMutedStyle.Render(truncate(formatPorts(c.Publishers), wPorts))
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(row))
+	}
+	return lines
+}
+
+// immichLines renders the Immich status card, or its loading and error states.
+func (m Model) immichLines(width int) []string {
+	switch {
+	case m.immichErr != nil:
+		return []string{statusErrorStyle.Render(truncate("✗ Error: "+m.immichErr.Error(), width))}
+	case m.immichStatus == nil:
+		return []string{statusMutedStyle.Render(truncate("Checking Immich...", width))}
+	}
+
+	clip := lipgloss.NewStyle().MaxWidth(width)
+	lines := strings.Split(strings.TrimRight(immich.RenderStatus(m.immichStatus, width), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = clip.Render(line)
+	}
+	if m.immichLoading {
+		lines = append(lines, statusMutedStyle.Render("Refreshing..."))
+	}
+	return lines
+}
+
+// renderServiceLogs renders the logs pane.
+func (m Model) renderServiceLogs(width, height int) []string {
+	logs := m.serviceLogs
+	title := fmt.Sprintf("Logs: %s (last %d lines)", logs.Title, serviceLogsTail)
+	lines := []string{projectNameStyle.Render(truncate(title, width)), ""}
+
+	switch {
+	case logs.Loading && logs.Lines == nil:
+		return append(lines, statusMutedStyle.Render("Loading logs..."))
+	case logs.Err != nil && len(logs.Lines) == 0:
+		return append(lines, statusErrorStyle.Render(truncate("✗ Error: "+logs.Err.Error(), width)))
+	case len(logs.Lines) == 0:
+		return append(lines, statusMutedStyle.Render("No log output."))
+	}
+
+	start := min(logs.ScrollOffset, max(0, len(logs.Lines)-(height-2)))
+	end := min(len(logs.Lines), start+height-2)
+	for _, line := range logs.Lines[start:end] {
+		lines = append(lines, truncate(line, width))
+	}
+	return lines
+}
+
+// renderViewBar renders the tabs of the dashboard views.
+func (m Model) renderViewBar() string {
+	tabs := make([]string, 0, viewCount+1)
+	for v := ViewProjects; v < viewCount; v++ {
+		if v == m.view {
+			tabs = append(tabs, tabActiveStyle.Render(v.String()))
+		} else {
+			tabs = append(tabs, tabInactiveStyle.Render(v.String()))
+		}
+	}
+	if prev, next := m.keys.PrevView, m.keys.NextView; prev.Enabled() && next.Enabled() {
+		tabs = append(tabs, statusMutedStyle.Render(fmt.Sprintf("  %s %s switch view", prev.Keys()[0], next.Keys()[0])))
+	}
+	return lipgloss.NewStyle().MaxWidth(m.windowWidth - 4).Render(lipgloss.JoinHorizontal(lipgloss.Top, tabs...))
+}
+
+// renderFrame lays out a view below the view bar, which takes the place of the top padding.
+func (m Model) renderFrame(content string) string {
+	return appStyle.PaddingTop(0).Render(lipgloss.JoinVertical(lipgloss.Left, m.renderViewBar(), content))
+}
+
+func runningContainers(details []containers.ContainerDetail) int {
+	running := 0
+	for _, c := range details {
+		if c.State == "running" {
+			running++
+		}
+	}
+	return running
+}
+
+// stackState summarizes a stack from its running and total container counts.
+func stackState(running, total int) (string, lipgloss.Style) {
+	switch {
+	case total == 0:
+		return "Stopped", statusMutedStyle
+	case running == total:
+		return "Running", statusSuccessStyle
+	case running > 0:
+		return "Partial", statusWarningStyle
+	default:
+		return "Exited", statusErrorStyle
+	}
+}
+
+// stackHealth summarizes the health checks of a stack's containers.
+func stackHealth(details []containers.ContainerDetail) (string, lipgloss.Style) {
+	var healthy, unhealthy, starting int
+	for _, c := range details {
+		switch c.Health {
+		case "healthy":
+			healthy++
+		case "unhealthy":
+			unhealthy++
+		case "starting":
+			starting++
+		}
+	}
+	switch {
+	case unhealthy > 0:
+		return fmt.Sprintf("%d unhealthy", unhealthy), statusErrorStyle
+	case starting > 0:
+		return fmt.Sprintf("%d starting", starting), statusWarningStyle
+	case healthy > 0:
+		return fmt.Sprintf("%d healthy", healthy), statusSuccessStyle
+	}
+	return "—", statusMutedStyle
+}
+
+func containerHealthStyle(health string) lipgloss.Style {
+	switch health {
+	case "healthy":
+		return statusSuccessStyle
+	case "unhealthy":
+		return statusErrorStyle
+	case "starting":
+		return statusWarningStyle
+	}
+	return statusMutedStyle
+}
+
+func formatPorts(publishers []containers.Publisher) string {
+	var ports []string
+	for _, p := range publishers {
+		if p.PublishedPort > 0 {
+			ports = append(ports, fmt.Sprintf("%d→%d/%s", p.PublishedPort, p.TargetPort, p.Protocol))
+		}
+	}
+	if len(ports) == 0 {
+		return "—"
+	}
+	return strings.Join(ports, ", ")
+}
+
+// padLines joins lines, cut or padded to exactly n lines.
+func padLines(lines []string, n int) string {
+	if len(lines) > n {
+		lines = lines[:max(0, n)]
+	}
+	for len(lines) < n {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,177 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eng618/eng/internal/cmdutil"
+	"github.com/eng618/eng/internal/config"
+	"github.com/eng618/eng/internal/ui/theme"
+)
+
+// snapshotConcurrency bounds how many repositories are checked at once.
+const snapshotConcurrency = 8
+
+// SnapshotOptions configures a non-interactive dashboard snapshot.
+type SnapshotOptions struct {
+	Format  cmdutil.OutputFormat // json or yaml print the statuses instead of the rendered tables
+	Refresh bool                 // Query git directly instead of using cached statuses
+	Width   int                  // Width of the rendered tables
+}
+
+// repoSnapshot is the structured form of a repository's status.
+type repoSnapshot struct {
+	Project        string   `json:"project" yaml:"project"`
+	Name           string   `json:"name" yaml:"name"`
+	URL            string   `json:"url" yaml:"url"`
+	Path           string   `json:"path" yaml:"path"`
+	Cloned         bool     `json:"cloned" yaml:"cloned"`
+	Branch         string   `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Detached       bool     `json:"detached" yaml:"detached"`
+	HasUpstream    bool     `json:"has_upstream" yaml:"has_upstream"`
+	Ahead          int      `json:"ahead" yaml:"ahead"`
+	Behind         int      `json:"behind" yaml:"behind"`
+	Unstaged       int      `json:"unstaged" yaml:"unstaged"`
+	Staged         int      `json:"staged" yaml:"staged"`
+	Untracked      int      `json:"untracked" yaml:"untracked"`
+	Conflicts      int      `json:"conflicts" yaml:"conflicts"`
+	OngoingOp      string   `json:"ongoing_op,omitempty" yaml:"ongoing_op,omitempty"`
+	Worktrees      []string `json:"worktrees,omitempty" yaml:"worktrees,omitempty"`
+	NeedsAttention []string `json:"needs_attention,omitempty" yaml:"needs_attention,omitempty"`
+	Error          string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// projectSnapshot is the structured form of a project's repositories.
+type projectSnapshot struct {
+	Name  string         `json:"name" yaml:"name"`
+	Repos []repoSnapshot `json:"repos" yaml:"repos"`
+}
+
+// dashboardSnapshot is the document printed by a structured snapshot.
+type dashboardSnapshot struct {
+	GeneratedAt    time.Time         `json:"generated_at" yaml:"generated_at"`
+	NeedsAttention int               `json:"needs_attention" yaml:"needs_attention"`
+	Projects       []projectSnapshot `json:"projects" yaml:"projects"`
+}
+
+// Snapshot collects the status of every configured repository, as the dashboard does, and writes a
+// single static render of it, or JSON or YAML, to w. It needs no terminal, so it suits status lines, MOTD
+// scripts, and cron jobs.
+func Snapshot(w io.Writer, opts SnapshotOptions) error {
+	projects := config.GetProjects()
+	if len(projects) == 0 {
+		return theme.NewActionableError(
+			fmt.Errorf("no projects configured"),
+			"Use 'eng project add' to add a project before taking a snapshot.",
+		)
+	}
+
+	m := NewModel(projects, devPathFromConfig(), "")
+	m.collectStatuses(opts.Refresh)
+
+	if opts.Format.IsStructured() {
+		return cmdutil.WriteStructured(w, opts.Format, m.snapshot())
+	}
+
+	width := opts.Width
+	if width <= 0 {
+		width = 100
+	}
+	_, err := fmt.Fprint(w, m.renderSnapshot(width))
+	return err
+}
+
+// collectStatuses checks every configured repository and applies the results to the model.
+func (m *Model) collectStatuses(refresh bool) {
+	var refs []repoRef
+	for _, p := range m.projects {
+		for _, r := range p.Repos {
+			refs = append(refs, repoRef{Project: p.Name, Repo: r})
+		}
+	}
+
+	msgs := make([]statusMsg, len(refs))
+	sem := make(chan struct{}, snapshotConcurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			msgs[i] = checkRepoStatus(ref.Project, ref.Repo, m.devPath, refresh).(statusMsg)
+		}()
+	}
+	wg.Wait()
+
+	for _, msg := range msgs {
+		next, _ := m.Update(msg)
+		*m = next.(Model)
+	}
+}
+
+// snapshot returns the structured document of the collected statuses.
+func (m Model) snapshot() dashboardSnapshot {
+	doc := dashboardSnapshot{GeneratedAt: time.Now(), Projects: []projectSnapshot{}}
+	for _, p := range m.projects {
+		ps := projectSnapshot{Name: p.Name, Repos: []repoSnapshot{}}
+		for _, r := range p.Repos {
+			ref := repoRef{Project: p.Name, Repo: r}
+			status := m.repoStatuses[ref.Key()]
+			rs := repoSnapshot{
+				Project:        p.Name,
+				Name:           ref.Name(),
+				URL:            r.URL,
+				Path:           ref.FullPath(m.devPath),
+				Cloned:         status.IsCloned,
+				Branch:         status.Branch,
+				Detached:       status.IsDetached,
+				HasUpstream:    status.HasUpstream,
+				Ahead:          status.AheadCount,
+				Behind:         status.BehindCount,
+				Unstaged:       status.UnstagedCount,
+				Staged:         status.StagedCount,
+				Untracked:      status.UntrackedCount,
+				Conflicts:      status.ConflictCount,
+				OngoingOp:      status.OngoingOp,
+				Worktrees:      status.Worktrees,
+				NeedsAttention: attentionReasons(status),
+			}
+			if status.Error != nil {
+				rs.Error = status.Error.Error()
+			}
+			if attentionSeverity(status) > 0 {
+				doc.NeedsAttention++
+			}
+			ps.Repos = append(ps.Repos, rs)
+		}
+		doc.Projects = append(doc.Projects, ps)
+	}
+	return doc
+}
+
+// renderSnapshot renders the "Needs attention" entry and each project with the dashboard's repository
+// table, with nothing selected.
+func (m Model) renderSnapshot(width int) string {
+	m.focusedPane = FocusLeft
+	m.repoScrollOffset = 0
+
+	var sections []string
+	for i, item := range m.list.Items() {
+		m.list.Select(i)
+		title := item.(ProjectItem).Title()
+		if m.attentionSelected() {
+			title = m.attentionHeader()
+		}
+		refs := m.visibleRepos()
+		section := projectNameStyle.Render(title)
+		if len(refs) > 0 {
+			section += "\n" + m.renderRepoTable(refs, width, len(refs)+2)
+		}
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n\n") + "\n"
+}
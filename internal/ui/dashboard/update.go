@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -32,37 +33,28 @@ type statusMsg struct {
 
 func (m Model) Init() tea.Cmd {
 	// Automatically trigger loading statuses for the initially selected project.
-	return m.loadSelectedProjectStatusesCmd()
+	return tea.Batch(m.loadSelectedProjectStatusesCmd(), m.watcher.waitCmd())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
+	if m.commitForm != nil {
+		// The commit form owns the keyboard; other messages still reach the dashboard.
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m.updateCommitForm(msg)
+		}
+		m, cmd = m.updateCommitForm(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.windowWidth = msg.Width
 		m.windowHeight = msg.Height
 
-		var listWidth, listHeight int
-		if m.isCompactMode() {
-			listWidth = msg.Width - 6
-			listHeight = msg.Height - 6
-		} else {
-			totalPanesWidth := msg.Width - 4
-			leftPaneOuterWidth := totalPanesWidth / 4
-			if leftPaneOuterWidth < 20 {
-				leftPaneOuterWidth = 20
-			}
-			if leftPaneOuterWidth > 30 {
-				leftPaneOuterWidth = 30
-			}
-
-			leftStyleWidth := leftPaneOuterWidth - 2
-			listWidth = leftStyleWidth - 2
-			listHeight = msg.Height - 6
-		}
-
+		listWidth, listHeight, _, _ := m.paneSizes()
 		if listWidth < 1 {
 			listWidth = 1
 		}
@@ -73,7 +65,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ready = true
 
 	case tea.KeyMsg:
-		if msg.String() == "ctrl+c" || msg.String() == "q" {
+		// Ctrl+C always quits, even when the quit binding is remapped.
+		if msg.String() == "ctrl+c" || key.Matches(msg, m.keys.Quit) {
 			return m, tea.Quit
 		}
 
@@ -90,8 +83,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
-		switch msg.String() {
-		case "tab":
+		if m.confirmService != "" {
+			return m.updateServiceConfirm(msg)
+		}
+
+		if m.list.FilterState() != list.Filtering {
+			switch {
+			case key.Matches(msg, m.keys.NextView):
+				return m.switchView(1)
+			case key.Matches(msg, m.keys.PrevView):
+				return m.switchView(-1)
+			}
+		}
+		if m.view != ViewProjects && !key.Matches(msg, m.keys.Help) {
+			return m.updateServices(msg)
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.NextPane):
 			if m.focusedPane == FocusLeft {
 				m.focusedPane = FocusRight
 				m.selectedRepoIndex = 0
@@ -100,29 +109,61 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.clampScrollOffset()
 			return m, nil
-		case "enter", "l", "right":
+		case key.Matches(msg, m.keys.Focus):
 			if m.focusedPane == FocusLeft {
 				m.focusedPane = FocusRight
 				m.selectedRepoIndex = 0
 				m.clampScrollOffset()
 				return m, nil
 			}
-		case "esc", "h", "left":
+		case key.Matches(msg, m.keys.Back):
+			if m.focusedPane == FocusDetail {
+				m.focusedPane = FocusRight
+				return m, nil
+			}
 			if m.focusedPane == FocusRight {
 				m.focusedPane = FocusLeft
 				m.clampScrollOffset()
 				return m, nil
 			}
-		case "j", "down":
+		case key.Matches(msg, m.keys.Details):
+			switch m.focusedPane {
+			case FocusDetail:
+				m.focusedPane = FocusRight
+				return m, nil
+			case FocusRight:
+				ref, ok := m.selectedRepo()
+				if !ok {
+					return m, nil
+				}
+				if status, exists := m.repoStatuses[ref.Key()]; exists && !status.Loading && !status.IsCloned {
+					m.notificationID++
+					m.notification = "Not cloned: nothing to show"
+					m.notificationStyle = notificationErrorStyle
+					m.notificationType = NotifyError
+					return m, m.delayClearNotificationCmd(m.notificationID)
+				}
+				m.focusedPane = FocusDetail
+				m.detailScrollOffset = 0
+				return m, m.loadSelectedRepoDetailCmd()
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.focusedPane == FocusDetail {
+				m.scrollDetail(1)
+				return m, nil
+			}
 			if m.focusedPane == FocusRight {
-				item, ok := m.list.SelectedItem().(ProjectItem)
-				if ok && m.selectedRepoIndex < len(item.Project.Repos)-1 {
+				if m.selectedRepoIndex < len(m.visibleRepos())-1 {
 					m.selectedRepoIndex++
 				}
 				m.clampScrollOffset()
 				return m, nil
 			}
-		case "k", "up":
+		case key.Matches(msg, m.keys.Up):
+			if m.focusedPane == FocusDetail {
+				m.scrollDetail(-1)
+				return m, nil
+			}
 			if m.focusedPane == FocusRight {
 				if m.selectedRepoIndex > 0 {
 					m.selectedRepoIndex--
@@ -130,10 +171,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.clampScrollOffset()
 				return m, nil
 			}
-		case "?":
+		case key.Matches(msg, m.keys.Help):
 			m.showHelp = true
 			return m, nil
-		case "e":
+		case key.Matches(msg, m.keys.Mark) && m.focusedPane == FocusRight:
+			m.toggleMark()
+			return m, nil
+		case key.Matches(msg, m.keys.ClearMarks) && len(m.marked) > 0 && m.list.FilterState() != list.Filtering:
+			m.marked = make(map[string]bool)
+			return m, nil
+		case key.Matches(msg, m.keys.Edit):
 			cmd, err := m.openInEditorCmd()
 			if err != nil {
 				m.notificationID++
@@ -143,7 +190,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.delayClearNotificationCmd(m.notificationID)
 			}
 			return m, cmd
-		case "E":
+		case key.Matches(msg, m.keys.EditPicker):
 			cmd, err := m.openInCustomEditorCmd()
 			if err != nil {
 				m.notificationID++
@@ -153,7 +200,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.delayClearNotificationCmd(m.notificationID)
 			}
 			return m, cmd
-		case "t":
+		case key.Matches(msg, m.keys.Terminal):
 			cmd, err := m.openInTerminalCmd()
 			if err != nil {
 				m.notificationID++
@@ -163,15 +210,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.delayClearNotificationCmd(m.notificationID)
 			}
 			return m, cmd
-		case "f", "p", "o", "c", "s":
+		case key.Matches(msg, m.keys.Fetch, m.keys.Pull, m.keys.Sync, m.keys.Clone, m.keys.Open):
 			// Handle actions based on focus
-			resModel, cmd := handleAction(m, msg.String())
+			resModel, cmd := handleAction(m, m.repoActionCode(msg))
 			m = resModel.(Model)
 			m.clampScrollOffset()
 			return m, cmd
-		case "r":
+		case key.Matches(msg, m.keys.Commit):
+			return m, m.startCommit()
+		case key.Matches(msg, m.keys.Refresh):
+			if m.focusedPane == FocusDetail {
+				return m, tea.Batch(m.forceRefreshSelectedProjectStatusesCmd(), m.loadSelectedRepoDetailCmd())
+			}
 			return m, m.forceRefreshSelectedProjectStatusesCmd()
-		case "a":
+		case key.Matches(msg, m.keys.Add):
 			cmd := m.addProjectOrRepoCmd()
 			return m, cmd
 		}
@@ -205,11 +257,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.projects = msg.projects
-		items := make([]list.Item, len(m.projects))
-		for i, p := range m.projects {
-			items[i] = ProjectItem{Project: p}
-		}
-		m.list.SetItems(items)
+		m.list.SetItems(projectItems(m.projects))
 
 		// Focus the target project
 		for idx, item := range m.list.Items() {
@@ -222,13 +270,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Focus the new repository
 		m.selectedRepoIndex = 0
 		m.repoScrollOffset = 0
-		project := config.GetProjectByName(msg.targetProject)
-		if project != nil {
-			for idx, r := range project.Repos {
-				if r.URL == msg.addedRepo {
-					m.selectedRepoIndex = idx
-					break
-				}
+		for idx, ref := range m.visibleRepos() {
+			if ref.Repo.URL == msg.addedRepo {
+				m.selectedRepoIndex = idx
+				break
 			}
 		}
 
@@ -252,6 +297,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.hasError = true
 			m.lastError = msg.err
+			m.completedActions += m.skipChainedActions()
 		}
 		m.completedActions++
 
@@ -261,7 +307,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		} else {
 			m.actionState = ""
-			cmds = append(cmds, m.forceRefreshSelectedProjectStatusesCmd())
+			m.bulkCompleted = nil
+			cmds = append(cmds, m.forceRefreshSelectedProjectStatusesCmd(), m.loadViewCmd())
+			if m.focusedPane == FocusDetail {
+				cmds = append(cmds, m.loadSelectedRepoDetailCmd())
+			}
 
 			m.notificationID++
 			if m.hasError {
@@ -305,11 +355,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case logLineMsg:
 		m.actionLogs = append(m.actionLogs, msg.line)
+		if m.bulkCompleted != nil {
+			m.completedActions = int(m.bulkCompleted.Load())
+		}
 		cmds = append(cmds, readLogCmd(msg.scanner))
 
 	case statusMsg:
 		key := msg.ProjectName + msg.RepoURL
 		m.repoStatuses[key] = msg.Status
+
+	case detailMsg:
+		m.repoDetails[msg.Key] = msg.Detail
+
+	case repoChangedMsg:
+		cmds = append(cmds, m.refreshChangedReposCmd(msg.Keys), m.watcher.waitCmd())
+
+	case stacksMsg:
+		m.stacksLoading = false
+		m.stacks = msg.Stacks
+		m.stacksErr = msg.Err
+		if msg.Details != nil {
+			m.stackDetails = msg.Details
+		}
+		m.selectedStackIndex = min(m.selectedStackIndex, max(0, len(m.stacks)-1))
+
+	case immichStatusMsg:
+		m.immichLoading = false
+		m.immichStatus = msg.Status
+		m.immichErr = msg.Err
+
+	case serviceLogsMsg:
+		if m.serviceLogs != nil && m.serviceLogs.Title == msg.Title {
+			m.serviceLogs.Loading = false
+			m.serviceLogs.Lines = msg.Lines
+			m.serviceLogs.Err = msg.Err
+			// Open on the most recent lines.
+			m.scrollServiceLogs(len(msg.Lines))
+		}
 	}
 
 	m.clampScrollOffset()
@@ -317,35 +399,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) clampScrollOffset() {
-	item, ok := m.list.SelectedItem().(ProjectItem)
-	if !ok {
-		m.repoScrollOffset = 0
-		return
-	}
-	p := item.Project
-	if len(p.Repos) == 0 {
+	repoCount := len(m.visibleRepos())
+	if repoCount == 0 {
+		m.selectedRepoIndex = 0
 		m.repoScrollOffset = 0
 		return
 	}
 
-	var innerRightWidth, innerRightHeight int
-	if m.isCompactMode() {
-		innerRightWidth = m.windowWidth - 6
-		innerRightHeight = m.windowHeight - 6
-	} else {
-		totalPanesWidth := m.windowWidth - 4
-		leftPaneOuterWidth := totalPanesWidth / 4
-		if leftPaneOuterWidth < 20 {
-			leftPaneOuterWidth = 20
-		}
-		if leftPaneOuterWidth > 30 {
-			leftPaneOuterWidth = 30
-		}
-		rightPaneOuterWidth := totalPanesWidth - leftPaneOuterWidth
-		rightStyleWidth := rightPaneOuterWidth - 2
-		innerRightWidth = rightStyleWidth - 2
-		innerRightHeight = m.windowHeight - 6
-	}
+	_, _, innerRightWidth, innerRightHeight := m.paneSizes()
 
 	if innerRightHeight < 3 {
 		m.repoScrollOffset = 0
@@ -356,9 +417,12 @@ func (m *Model) clampScrollOffset() {
 		H_repos = 1
 	}
 
-	if m.selectedRepoIndex < 0 || m.selectedRepoIndex >= len(p.Repos) {
+	if m.selectedRepoIndex < 0 {
 		m.selectedRepoIndex = 0
 	}
+	if m.selectedRepoIndex >= repoCount {
+		m.selectedRepoIndex = repoCount - 1
+	}
 
 	if innerRightWidth >= 75 {
 		// Table view scroll clamping (one row per repository)
@@ -373,7 +437,7 @@ func (m *Model) clampScrollOffset() {
 			m.repoScrollOffset = m.selectedRepoIndex - H_body + 1
 		}
 
-		maxScroll := len(p.Repos) - H_body
+		maxScroll := repoCount - H_body
 		if maxScroll < 0 {
 			maxScroll = 0
 		}
@@ -430,30 +494,54 @@ func (m Model) delayClearNotificationCmd(id int) tea.Cmd {
 	})
 }
 
+// repoActionCode returns the action code of a fetch, pull, sync, clone, or open key.
+func (m Model) repoActionCode(msg tea.KeyMsg) string {
+	switch {
+	case key.Matches(msg, m.keys.Fetch):
+		return "f"
+	case key.Matches(msg, m.keys.Pull):
+		return "p"
+	case key.Matches(msg, m.keys.Sync):
+		return "s"
+	case key.Matches(msg, m.keys.Clone):
+		return "c"
+	}
+	return "o"
+}
+
 func handleAction(m Model, action string) (tea.Model, tea.Cmd) {
-	item, ok := m.list.SelectedItem().(ProjectItem)
-	if !ok {
+	if _, ok := m.list.SelectedItem().(ProjectItem); !ok {
 		return m, nil
 	}
-	p := item.Project
 
 	m.actionQueue = []ActionItem{}
 	m.actionLogs = []string{}
 	m.hasError = false
 	m.lastError = nil
 
-	if m.focusedPane == FocusRight {
-		if len(p.Repos) == 0 {
-			return m, nil
+	// Marked repositories take precedence over the focused pane and run concurrently.
+	if marked := m.markedRepos(); len(marked) > 0 {
+		items := make([]ActionItem, len(marked))
+		for i, ref := range marked {
+			items[i] = ActionItem{
+				Action:   action,
+				RepoName: ref.Repo.URL,
+				FullPath: ref.FullPath(m.devPath),
+				Label:    ref.Project + "/" + ref.Name(),
+			}
 		}
-		repoDef := p.Repos[m.selectedRepoIndex]
-		relPath, _ := repoDef.GetEffectivePath()
-		fullPath := filepath.Join(m.devPath, p.Name, relPath)
+		var cmd tea.Cmd
+		m, cmd = m.runBulkActions(items)
+		return m, tea.Batch(m.spinner.Tick, cmd)
+	}
 
-		repoName, err := repoDef.GetEffectivePath()
-		if err != nil {
-			repoName = repoDef.URL
+	if m.repoFocused() {
+		ref, ok := m.selectedRepo()
+		if !ok {
+			return m, nil
 		}
+		fullPath := ref.FullPath(m.devPath)
+		repoName := ref.Name()
 
 		cloned := repo.IsCloned(fullPath)
 		if action == "c" && cloned {
@@ -473,17 +561,15 @@ func handleAction(m Model, action string) (tea.Model, tea.Cmd) {
 
 		m.actionQueue = append(m.actionQueue, ActionItem{
 			Action:   action,
-			RepoName: repoDef.URL,
+			RepoName: ref.Repo.URL,
 			FullPath: fullPath,
 		})
 	} else {
-		for _, repoDef := range p.Repos {
-			relPath, _ := repoDef.GetEffectivePath()
-			fullPath := filepath.Join(m.devPath, p.Name, relPath)
+		for _, ref := range m.visibleRepos() {
 			m.actionQueue = append(m.actionQueue, ActionItem{
 				Action:   action,
-				RepoName: repoDef.URL,
-				FullPath: fullPath,
+				RepoName: ref.Repo.URL,
+				FullPath: ref.FullPath(m.devPath),
 			})
 		}
 	}
@@ -526,26 +612,10 @@ func (m Model) popAndRunNextAction() (Model, tea.Cmd) {
 	item := m.actionQueue[0]
 	m.actionQueue = m.actionQueue[1:]
 
-	var actionName string
-	switch item.Action {
-	case "f":
-		actionName = "Fetching"
-	case "p":
-		actionName = "Pulling"
-	case "s":
-		actionName = "Syncing"
-	case "c":
-		actionName = "Setting up"
-	case "o":
-		actionName = "Opening"
-	}
+	prettyName := actionTargetName(item)
+	stacks, photos := *m.stackManager, *m.immichManager
 
-	prettyName, err := config.RepoNameFromURL(item.RepoName)
-	if err != nil {
-		prettyName = item.RepoName
-	}
-
-	m.actionState = fmt.Sprintf("%s %s...", actionName, prettyName)
+	m.actionState = fmt.Sprintf("%s %s...", actionNames[item.Action], prettyName)
 
 	pr, pw := io.Pipe()
 
@@ -554,75 +624,90 @@ func (m Model) popAndRunNextAction() (Model, tea.Cmd) {
 		log.SetWriters(pw, pw)
 		defer log.ResetWriters()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		timeout := 30 * time.Second
+		if isServiceAction(item.Action) {
+			timeout = serviceActionTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
 		var err error
 
-		cloned := repo.IsCloned(item.FullPath)
-		if item.Action == "c" && cloned {
-			log.Warn("Already cloned: %s", prettyName)
-			pw.Close()
-			return
-		}
-		if item.Action != "c" && !cloned {
-			log.Warn("Skipping: %s (not cloned)", prettyName)
+		if reason := skipReason(item, prettyName); reason != "" {
+			log.Warn("%s", reason)
 			pw.Close()
 			return
 		}
+		stacks.Output = pw
+		photos.Output = pw
 
 		switch item.Action {
-		case "f":
-			log.Info("Fetching %s...", prettyName)
-			err = repo.FetchAllPrune(ctx, item.FullPath)
+		case "f", "p", "s", "c", "o":
+			log.Info("%s %s...", actionNames[item.Action], prettyName)
+			err = runRepoAction(ctx, item, pw)
+			if err == nil {
+				log.Success("%s", repoActionDone[item.Action])
+			}
+		case "stage":
+			if len(item.Unstage) > 0 {
+				log.Info("Unstaging %d file(s)...", len(item.Unstage))
+				err = repo.UnstageFiles(ctx, item.FullPath, item.Unstage)
+			}
 			if err == nil {
-				log.Success("Fetch completed successfully!")
-			}
-		case "p":
-			log.Info("Pulling %s...", prettyName)
-			err = repo.PullLatestCode(ctx, item.FullPath)
-			if errors.Is(err, git.NoErrAlreadyUpToDate) {
-				log.Info("Already up to date.")
-				err = nil
-			} else if err == nil {
-				log.Success("Pull completed successfully!")
-			}
-		case "s":
-			log.Info("Syncing %s...", prettyName)
-			log.Info("1/2 Fetching...")
-			err = repo.FetchAllPrune(ctx, item.FullPath)
+				log.Info("Staging %d file(s)...", len(item.Stage))
+				err = repo.StageFiles(ctx, item.FullPath, item.Stage)
+			}
 			if err == nil {
-				log.Info("2/2 Pulling...")
-				err = repo.PullLatestCode(ctx, item.FullPath)
-				if errors.Is(err, git.NoErrAlreadyUpToDate) {
-					log.Info("Already up to date.")
-					err = nil
-				}
+				log.Success("Files staged.")
 			}
+		case "commit":
+			subject, _, _ := strings.Cut(item.Message, "\n")
+			log.Info("Committing %q...", subject)
+			err = repo.Commit(ctx, item.FullPath, item.Message)
 			if err == nil {
-				log.Success("Sync completed successfully!")
+				log.Success("Commit created.")
 			}
-		case "c":
-			log.Info("Cloning %s...", prettyName)
-			parentDir := filepath.Dir(item.FullPath)
-			err = os.MkdirAll(parentDir, 0o755)
+		case "push":
+			log.Info("Pushing %s...", prettyName)
+			err = repo.Push(ctx, item.FullPath, repo.PushOptions{SetUpstream: item.SetUpstream})
 			if err == nil {
-				cmd := exec.CommandContext(ctx, "git", "clone", item.RepoName, item.FullPath)
-				cmd.Stdout = pw
-				cmd.Stderr = pw
-				err = cmd.Run()
+				log.Success("Push completed successfully!")
 			}
+		case "stack-up":
+			log.Info("Bringing up %s (%s)...", prettyName, stacksUpEnv)
+			err = stacks.Up([]string{item.Stack}, stacksUpEnv, true, false)
 			if err == nil {
-				log.Success("Clone completed successfully!")
-			}
-		case "o":
-			log.Info("Opening %s...", item.FullPath)
-			cmd := exec.CommandContext(ctx, "open", item.FullPath)
-			cmd.Stdout = pw
-			cmd.Stderr = pw
-			err = cmd.Run()
+				log.Success("Stack is up.")
+			}
+		case "stack-down":
+			log.Info("Bringing down %s...", prettyName)
+			err = stacks.Down([]string{item.Stack}, false)
 			if err == nil {
-				log.Success("Directory opened.")
+				log.Success("Stack is down.")
+			}
+		case "stack-restart":
+			log.Info("Restarting %s...", prettyName)
+			err = stacks.Restart([]string{item.Stack})
+			if err == nil {
+				log.Success("Stack restarted.")
+			}
+		case "immich-up":
+			log.Info("Starting Immich...")
+			err = photos.Start(ctx)
+			if err == nil {
+				log.Success("Immich started.")
+			}
+		case "immich-down":
+			log.Info("Stopping Immich...")
+			err = photos.Stop(ctx)
+			if err == nil {
+				log.Success("Immich stopped.")
+			}
+		case "immich-restart":
+			log.Info("Restarting Immich...")
+			err = photos.Restart(ctx)
+			if err == nil {
+				log.Success("Immich restarted.")
 			}
 		}
 
@@ -638,18 +723,116 @@ func (m Model) popAndRunNextAction() (Model, tea.Cmd) {
 	return m, readLogCmd(scanner)
 }
 
-// loadSelectedProjectStatusesCmd generates tea.Cmds to fetch the status of each repo in the currently selected project.
-func (m *Model) loadSelectedProjectStatusesCmd() tea.Cmd {
-	item, ok := m.list.SelectedItem().(ProjectItem)
-	if !ok {
+// actionNames are the progress verbs of the queued actions.
+var actionNames = map[string]string{
+	"f":              "Fetching",
+	"p":              "Pulling",
+	"s":              "Syncing",
+	"c":              "Setting up",
+	"o":              "Opening",
+	"stage":          "Staging",
+	"commit":         "Committing",
+	"push":           "Pushing",
+	"stack-up":       "Starting",
+	"immich-up":      "Starting",
+	"stack-down":     "Stopping",
+	"immich-down":    "Stopping",
+	"stack-restart":  "Restarting",
+	"immich-restart": "Restarting",
+}
+
+// repoActionDone are the success messages of the repository actions run by runRepoAction.
+var repoActionDone = map[string]string{
+	"f": "Fetch completed successfully!",
+	"p": "Pull completed successfully!",
+	"s": "Sync completed successfully!",
+	"c": "Clone completed successfully!",
+	"o": "Directory opened.",
+}
+
+// actionTargetName returns the name of the repository, stack, or service an action applies to.
+func actionTargetName(item ActionItem) string {
+	if item.Label != "" {
+		return item.Label
+	}
+	if item.Stack != "" {
+		return item.Stack
+	}
+	if strings.HasPrefix(item.Action, "immich-") {
+		return "Immich"
+	}
+	prettyName, err := config.RepoNameFromURL(item.RepoName)
+	if err != nil {
+		return item.RepoName
+	}
+	return prettyName
+}
+
+// skipReason explains why a queued repository action no longer applies, as the repository was
+// cloned or removed since it was queued. It returns "" when the action should run.
+func skipReason(item ActionItem, name string) string {
+	if isServiceAction(item.Action) {
+		return ""
+	}
+	cloned := repo.IsCloned(item.FullPath)
+	if item.Action == "c" && cloned {
+		return fmt.Sprintf("Already cloned: %s", name)
+	}
+	if item.Action != "c" && !cloned {
+		return fmt.Sprintf("Skipping: %s (not cloned)", name)
+	}
+	return ""
+}
+
+// runRepoAction fetches, pulls, syncs, clones, or opens the repository of item, writing progress and
+// command output to out.
+func runRepoAction(ctx context.Context, item ActionItem, out io.Writer) error {
+	switch item.Action {
+	case "f":
+		return repo.FetchAllPrune(ctx, item.FullPath)
+	case "p":
+		return pullRepo(ctx, item.FullPath, out)
+	case "s":
+		fmt.Fprintln(out, "1/2 Fetching...")
+		if err := repo.FetchAllPrune(ctx, item.FullPath); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "2/2 Pulling...")
+		return pullRepo(ctx, item.FullPath, out)
+	case "c":
+		if err := os.MkdirAll(filepath.Dir(item.FullPath), 0o755); err != nil {
+			return err
+		}
+		cmd := exec.CommandContext(ctx, "git", "clone", item.RepoName, item.FullPath)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		return cmd.Run()
+	case "o":
+		cmd := exec.CommandContext(ctx, "open", item.FullPath)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		return cmd.Run()
+	}
+	return fmt.Errorf("unknown repository action %q", item.Action)
+}
+
+// pullRepo pulls the latest code, treating an up-to-date repository as success.
+func pullRepo(ctx context.Context, path string, out io.Writer) error {
+	err := repo.PullLatestCode(ctx, path)
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		fmt.Fprintln(out, "Already up to date.")
 		return nil
 	}
+	return err
+}
 
-	p := item.Project
+// loadSelectedProjectStatusesCmd generates tea.Cmds to fetch the status of each repo in the currently selected project.
+func (m *Model) loadSelectedProjectStatusesCmd() tea.Cmd {
 	var cmds []tea.Cmd
+	m.watchSelectedProject()
 
-	for _, r := range p.Repos {
-		key := p.Name + r.URL
+	for _, ref := range m.trackedRepos() {
+		key := ref.Key()
 		// Only load if not already loaded or loading
 		if status, exists := m.repoStatuses[key]; !exists || (!status.IsCloned && !status.Loading) {
 			// Mark as loading
@@ -658,12 +841,12 @@ func (m *Model) loadSelectedProjectStatusesCmd() tea.Cmd {
 			m.repoStatuses[key] = s
 
 			// Capture loop variables
-			projectName := p.Name
-			repoDef := r
+			projectName := ref.Project
+			repoDef := ref.Repo
 			devPath := m.devPath
 
 			cmds = append(cmds, func() tea.Msg {
-				return checkRepoStatus(projectName, repoDef, devPath)
+				return limitedCheckRepoStatus(projectName, repoDef, devPath, false)
 			})
 		}
 	}
@@ -673,35 +856,84 @@ func (m *Model) loadSelectedProjectStatusesCmd() tea.Cmd {
 
 // forceRefreshSelectedProjectStatusesCmd generates tea.Cmds to fetch the status of each repo in the currently selected project, bypassing any caching.
 func (m *Model) forceRefreshSelectedProjectStatusesCmd() tea.Cmd {
-	item, ok := m.list.SelectedItem().(ProjectItem)
-	if !ok {
-		return nil
-	}
-
-	p := item.Project
 	var cmds []tea.Cmd
+	m.watchSelectedProject()
 
-	for _, r := range p.Repos {
-		key := p.Name + r.URL
+	for _, ref := range m.trackedRepos() {
+		key := ref.Key()
 		s := m.repoStatuses[key]
 		s.Loading = true
 		s.Error = nil
 		m.repoStatuses[key] = s
 
 		// Capture loop variables
-		projectName := p.Name
-		repoDef := r
+		projectName := ref.Project
+		repoDef := ref.Repo
 		devPath := m.devPath
 
 		cmds = append(cmds, func() tea.Msg {
-			return checkRepoStatus(projectName, repoDef, devPath)
+			return limitedCheckRepoStatus(projectName, repoDef, devPath, true)
 		})
 	}
 
 	return tea.Batch(cmds...)
 }
 
-func checkRepoStatus(projectName string, repoDef config.ProjectRepo, devPath string) tea.Msg {
+// watchSelectedProject points the watcher at the cloned repositories the right pane depends on.
+func (m *Model) watchSelectedProject() {
+	if m.watcher == nil {
+		return
+	}
+	repos := make(map[string]string)
+	for _, ref := range m.trackedRepos() {
+		if fullPath := ref.FullPath(m.devPath); repo.IsCloned(fullPath) {
+			repos[ref.Key()] = fullPath
+		}
+	}
+	m.watcher.Set(repos)
+}
+
+// refreshChangedReposCmd re-reads the status of changed repositories in the right pane, and
+// their detail when it is open. Statuses are replaced in place so the view does not flicker.
+func (m *Model) refreshChangedReposCmd(keys []string) tea.Cmd {
+	changed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		changed[key] = true
+	}
+
+	var cmds []tea.Cmd
+	for _, ref := range m.trackedRepos() {
+		if !changed[ref.Key()] {
+			continue
+		}
+		projectName := ref.Project
+		repoDef := ref.Repo
+		devPath := m.devPath
+		cmds = append(cmds, func() tea.Msg {
+			return limitedCheckRepoStatus(projectName, repoDef, devPath, true)
+		})
+	}
+	if ref, ok := m.selectedRepo(); ok && changed[ref.Key()] && m.focusedPane == FocusDetail {
+		cmds = append(cmds, m.loadSelectedRepoDetailCmd())
+	}
+	return tea.Batch(cmds...)
+}
+
+// statusCheckSlots bounds how many status checks the dashboard runs at once, so that the checks of
+// the "Needs attention" entry, which covers every project, do not run into their timeout.
+var statusCheckSlots = make(chan struct{}, snapshotConcurrency)
+
+// limitedCheckRepoStatus runs checkRepoStatus once a status check slot is free. The check's timeout
+// only starts once it runs.
+func limitedCheckRepoStatus(projectName string, repoDef config.ProjectRepo, devPath string, refresh bool) tea.Msg {
+	statusCheckSlots <- struct{}{}
+	defer func() { <-statusCheckSlots }()
+	return checkRepoStatus(projectName, repoDef, devPath, refresh)
+}
+
+// checkRepoStatus reads the status of a single repository. Cached statuses are used
+// unless refresh is set, in which case git is queried directly and the cache updated.
+func checkRepoStatus(projectName string, repoDef config.ProjectRepo, devPath string, refresh bool) tea.Msg {
 	// Allow a very brief timeout since this blocks UI refresh momentarily if it hangs
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -729,7 +961,12 @@ func checkRepoStatus(projectName string, repoDef config.ProjectRepo, devPath str
 	}
 
 	if isCloned {
-		info, err := repo.GetDetailedStatus(ctx, fullPath)
+		getStatus := repo.GetDetailedStatus
+		if refresh {
+			getStatus = repo.RefreshDetailedStatus
+		}
+
+		info, err := getStatus(ctx, fullPath)
 		if err == nil {
 			status.Branch = info.Branch
 			status.IsDetached = info.IsDetached
@@ -745,6 +982,16 @@ func checkRepoStatus(projectName string, repoDef config.ProjectRepo, devPath str
 		} else {
 			status.Error = err
 		}
+
+		if worktrees, err := repo.ListWorktrees(ctx, fullPath); err == nil && len(worktrees) > 1 {
+			for _, wt := range worktrees[1:] {
+				if wt.Branch != "" {
+					status.Worktrees = append(status.Worktrees, wt.Branch)
+				} else {
+					status.Worktrees = append(status.Worktrees, filepath.Base(wt.Path))
+				}
+			}
+		}
 	}
 
 	status.LastUpdated = time.Now()
@@ -760,28 +1007,38 @@ type editorFinishedMsg struct {
 	err error
 }
 
-func (m Model) openInEditorCmd() (tea.Cmd, error) {
+// targetPath returns the directory the editor and terminal actions open: the selected repository,
+// or the selected project's folder, which is created if needed.
+func (m Model) targetPath() (string, error) {
 	item, ok := m.list.SelectedItem().(ProjectItem)
 	if !ok {
-		return nil, fmt.Errorf("no project selected")
+		return "", fmt.Errorf("no project selected")
 	}
-	p := item.Project
 
-	var targetPath string
-	if m.focusedPane == FocusRight {
-		if len(p.Repos) == 0 {
-			return nil, fmt.Errorf("no repository selected")
+	if m.repoFocused() {
+		ref, ok := m.selectedRepo()
+		if !ok {
+			return "", fmt.Errorf("no repository selected")
 		}
-		repoDef := p.Repos[m.selectedRepoIndex]
-		relPath, _ := repoDef.GetEffectivePath()
-		targetPath = filepath.Join(m.devPath, p.Name, relPath)
-
+		targetPath := ref.FullPath(m.devPath)
 		if !repo.IsCloned(targetPath) {
-			return nil, fmt.Errorf("repository not cloned yet")
+			return "", fmt.Errorf("repository not cloned yet")
 		}
-	} else {
-		targetPath = filepath.Join(m.devPath, p.Name)
-		_ = os.MkdirAll(targetPath, 0o755)
+		return targetPath, nil
+	}
+
+	if item.Attention {
+		return "", fmt.Errorf("select a repository to open")
+	}
+	targetPath := filepath.Join(m.devPath, item.Project.Name)
+	_ = os.MkdirAll(targetPath, 0o755)
+	return targetPath, nil
+}
+
+func (m Model) openInEditorCmd() (tea.Cmd, error) {
+	targetPath, err := m.targetPath()
+	if err != nil {
+		return nil, err
 	}
 
 	execCmd := resolveEditorCommand(m.editor, targetPath)
@@ -792,27 +1049,9 @@ func (m Model) openInEditorCmd() (tea.Cmd, error) {
 }
 
 func (m Model) openInCustomEditorCmd() (tea.Cmd, error) {
-	item, ok := m.list.SelectedItem().(ProjectItem)
-	if !ok {
-		return nil, fmt.Errorf("no project selected")
-	}
-	p := item.Project
-
-	var targetPath string
-	if m.focusedPane == FocusRight {
-		if len(p.Repos) == 0 {
-			return nil, fmt.Errorf("no repository selected")
-		}
-		repoDef := p.Repos[m.selectedRepoIndex]
-		relPath, _ := repoDef.GetEffectivePath()
-		targetPath = filepath.Join(m.devPath, p.Name, relPath)
-
-		if !repo.IsCloned(targetPath) {
-			return nil, fmt.Errorf("repository not cloned yet")
-		}
-	} else {
-		targetPath = filepath.Join(m.devPath, p.Name)
-		_ = os.MkdirAll(targetPath, 0o755)
+	targetPath, err := m.targetPath()
+	if err != nil {
+		return nil, err
 	}
 
 	self, err := os.Executable()
@@ -828,27 +1067,9 @@ func (m Model) openInCustomEditorCmd() (tea.Cmd, error) {
 }
 
 func (m Model) openInTerminalCmd() (tea.Cmd, error) {
-	item, ok := m.list.SelectedItem().(ProjectItem)
-	if !ok {
-		return nil, fmt.Errorf("no project selected")
-	}
-	p := item.Project
-
-	var targetPath string
-	if m.focusedPane == FocusRight {
-		if len(p.Repos) == 0 {
-			return nil, fmt.Errorf("no repository selected")
-		}
-		repoDef := p.Repos[m.selectedRepoIndex]
-		relPath, _ := repoDef.GetEffectivePath()
-		targetPath = filepath.Join(m.devPath, p.Name, relPath)
-
-		if !repo.IsCloned(targetPath) {
-			return nil, fmt.Errorf("repository not cloned yet")
-		}
-	} else {
-		targetPath = filepath.Join(m.devPath, p.Name)
-		_ = os.MkdirAll(targetPath, 0o755)
+	targetPath, err := m.targetPath()
+	if err != nil {
+		return nil, err
 	}
 
 	// Detect terminal app in fallback chain: Ghostty -> iTerm -> Terminal
@@ -911,8 +1132,8 @@ func findAddedDiff(oldProjs, newProjs []config.Project) (targetProject, addedRep
 
 func (m Model) addProjectOrRepoCmd() tea.Cmd {
 	var preSelectedProject string
-	if m.focusedPane == FocusRight {
-		if item, ok := m.list.SelectedItem().(ProjectItem); ok {
+	if m.repoFocused() {
+		if item, ok := m.list.SelectedItem().(ProjectItem); ok && !item.Attention {
 			preSelectedProject = item.Project.Name
 		}
 	}
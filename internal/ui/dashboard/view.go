@@ -6,7 +6,6 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/eng618/eng/internal/config"
 	"github.com/eng618/eng/internal/ui/theme"
 )
 
@@ -29,12 +28,27 @@ func (m Model) View() string {
 	}
 
 	var mainView string
-	if m.isCompactMode() {
+	switch {
+	case m.view != ViewProjects:
+		mainView = m.renderServicesDashboard()
+	case m.isCompactMode():
 		mainView = m.renderCompactDashboard()
-	} else {
+	default:
 		mainView = m.renderFullDashboard()
 	}
 
+	if m.commitForm != nil {
+		modal := modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+			projectNameStyle.Render("Commit "+m.commitPlan.Branch),
+			"",
+			m.commitForm.View(),
+		))
+		return overlayStyle.
+			Width(m.windowWidth).
+			Height(m.windowHeight).
+			Render(lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, modal, lipgloss.WithWhitespaceChars(" ")))
+	}
+
 	if m.actionState != "" {
 		// Render Modal Overlay
 		var logLines string
@@ -54,10 +68,15 @@ func (m Model) View() string {
 				pct = 1.0
 			}
 			progressBar := renderProgressBar(30, pct)
+			unit := "repositories"
+			if m.view != ViewProjects {
+				unit = "actions"
+			}
 			progressInfo := fmt.Sprintf(
-				"%d of %d repositories processed (%d%%)",
+				"%d of %d %s processed (%d%%)",
 				m.completedActions,
 				m.totalActions,
+				unit,
 				int(pct*100),
 			)
 			progressLine = lipgloss.JoinVertical(lipgloss.Center,
@@ -98,30 +117,22 @@ func (m Model) renderFullDashboard() string {
 		rightStyle = activePaneStyle
 	}
 
-	totalPanesWidth := m.windowWidth - 4
-	leftPaneOuterWidth := totalPanesWidth / 4
-	if leftPaneOuterWidth < 20 {
-		leftPaneOuterWidth = 20
-	}
-	if leftPaneOuterWidth > 30 {
-		leftPaneOuterWidth = 30
-	}
-	rightPaneOuterWidth := totalPanesWidth - leftPaneOuterWidth
-
-	leftStyleWidth := leftPaneOuterWidth - 2
-	rightStyleWidth := rightPaneOuterWidth - 2
-
-	leftStyle = leftStyle.Width(leftStyleWidth).Height(m.windowHeight - 4)
-	rightStyle = rightStyle.Width(rightStyleWidth).Height(m.windowHeight - 4)
+	// Pane styles are sized by content plus padding; the border adds 2 in each direction.
+	listWidth, listHeight, rightWidth, rightHeight := m.paneSizes()
+	leftStyle = leftStyle.Width(listWidth + 2).Height(listHeight + 2)
+	rightStyle = rightStyle.Width(rightWidth + 2).Height(rightHeight + 2)
 
 	// Render Left Pane
-	leftContent := leftStyle.Render(limitLines(m.list.View(), m.windowHeight-6))
+	leftContent := leftStyle.Render(limitLines(m.list.View(), listHeight))
 
 	// Render Right Pane
-	rightContent := rightStyle.Render(limitLines(m.renderRightPane(), m.windowHeight-6))
+	rightContent := rightStyle.Render(limitLines(m.renderRightPane(), rightHeight))
 
 	// Combine panes
-	return appStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, leftContent, rightContent))
+	if m.layout == LayoutVertical {
+		return m.renderFrame(lipgloss.JoinVertical(lipgloss.Left, leftContent, rightContent))
+	}
+	return m.renderFrame(lipgloss.JoinHorizontal(lipgloss.Top, leftContent, rightContent))
 }
 
 func (m Model) renderCompactDashboard() string {
@@ -131,19 +142,27 @@ func (m Model) renderCompactDashboard() string {
 	var tab1, tab2 string
 	item, ok := m.list.SelectedItem().(ProjectItem)
 	projName := "Repos"
-	if ok {
+	if ok && item.Attention {
+		projName = "Needs attention"
+	} else if ok {
 		projName = fmt.Sprintf("Repos (%s)", truncate(item.Project.Name, 15))
 	}
 
-	if m.focusedPane == FocusLeft {
+	tab3 := tabInactiveStyle.Render("3: Details")
+	switch m.focusedPane {
+	case FocusLeft:
 		tab1 = tabActiveStyle.Render("[1: Projects]")
 		tab2 = tabInactiveStyle.Render("2: " + projName)
-	} else {
+	case FocusRight:
 		tab1 = tabInactiveStyle.Render("1: Projects")
 		tab2 = tabActiveStyle.Render("[2: " + projName + "]")
+	case FocusDetail:
+		tab1 = tabInactiveStyle.Render("1: Projects")
+		tab2 = tabInactiveStyle.Render("2: " + projName)
+		tab3 = tabActiveStyle.Render("[3: Details]")
 	}
 
-	tabBar := lipgloss.JoinHorizontal(lipgloss.Left, tab1, "  ", tab2)
+	tabBar := lipgloss.JoinHorizontal(lipgloss.Left, tab1, "  ", tab2, "  ", tab3)
 	b.WriteString(tabBar)
 	b.WriteString("\n")
 
@@ -158,17 +177,17 @@ func (m Model) renderCompactDashboard() string {
 		b.WriteString(limitLines(m.renderRightPane(), contentHeight))
 	}
 
-	paneWidth := m.windowWidth - 4
+	paneWidth := m.windowWidth - 6
 	if paneWidth < 10 {
 		paneWidth = 10
 	}
 
 	pane := compactPaneStyle.
 		Width(paneWidth).
-		Height(m.windowHeight - 2).
+		Height(m.windowHeight - 4).
 		Render(b.String())
 
-	return appStyle.Render(pane)
+	return m.renderFrame(pane)
 }
 
 func (m Model) renderRightPane() string {
@@ -176,41 +195,49 @@ func (m Model) renderRightPane() string {
 	if !ok {
 		return "No project selected."
 	}
-	p := item.Project
+	refs := m.visibleRepos()
 
 	var b strings.Builder
 
-	totalPanesWidth := m.windowWidth - 4
-	leftPaneOuterWidth := totalPanesWidth / 4
-	if leftPaneOuterWidth < 20 {
-		leftPaneOuterWidth = 20
+	_, _, innerRightWidth, innerRightHeight := m.paneSizes()
+
+	projectName := fmt.Sprintf("Project: %s", item.Project.Name)
+	if item.Attention {
+		projectName = m.attentionHeader()
 	}
-	if leftPaneOuterWidth > 30 {
-		leftPaneOuterWidth = 30
+	if n := len(m.marked); n > 0 {
+		projectName += fmt.Sprintf(" · %d marked", n)
 	}
-	rightPaneOuterWidth := totalPanesWidth - leftPaneOuterWidth
-	rightStyleWidth := rightPaneOuterWidth - 2
-	innerRightWidth := rightStyleWidth - 2
-
-	projectName := fmt.Sprintf("Project: %s", p.Name)
 	projectName = truncate(projectName, innerRightWidth)
 	b.WriteString(projectNameStyle.Render(projectName))
 	b.WriteString("\n\n")
 
-	if len(p.Repos) == 0 {
+	if len(refs) == 0 {
 		noReposStr := truncate("No repositories configured for this project.", innerRightWidth)
-		b.WriteString(statusMutedStyle.Render(noReposStr))
+		style := statusMutedStyle
+		if item.Attention {
+			noReposStr = truncate("Checking repositories...", innerRightWidth)
+			if m.pendingAttentionChecks() == 0 {
+				noReposStr = truncate("✓ Nothing needs attention: no conflicts, dirty, behind, or detached repositories.", innerRightWidth)
+				style = statusSuccessStyle
+			}
+		}
+		b.WriteString(style.Render(noReposStr))
 		return b.String()
 	}
 
-	innerRightHeight := m.windowHeight - 6
 	if innerRightHeight < 5 {
 		return "Terminal too small"
 	}
 	H_repos := innerRightHeight - 4
 
+	if m.focusedPane == FocusDetail {
+		b.WriteString(m.renderDetailPane(innerRightWidth))
+		return b.String()
+	}
+
 	if innerRightWidth >= 75 {
-		b.WriteString(m.renderRepoTable(p, innerRightWidth, H_repos))
+		b.WriteString(m.renderRepoTable(refs, innerRightWidth, H_repos))
 	} else {
 		allLines, _, _ := m.getRepoLines()
 
@@ -237,65 +264,65 @@ func (m Model) renderRightPane() string {
 		b.WriteString(strings.Join(lines, "\n"))
 	}
 
-	var footerText string
-	if m.notification != "" {
-		var prefix string
-		switch m.notificationType {
-		case NotifySuccess:
-			prefix = "✓ "
-		case NotifyError:
-			prefix = "✗ "
-		case NotifyWarn:
-			prefix = "⚠ "
-		}
-		footerText = prefix + m.notification
-		footerText = truncate(footerText, innerRightWidth)
-		b.WriteString("\n")
-		b.WriteString(m.notificationStyle.Render(footerText))
-	} else {
-		if m.focusedPane == FocusRight {
-			footerText = "[j/k] Navigate  [f] Fetch  [p] Pull  [s] Sync  [c] Clone  [o] Open  [e/E] Edit  [t] Term  [r] Refresh  [a] Add Repo  [?] Help  [Esc] Back"
-		} else {
-			footerText = "[Enter/l] Focus  [f] Fetch All  [p] Pull All  [s] Sync All  [e/E] Edit All  [t] Term All  [r] Refresh All  [a] Add  [?] Help"
-		}
-		footerText = truncate(footerText, innerRightWidth)
-		b.WriteString("\n")
-		b.WriteString(statusMutedStyle.Render(footerText))
+	k := m.keys
+	footer := hints(
+		hint("Focus", k.Focus), hint("Fetch All", k.Fetch), hint("Pull All", k.Pull), hint("Sync All", k.Sync),
+		hint("Edit All", k.Edit, k.EditPicker), hint("Term All", k.Terminal), hint("Refresh All", k.Refresh),
+		hint("Add", k.Add), hint("Help", k.Help),
+	)
+	if m.focusedPane == FocusRight {
+		footer = hints(
+			hint("Navigate", k.Down, k.Up), hint("Details", k.Details), hint("Mark", k.Mark), hint("Fetch", k.Fetch),
+			hint("Pull", k.Pull), hint("Sync", k.Sync), hint("Clone", k.Clone), hint("Commit", k.Commit),
+			hint("Open", k.Open),
+			hint("Edit", k.Edit, k.EditPicker), hint("Term", k.Terminal), hint("Refresh", k.Refresh),
+			hint("Add Repo", k.Add), hint("Help", k.Help), hint("Back", k.Back),
+		)
 	}
+	if len(m.marked) > 0 {
+		footer = hints(
+			"Marked:", hint("Fetch", k.Fetch), hint("Pull", k.Pull), hint("Sync", k.Sync), hint("Clone", k.Clone),
+			hint("Open", k.Open), hint("Clear", k.ClearMarks), hint("Mark", k.Mark), hint("Help", k.Help),
+		)
+	}
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(footer, innerRightWidth))
 
 	return b.String()
 }
 
+// renderFooter renders the current notification, or the key hint when there is none.
+func (m Model) renderFooter(hint string, width int) string {
+	if m.notification == "" {
+		return statusMutedStyle.Render(truncate(hint, width))
+	}
+	var prefix string
+	switch m.notificationType {
+	case NotifySuccess:
+		prefix = "✓ "
+	case NotifyError:
+		prefix = "✗ "
+	case NotifyWarn:
+		prefix = "⚠ "
+	}
+	return m.notificationStyle.Render(truncate(prefix+m.notification, width))
+}
+
 func (m Model) getRepoLines() (allLines []string, repoStarts, repoEnds []int) {
-	item, ok := m.list.SelectedItem().(ProjectItem)
-	if !ok {
+	refs := m.visibleRepos()
+	if len(refs) == 0 {
 		return nil, nil, nil
 	}
-	p := item.Project
 
-	totalPanesWidth := m.windowWidth - 4
-	leftPaneOuterWidth := totalPanesWidth / 4
-	if leftPaneOuterWidth < 20 {
-		leftPaneOuterWidth = 20
-	}
-	if leftPaneOuterWidth > 30 {
-		leftPaneOuterWidth = 30
-	}
-	rightPaneOuterWidth := totalPanesWidth - leftPaneOuterWidth
-	rightStyleWidth := rightPaneOuterWidth - 2
-	innerRightWidth := rightStyleWidth - 2
+	_, _, innerRightWidth, _ := m.paneSizes()
 
-	repoStarts = make([]int, len(p.Repos))
-	repoEnds = make([]int, len(p.Repos))
+	repoStarts = make([]int, len(refs))
+	repoEnds = make([]int, len(refs))
 
-	for i, r := range p.Repos {
+	for i, ref := range refs {
 		var repoLines []string
 
-		repoName, err := r.GetEffectivePath()
-		if err != nil {
-			repoName = r.URL
-		}
-		repoTitle := fmt.Sprintf("repo: %s", repoName)
+		repoTitle := fmt.Sprintf("repo: %s", m.markedLabel(ref, m.repoLabel(ref)))
 		repoTitle = truncate(repoTitle, innerRightWidth)
 
 		var titleLine string
@@ -306,8 +333,7 @@ func (m Model) getRepoLines() (allLines []string, repoStarts, repoEnds []int) {
 		}
 		repoLines = append(repoLines, titleLine)
 
-		key := p.Name + r.URL
-		status, exists := m.repoStatuses[key]
+		status, exists := m.repoStatuses[ref.Key()]
 
 		if !exists || status.Loading {
 			checkingStr := truncate("  [ Checking status... ]", innerRightWidth)
@@ -402,6 +428,14 @@ func (m Model) getRepoLines() (allLines []string, repoStarts, repoEnds []int) {
 			statusLine := fmt.Sprintf("  status: %s", statusText)
 			statusLine = truncate(statusLine, innerRightWidth)
 			repoLines = append(repoLines, statusColor.Render(statusLine))
+			if len(status.Worktrees) > 0 {
+				worktreeLine := fmt.Sprintf("  worktrees: %s", strings.Join(status.Worktrees, ", "))
+				repoLines = append(repoLines, statusMutedStyle.Render(truncate(worktreeLine, innerRightWidth)))
+			}
+			if reasons := attentionReasons(status); m.attentionSelected() && len(reasons) > 0 {
+				needsLine := fmt.Sprintf("  needs: %s", strings.Join(reasons, ", "))
+				repoLines = append(repoLines, attentionStyle(status).Render(truncate(needsLine, innerRightWidth)))
+			}
 			repoLines = append(repoLines, "")
 		}
 
@@ -456,38 +490,45 @@ func (m Model) renderFallbackScreen() string {
 }
 
 func (m Model) renderHelpModal() string {
-	var b strings.Builder
-	b.WriteString(projectNameStyle.Render("Keyboard Shortcuts"))
-	b.WriteString("\n\n")
-
 	keyStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
 	descStyle := lipgloss.NewStyle().Foreground(theme.Foreground)
 
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("?     "), descStyle.Render("Toggle Help Menu"))
-	fmt.Fprintf(&b, "  %s   %s\n\n", keyStyle.Render("q/Ctrl+C"), descStyle.Render("Quit Application"))
-
-	b.WriteString(statusMutedStyle.Render("Navigation:"))
-	b.WriteString("\n\n")
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("h/Left"), descStyle.Render("Focus Projects Pane (Left)"))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("l/Right"), descStyle.Render("Focus Repositories Pane (Right)"))
-	fmt.Fprintf(&b, "  %s   %s\n\n", keyStyle.Render("j/k/Up/Down"), descStyle.Render("Navigate Lists"))
+	// The help is generated from the key bindings, in two columns when they fit the terminal.
+	groups := m.keys.helpGroups()
+	renderGroups := func(groups []helpGroup) string {
+		keyWidth := 0
+		for _, g := range groups {
+			for _, kb := range g.Bindings {
+				keyWidth = max(keyWidth, lipgloss.Width(kb.Help().Key))
+			}
+		}
+		var b strings.Builder
+		for _, g := range groups {
+			b.WriteString(statusMutedStyle.Render(g.Title))
+			b.WriteString("\n\n")
+			for _, kb := range g.Bindings {
+				if kb.Enabled() {
+					key := keyStyle.Render(fmt.Sprintf("%-*s", keyWidth, kb.Help().Key))
+					fmt.Fprintf(&b, "  %s   %s\n", key, descStyle.Render(kb.Help().Desc))
+				}
+			}
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
 
-	b.WriteString(statusMutedStyle.Render("Actions (Context-aware):"))
-	b.WriteString("\n\n")
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("f     "), descStyle.Render("Fetch repository (or all)"))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("p     "), descStyle.Render("Pull repository (or all)"))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("s     "), descStyle.Render("Sync repository (or all)"))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("c     "), descStyle.Render("Clone/Setup repository (or all)"))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("o     "), descStyle.Render("Open in Finder / File Explorer"))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("e     "), descStyle.Render("Open in Configured Editor"))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("E     "), descStyle.Render("Choose Editor to Open in..."))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("t     "), descStyle.Render("Open in Terminal Window"))
-	fmt.Fprintf(&b, "  %s   %s\n", keyStyle.Render("r     "), descStyle.Render("Refresh repository statuses"))
-	fmt.Fprintf(&b, "  %s   %s\n\n", keyStyle.Render("a     "), descStyle.Render("Add project or repository"))
-
-	b.WriteString(statusMutedStyle.Render("Press any key to close"))
+	half := len(groups) / 2
+	body := lipgloss.JoinHorizontal(lipgloss.Top, renderGroups(groups[:half]), "    ", renderGroups(groups[half:]))
+	if lipgloss.Width(body)+helpModalStyle.GetHorizontalFrameSize() > m.windowWidth {
+		body = renderGroups(groups)
+	}
 
-	return b.String()
+	return lipgloss.JoinVertical(lipgloss.Left,
+		projectNameStyle.Render("Keyboard Shortcuts"),
+		"",
+		body,
+		statusMutedStyle.Render("Press any key to close"),
+	)
 }
 
 func renderProgressBar(width int, percentage float64) string {
@@ -508,7 +549,7 @@ func renderProgressBar(width int, percentage float64) string {
 	return progressBarFilledStyle.Render(filled) + progressBarTrackStyle.Render(empty)
 }
 
-func (m Model) renderRepoTable(p config.Project, innerRightWidth, H_repos int) string {
+func (m Model) renderRepoTable(refs []repoRef, innerRightWidth, H_repos int) string {
 	gap := "  "
 	gapWidth := len(gap)
 	totalGaps := 4
@@ -530,7 +571,11 @@ func (m Model) renderRepoTable(p config.Project, innerRightWidth, H_repos int) s
 
 	headerRepo := fmt.Sprintf("  %-*s", wRepo-2, "REPOSITORY")
 	headerBranch := fmt.Sprintf("%-*s", wBranch, "BRANCH")
-	headerStatus := fmt.Sprintf("%-*s", wStatus, "STATUS")
+	statusHeader := "STATUS"
+	if m.attentionSelected() {
+		statusHeader = "NEEDS"
+	}
+	headerStatus := fmt.Sprintf("%-*s", wStatus, statusHeader)
 	headerUpstream := fmt.Sprintf("%-*s", wUpstream, "UPSTREAM")
 	headerUpdated := fmt.Sprintf("%*s", wUpdated, "UPDATED")
 
@@ -549,18 +594,17 @@ func (m Model) renderRepoTable(p config.Project, innerRightWidth, H_repos int) s
 
 	start := m.repoScrollOffset
 	end := start + H_body
-	if end > len(p.Repos) {
-		end = len(p.Repos)
+	if end > len(refs) {
+		end = len(refs)
 	}
 
 	for idx := start; idx < end; idx++ {
-		r := p.Repos[idx]
-		key := p.Name + r.URL
-		status := m.repoStatuses[key]
+		ref := refs[idx]
+		status := m.repoStatuses[ref.Key()]
+		repoName := m.markedLabel(ref, m.repoLabel(ref))
 
-		repoName, err := r.GetEffectivePath()
-		if err != nil {
-			repoName = r.URL
+		if n := len(status.Worktrees); n > 0 {
+			repoName = fmt.Sprintf("%s +%d wt", repoName, n)
 		}
 
 		isSelected := m.focusedPane == FocusRight && idx == m.selectedRepoIndex
@@ -568,6 +612,9 @@ func (m Model) renderRepoTable(p config.Project, innerRightWidth, H_repos int) s
 		repoCell := renderRepoCell(repoName, isSelected, wRepo)
 		branchCell := renderBranchCell(status, isSelected, wBranch)
 		statusCell := renderStatusCell(status, isSelected, wStatus)
+		if m.attentionSelected() {
+			statusCell = renderAttentionCell(status, isSelected, wStatus)
+		}
 		upstreamCell := renderUpstreamCell(status, isSelected, wUpstream)
 		updatedCell := renderUpdatedCell(status, isSelected, wUpdated)
 
@@ -676,6 +723,27 @@ func renderStatusCell(status RepoStatus, isSelected bool, wStatus int) string {
 	return statusColor.Render(statusTextFormatted)
 }
 
+func renderAttentionCell(status RepoStatus, isSelected bool, wStatus int) string {
+	statusText := strings.Join(attentionReasons(status), ", ")
+	statusTextFormatted := fmt.Sprintf("%-*s", wStatus, truncate(statusText, wStatus-1))
+	if isSelected {
+		return selectedTableCellStyle.Render(statusTextFormatted)
+	}
+	return attentionStyle(status).Render(statusTextFormatted)
+}
+
+// attentionStyle colors a repository by how urgently it needs attention.
+func attentionStyle(status RepoStatus) lipgloss.Style {
+	switch severity := attentionSeverity(status); {
+	case severity >= 4:
+		return statusErrorStyle
+	case severity >= 2:
+		return statusWarningStyle
+	default:
+		return statusMutedStyle
+	}
+}
+
 func renderUpstreamCell(status RepoStatus, isSelected bool, wUpstream int) string {
 	var upstreamText string
 	upstreamColor := statusMutedStyle
@@ -718,7 +786,7 @@ func renderUpdatedCell(status RepoStatus, isSelected bool, wUpdated int) string
 	if !status.IsCloned || status.LastUpdated.IsZero() {
 		updatedText = "—"
 	} else {
-		updatedText = status.LastUpdated.Format("15:04:56")
+		updatedText = status.LastUpdated.Format("15:04:05")
 	}
 
 	updatedTextFormatted := fmt.Sprintf("%*s", wUpdated, truncate(updatedText, wUpdated))
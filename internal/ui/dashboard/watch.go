@@ -0,0 +1,316 @@
+package dashboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// watchDebounce is how long changes must settle before the affected repositories are refreshed.
+	watchDebounce = 500 * time.Millisecond
+	// watchMaxDelay bounds how long a steady stream of changes, such as a large checkout, postpones a refresh.
+	watchMaxDelay = 3 * time.Second
+	// watchPollInterval is how often repositories are checked when file events are unavailable.
+	watchPollInterval = 2 * time.Second
+)
+
+// repoChangedMsg reports the status keys of repositories whose files changed.
+type repoChangedMsg struct {
+	Keys []string
+}
+
+// repoWatcher watches the worktree root and git directory of the visible repositories and reports
+// changes, debounced, through waitCmd. It uses file events (inotify on Linux, kqueue on macOS) and
+// polls modification times for repositories that cannot be watched, for example when the inotify
+// watch limit is reached.
+type repoWatcher struct {
+	mu     sync.Mutex
+	repos  map[string]string // Status key -> repository path
+	dirs   map[string]string // Watched directory -> status key
+	polled map[string]string // Status key -> last polled signature
+
+	events       *fsnotify.Watcher // Nil when only polling is available
+	pollInterval time.Duration
+	changes      chan []string
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// newRepoWatcher starts a watcher that uses file events when the platform supports them.
+func newRepoWatcher() *repoWatcher {
+	events, err := fsnotify.NewWatcher()
+	if err != nil {
+		events = nil
+	}
+	return startRepoWatcher(events, watchPollInterval)
+}
+
+// startRepoWatcher starts the watcher loop. A nil events watcher polls every repository.
+func startRepoWatcher(events *fsnotify.Watcher, pollInterval time.Duration) *repoWatcher {
+	w := &repoWatcher{
+		repos:        make(map[string]string),
+		dirs:         make(map[string]string),
+		polled:       make(map[string]string),
+		events:       events,
+		pollInterval: pollInterval,
+		changes:      make(chan []string),
+		done:         make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Close stops the watcher.
+func (w *repoWatcher) Close() {
+	if w == nil {
+		return
+	}
+	w.closeOnce.Do(func() {
+		close(w.done)
+		if w.events != nil {
+			_ = w.events.Close()
+		}
+	})
+}
+
+// Set replaces the watched repositories with repos, a map of status key to repository path.
+func (w *repoWatcher) Set(repos map[string]string) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for key, path := range w.repos {
+		if repos[key] != path {
+			w.unwatchLocked(key)
+		}
+	}
+	for key, path := range repos {
+		if _, ok := w.repos[key]; !ok {
+			w.watchLocked(key, path)
+		}
+	}
+}
+
+// watchLocked starts watching a repository, falling back to polling when its directories cannot be watched.
+func (w *repoWatcher) watchLocked(key, path string) {
+	w.repos[key] = path
+	if w.events != nil {
+		var err error
+		for _, dir := range watchedDirs(path) {
+			if err = w.events.Add(dir); err != nil {
+				break
+			}
+			w.dirs[dir] = key
+		}
+		if err == nil {
+			return
+		}
+		w.unwatchDirsLocked(key)
+	}
+	w.polled[key] = pollSignature(path)
+}
+
+// unwatchLocked stops watching a repository.
+func (w *repoWatcher) unwatchLocked(key string) {
+	w.unwatchDirsLocked(key)
+	delete(w.polled, key)
+	delete(w.repos, key)
+}
+
+func (w *repoWatcher) unwatchDirsLocked(key string) {
+	for dir, k := range w.dirs {
+		if k == key {
+			_ = w.events.Remove(dir)
+			delete(w.dirs, dir)
+		}
+	}
+}
+
+// waitCmd blocks until repositories change and returns a repoChangedMsg. It returns nil once the watcher is closed.
+func (w *repoWatcher) waitCmd() tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		select {
+		case keys := <-w.changes:
+			return repoChangedMsg{Keys: keys}
+		case <-w.done:
+			return nil
+		}
+	}
+}
+
+// run collects file events and poll results and delivers them once they settle.
+func (w *repoWatcher) run() {
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	if w.events != nil {
+		events = w.events.Events
+		errs = w.events.Errors
+	}
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+
+	pending := make(map[string]bool)
+	var deadline time.Time
+	mark := func(keys ...string) {
+		if len(keys) == 0 {
+			return
+		}
+		for _, key := range keys {
+			pending[key] = true
+		}
+		if deadline.IsZero() {
+			deadline = time.Now().Add(watchMaxDelay)
+		}
+		debounce.Reset(min(watchDebounce, time.Until(deadline)))
+	}
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case ev, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if key := w.keyFor(ev); key != "" {
+				mark(key)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			// Events were dropped, so any repository may have changed.
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				mark(w.keys()...)
+			}
+		case <-ticker.C:
+			mark(w.poll()...)
+		case <-debounce.C:
+			keys := make([]string, 0, len(pending))
+			for key := range pending {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			select {
+			case w.changes <- keys:
+			case <-w.done:
+				return
+			}
+			pending = make(map[string]bool)
+			deadline = time.Time{}
+		}
+	}
+}
+
+// keyFor returns the status key of the repository an event belongs to, or "" if the event is ignored.
+func (w *repoWatcher) keyFor(ev fsnotify.Event) string {
+	// Lock files come and go around every git write; the write itself is reported separately.
+	if ev.Op == fsnotify.Chmod || strings.HasSuffix(ev.Name, ".lock") {
+		return ""
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if key, ok := w.dirs[filepath.Dir(ev.Name)]; ok {
+		return key
+	}
+	return w.dirs[ev.Name]
+}
+
+func (w *repoWatcher) keys() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	keys := make([]string, 0, len(w.repos))
+	for key := range w.repos {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// poll returns the polled repositories whose signature changed since the last poll.
+func (w *repoWatcher) poll() []string {
+	w.mu.Lock()
+	paths := make(map[string]string, len(w.polled))
+	for key := range w.polled {
+		paths[key] = w.repos[key]
+	}
+	w.mu.Unlock()
+
+	var changed []string
+	for key, path := range paths {
+		sig := pollSignature(path)
+		w.mu.Lock()
+		if last, ok := w.polled[key]; ok && last != sig {
+			w.polled[key] = sig
+			changed = append(changed, key)
+		}
+		w.mu.Unlock()
+	}
+	return changed
+}
+
+// gitDir returns the git directory of the repository at path, following the "gitdir:" file of linked worktrees.
+func gitDir(path string) string {
+	dotGit := filepath.Join(path, ".git")
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return dotGit
+	}
+	dir := strings.TrimSpace(strings.TrimPrefix(string(data), "gitdir:"))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(path, dir)
+	}
+	return dir
+}
+
+// watchedDirs returns the directories whose changes affect a repository's status: the worktree root
+// for added and removed files, the git directory for HEAD, index, and fetch updates, and the local
+// branch refs for commits.
+func watchedDirs(path string) []string {
+	dir := gitDir(path)
+	dirs := []string{path, dir}
+	if info, err := os.Stat(filepath.Join(dir, "refs", "heads")); err == nil && info.IsDir() {
+		dirs = append(dirs, filepath.Join(dir, "refs", "heads"))
+	}
+	return dirs
+}
+
+// pollSignature summarizes the modification times and sizes of the files that change with a repository's status.
+func pollSignature(path string) string {
+	dir := gitDir(path)
+	var b strings.Builder
+	for _, p := range []string{
+		path,
+		dir,
+		filepath.Join(dir, "HEAD"),
+		filepath.Join(dir, "index"),
+		filepath.Join(dir, "FETCH_HEAD"),
+		filepath.Join(dir, "packed-refs"),
+		filepath.Join(dir, "refs", "heads"),
+	} {
+		if info, err := os.Stat(p); err == nil {
+			fmt.Fprintf(&b, "%d:%d;", info.ModTime().UnixNano(), info.Size())
+		} else {
+			b.WriteString("-;")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// LiveBlock redraws a multi-line block of output in place, such as a progress table. When Out is not
+// a terminal, the block is printed only when its content changes, so logs stay readable.
+type LiveBlock struct {
+	mu    sync.Mutex
+	lines int
+	last  string
+}
+
+// NewLiveBlock creates an empty live block.
+func NewLiveBlock() *LiveBlock {
+	return &LiveBlock{}
+}
+
+// Update replaces the block with content.
+func (b *LiveBlock) Update(content string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if Out == nil {
+		return
+	}
+	content = strings.TrimSuffix(content, "\n")
+
+	if !isTerminal(Out) {
+		if content != b.last {
+			fmt.Fprintln(Out, content)
+		}
+		b.last = content
+		return
+	}
+
+	if b.lines > 0 {
+		// Move to the first line of the previous block and clear to the end of the screen.
+		fmt.Fprintf(Out, "\033[%dA\r\033[J", b.lines)
+	}
+	fmt.Fprintln(Out, content)
+	b.lines = strings.Count(content, "\n") + 1
+	b.last = content
+}
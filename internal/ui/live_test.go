@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLiveBlock(t *testing.T) {
+	var buf bytes.Buffer
+	oldOut := Out
+	Out = &buf
+	defer func() { Out = oldOut; forceTTY = nil }()
+
+	t.Run("Non-TTY prints changes only", func(t *testing.T) {
+		buf.Reset()
+		b := NewLiveBlock()
+		b.Update("a\nb")
+		b.Update("a\nb")
+		b.Update("a\nc")
+		if got, want := buf.String(), "a\nb\na\nc\n"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("TTY redraws in place", func(t *testing.T) {
+		buf.Reset()
+		trueVal := true
+		forceTTY = &trueVal
+		b := NewLiveBlock()
+		b.Update("a\nb")
+		b.Update("c")
+		if got := buf.String(); !strings.HasPrefix(got, "a\nb\n\033[2A\r\033[J") || !strings.HasSuffix(got, "c\n") {
+			t.Errorf("expected the second update to replace the first two lines, got %q", got)
+		}
+	})
+}